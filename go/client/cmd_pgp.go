@@ -28,8 +28,11 @@ func NewCmdPGP(cl *libcmdline.CommandLine, g *libkb.GlobalContext) cli.Command {
 			NewCmdPGPDrop(cl, g),
 			NewCmdPGPList(cl, g),
 			NewCmdPGPPurge(cl, g),
+			NewCmdPGPRevoke(cl, g),
 			NewCmdPGPPushPrivate(cl, g),
 			NewCmdPGPPullPrivate(cl, g),
+			NewCmdPGPPullRemote(cl, g),
+			NewCmdPGPPushRemote(cl, g),
 		},
 	}
 }
@@ -4,6 +4,7 @@
 package client
 
 import (
+	"bytes"
 	"fmt"
 	"os"
 	"strings"
@@ -41,12 +42,26 @@ func NewCmdPGPExport(cl *libcmdline.CommandLine, g *libkb.GlobalContext) cli.Com
 				Name:  "unencrypted",
 				Usage: "When exporting private keys, do not protect with a passphrase.",
 			},
+			cli.IntFlag{
+				Name:  "s2k-count",
+				Usage: "When exporting an encrypted private key, use this many passphrase-stretching iterations (1024-65011712, default 65536). Higher is slower to decrypt but more resistant to offline passphrase guessing.",
+			},
+			cli.BoolFlag{
+				Name:  "strong-cipher",
+				Usage: "When exporting an encrypted private key, encrypt the key packets with AES-256 instead of the default AES-128.",
+			},
+			cli.StringFlag{
+				Name:  "format",
+				Value: "pgp",
+				Usage: "Output format: pgp (armored OpenPGP key, the default), ssh (OpenSSH authorized_keys line), or kbx (GnuPG keybox; not yet supported).",
+			},
 		},
 		Description: `"keybase pgp export" exports public (and optionally private)
    PGP keys from Keybase, and into a file or to standard output.
    It doesn't access the GnuPG keychain at all. By default, when
    exporting private keys, you will be asked for passphrase to encrypt
-   the exported keys.`,
+   the exported keys. Pass --format=ssh to instead export the primary
+   key as an OpenSSH authorized_keys line.`,
 	}
 }
 
@@ -54,6 +69,7 @@ type CmdPGPExport struct {
 	UnixFilter
 	arg     keybase1.PGPExportArg
 	outfile string
+	format  string
 	libkb.Contextified
 }
 
@@ -64,7 +80,16 @@ func (s *CmdPGPExport) ParseArgv(ctx *cli.Context) error {
 	s.arg.Options.Secret = ctx.Bool("secret")
 	s.arg.Options.Query = ctx.String("query")
 	s.arg.Encrypted = !ctx.Bool("unencrypted")
+	s.arg.S2k.Iterations = ctx.Int("s2k-count")
+	s.arg.S2k.StrongCipher = ctx.Bool("strong-cipher")
 	s.outfile = ctx.String("outfile")
+	s.format = ctx.String("format")
+
+	switch s.format {
+	case "", "pgp", "ssh", "kbx":
+	default:
+		err = fmt.Errorf("unsupported --format %q (want pgp, ssh, or kbx)", s.format)
+	}
 
 	if nargs > 0 {
 		err = fmt.Errorf("export doesn't take args")
@@ -104,11 +129,16 @@ func (s *CmdPGPExport) finish(res []keybase1.KeyInfo, inErr error) error {
 		return fmt.Errorf("No matching keys found")
 	}
 
+	out, err := s.convert(res[0].Key)
+	if err != nil {
+		return err
+	}
+
 	snk := initSink(s.G(), s.outfile)
 	if err := snk.Open(); err != nil {
 		return err
 	}
-	_, err := snk.Write([]byte(strings.TrimSpace(res[0].Key)))
+	_, err = snk.Write([]byte(strings.TrimSpace(out)))
 	if err != nil {
 		return err
 	}
@@ -119,6 +149,35 @@ func (s *CmdPGPExport) finish(res []keybase1.KeyInfo, inErr error) error {
 	return snk.Close()
 }
 
+// convert re-renders an armored PGP key in the format requested by
+// --format. The server only ever hands back armored OpenPGP, so anything
+// other than the default "pgp" format is produced locally by reparsing
+// that armor.
+func (s *CmdPGPExport) convert(armored string) (string, error) {
+	switch s.format {
+	case "", "pgp":
+		return armored, nil
+	case "ssh":
+		bundle, _, err := libkb.ReadOneKeyFromString(armored)
+		if err != nil {
+			return "", err
+		}
+		return bundle.ExportSSHAuthorizedKey()
+	case "kbx":
+		bundle, _, err := libkb.ReadOneKeyFromString(armored)
+		if err != nil {
+			return "", err
+		}
+		var buf bytes.Buffer
+		if err := bundle.WriteKeybox(&buf); err != nil {
+			return "", err
+		}
+		return buf.String(), nil
+	default:
+		return "", fmt.Errorf("unsupported --format %q", s.format)
+	}
+}
+
 func (s *CmdPGPExport) GetUsage() libkb.Usage {
 	return libkb.Usage{
 		Config:    true,
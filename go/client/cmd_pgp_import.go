@@ -33,6 +33,10 @@ func NewCmdPGPImport(cl *libcmdline.CommandLine, g *libkb.GlobalContext) cli.Com
 				Name:  "push-secret",
 				Usage: "Push an encrypted copy of the secret key to the server.",
 			},
+			cli.BoolFlag{
+				Name:  "re-encrypt-local",
+				Usage: "If the key is passphrase-protected (e.g. an encrypted GPG export), decrypt it in memory and re-encrypt it under your Keybase passphrase before it touches the local keyring.",
+			},
 		},
 		Description: `"keybase pgp import" imports a PGP secret key for use with Keybase.
    It accepts that secret key via file (with the "--infile" flag) or
@@ -61,6 +65,7 @@ func (s *CmdPGPImport) ParseArgv(ctx *cli.Context) error {
 	}
 
 	s.arg.PushSecret = ctx.Bool("push-secret")
+	s.arg.ReEncryptLocal = ctx.Bool("re-encrypt-local")
 	s.infile = ctx.String("infile")
 	return nil
 }
@@ -18,6 +18,21 @@ func NewCmdPGPList(cl *libcmdline.CommandLine, g *libkb.GlobalContext) cli.Comma
 	return cli.Command{
 		Name:  "list",
 		Usage: "List the active PGP keys in your account.",
+		Flags: []cli.Flag{
+			cli.BoolFlag{
+				Name:  "v, verbose",
+				Usage: "Also fetch and show each key's subkeys, so encryption subkeys can be audited.",
+			},
+			cli.BoolFlag{
+				Name:  "audit",
+				Usage: "Also fetch and check each key for weak or ROCA-vulnerable RSA moduli, weak self-signature or subkey-binding hashes, expired subkeys, and a missing encryption subkey.",
+			},
+			cli.StringFlag{
+				Name:  "fingerprint-format",
+				Value: "plain",
+				Usage: "How to render PGP fingerprints: plain (ungrouped lowercase hex, the default), quads (uppercase, grouped by 2 bytes, like GPG's --fingerprint), or gpg (lowercase, grouped by 1 byte and colon-separated, like `gpg --with-colons`).",
+			},
+		},
 		Action: func(c *cli.Context) {
 			cl.ChooseCommand(&CmdPGPList{Contextified: libkb.NewContextified(g)}, "list", c)
 		},
@@ -25,6 +40,9 @@ func NewCmdPGPList(cl *libcmdline.CommandLine, g *libkb.GlobalContext) cli.Comma
 }
 
 type CmdPGPList struct {
+	verbose           bool
+	audit             bool
+	fingerprintFormat libkb.FingerprintFormatOptions
 	libkb.Contextified
 }
 
@@ -32,6 +50,19 @@ func (s *CmdPGPList) ParseArgv(ctx *cli.Context) error {
 	if len(ctx.Args()) > 0 {
 		return UnexpectedArgsError("pgp list")
 	}
+	s.verbose = ctx.Bool("verbose")
+	s.audit = ctx.Bool("audit")
+
+	switch format := ctx.String("fingerprint-format"); format {
+	case "", "plain":
+		s.fingerprintFormat = libkb.FingerprintFormatOptions{Lowercase: true, GroupBytes: 20}
+	case "quads":
+		s.fingerprintFormat = libkb.TerminalFingerprintFormat
+	case "gpg":
+		s.fingerprintFormat = libkb.GnuPGFingerprintFormat
+	default:
+		return fmt.Errorf("unsupported --fingerprint-format %q (want plain, quads, or gpg)", format)
+	}
 
 	return nil
 }
@@ -60,13 +91,23 @@ func (s *CmdPGPList) Run() error {
 		return err
 	}
 
+	var pgpCli keybase1.PGPClient
+	if s.verbose || s.audit {
+		if pgpCli, err = GetPGPClient(s.G()); err != nil {
+			return err
+		}
+	}
+
 	dui := s.G().UI.GetDumbOutputUI()
 	for _, key := range publicKeys {
 		if len(key.PGPFingerprint) == 0 {
 			continue
 		}
+		fingerprint := libkb.PGPFingerprintFromHexNoError(key.PGPFingerprint)
 		dui.Printf("Keybase Key ID:  %s\n", key.KID)
-		dui.Printf("PGP Fingerprint: %s\n", libkb.PGPFingerprintFromHexNoError(key.PGPFingerprint))
+		if fingerprint != nil {
+			dui.Printf("PGP Fingerprint: %s\n", fingerprint.FormatFingerprint(s.fingerprintFormat))
+		}
 		if len(key.PGPIdentities) > 0 {
 			dui.Printf("PGP Identities:\n")
 			for _, id := range key.PGPIdentities {
@@ -85,12 +126,77 @@ func (s *CmdPGPList) Run() error {
 				dui.Printf("   %s%s%s%s\n", revoked, id.Username, comment, email)
 			}
 		}
+		if (s.verbose || s.audit) && !key.IsRevoked && fingerprint != nil {
+			bundle, err := s.fetchBundle(pgpCli, *fingerprint)
+			if err != nil {
+				dui.Printf("   (failed to fetch key: %s)\n", err)
+			} else {
+				if s.verbose {
+					s.printSubkeys(dui, bundle)
+				}
+				if s.audit {
+					s.printAudit(dui, bundle)
+				}
+			}
+		}
 		dui.Printf("\n")
 	}
 
 	return nil
 }
 
+// fetchBundle fetches the full public key for fingerprint, so its subkeys
+// and signatures -- not just what LoadPublicKeys already returned -- can be
+// inspected.
+func (s *CmdPGPList) fetchBundle(pgpCli keybase1.PGPClient, fingerprint libkb.PGPFingerprint) (*libkb.PGPKeyBundle, error) {
+	res, err := pgpCli.PGPExportByFingerprint(context.TODO(), keybase1.PGPExportByFingerprintArg{
+		Options: keybase1.PGPQuery{Query: fingerprint.String(), ExactMatch: true},
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(res) == 0 {
+		return nil, nil
+	}
+	bundle, _, err := libkb.ReadOneKeyFromString(res[0].Key)
+	return bundle, err
+}
+
+// printSubkeys prints an audit line for each of bundle's subkeys, so e.g.
+// an unexpected encryption subkey doesn't go unnoticed.
+func (s *CmdPGPList) printSubkeys(dui libkb.DumbOutputUI, bundle *libkb.PGPKeyBundle) {
+	if bundle == nil {
+		return
+	}
+	subkeys := bundle.SubkeyInfo()
+	if len(subkeys) == 0 {
+		return
+	}
+	dui.Printf("Subkeys:\n")
+	for _, sub := range subkeys {
+		dui.Printf("   %s\n", sub)
+	}
+}
+
+// printAudit runs AuditKey against bundle and prints any problems it
+// finds, so a weak or ROCA-vulnerable RSA modulus, an expired or missing
+// encryption subkey, or a weak self-signature/binding-signature hash
+// doesn't go unnoticed.
+func (s *CmdPGPList) printAudit(dui libkb.DumbOutputUI, bundle *libkb.PGPKeyBundle) {
+	if bundle == nil {
+		return
+	}
+	problems := libkb.AuditKey(bundle).Problems()
+	if len(problems) == 0 {
+		dui.Printf("Audit: no issues found\n")
+		return
+	}
+	dui.Printf("Audit:\n")
+	for _, problem := range problems {
+		dui.Printf("   ! %s\n", problem)
+	}
+}
+
 func (s *CmdPGPList) GetUsage() libkb.Usage {
 	return libkb.Usage{
 		Config:    true,
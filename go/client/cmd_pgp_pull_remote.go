@@ -0,0 +1,64 @@
+// Copyright 2015 Keybase, Inc. All rights reserved. Use of
+// this source code is governed by the included BSD license.
+
+package client
+
+import (
+	"fmt"
+
+	"golang.org/x/net/context"
+
+	"github.com/keybase/cli"
+	"github.com/keybase/client/go/libcmdline"
+	"github.com/keybase/client/go/libkb"
+	keybase1 "github.com/keybase/client/go/protocol/keybase1"
+)
+
+type CmdPGPPullRemote struct {
+	libkb.Contextified
+	query string
+}
+
+func (v *CmdPGPPullRemote) ParseArgv(ctx *cli.Context) error {
+	if nargs := len(ctx.Args()); nargs != 1 {
+		return fmt.Errorf("pull-remote takes one argument: a fingerprint, key ID, or email address")
+	}
+	v.query = ctx.Args()[0]
+	return nil
+}
+
+func (v *CmdPGPPullRemote) Run() (err error) {
+	cli, err := GetPGPClient(v.G())
+	if err != nil {
+		return err
+	}
+	return cli.PGPPullRemote(context.TODO(), keybase1.PGPPullRemoteArg{
+		Query: v.query,
+	})
+}
+
+func NewCmdPGPPullRemote(cl *libcmdline.CommandLine, g *libkb.GlobalContext) cli.Command {
+	return cli.Command{
+		Name:         "pull-remote",
+		ArgumentHelp: "<fingerprint|key ID|email>",
+		Usage:        "Fetch a PGP key from the configured HKP keyserver.",
+		Flags:        []cli.Flag{},
+		Action: func(c *cli.Context) {
+			cl.ChooseCommand(&CmdPGPPullRemote{Contextified: libkb.NewContextified(g)}, "pull-remote", c)
+		},
+		Description: `"keybase pgp pull-remote" fetches a PGP key from an HKP
+   keyserver (https://keys.openpgp.org by default; override with
+   --pgp-key-server-uri or the "pgp.key_server_uri" config setting) and
+   imports it into your local GnuPG keyring. Unlike "keybase pgp pull", this
+   doesn't go through Keybase at all, so it works for anyone with a key on
+   the keyserver, tracked or not.`,
+	}
+}
+
+func (v *CmdPGPPullRemote) GetUsage() libkb.Usage {
+	return libkb.Usage{
+		Config:     true,
+		GpgKeyring: true,
+		API:        true,
+	}
+}
@@ -25,13 +25,18 @@ func NewCmdPGPPurge(cl *libcmdline.CommandLine, g *libkb.GlobalContext) cli.Comm
 				Name:  "p, purge",
 				Usage: "After export, purge keys from keyring",
 			},
+			cli.BoolFlag{
+				Name:  "local",
+				Usage: "Also drop locally cached public PGP keys, so they're refetched from the server next time they're needed",
+			},
 		},
 	}
 }
 
 type CmdPGPPurge struct {
 	libkb.Contextified
-	doPurge bool
+	doPurge      bool
+	doPurgeLocal bool
 }
 
 func (s *CmdPGPPurge) ParseArgv(ctx *cli.Context) error {
@@ -40,6 +45,7 @@ func (s *CmdPGPPurge) ParseArgv(ctx *cli.Context) error {
 	}
 
 	s.doPurge = ctx.Bool("purge")
+	s.doPurgeLocal = ctx.Bool("local")
 
 	return nil
 }
@@ -64,7 +70,8 @@ func (s *CmdPGPPurge) Run() error {
 	}
 
 	arg := keybase1.PGPPurgeArg{
-		DoPurge: s.doPurge,
+		DoPurge:           s.doPurge,
+		DoPurgeLocalCache: s.doPurgeLocal,
 	}
 
 	res, err := cli.PGPPurge(context.TODO(), arg)
@@ -73,6 +80,14 @@ func (s *CmdPGPPurge) Run() error {
 	}
 
 	dui := s.G().UI.GetDumbOutputUI()
+	if len(res.PurgedCacheKIDs) > 0 {
+		dui.Printf("Purged %d locally cached PGP public key(s):\n", len(res.PurgedCacheKIDs))
+		for _, kid := range res.PurgedCacheKIDs {
+			dui.Printf("   %s\n", kid)
+		}
+		dui.Printf("\n")
+	}
+
 	if len(res.Filenames) == 0 {
 		dui.Printf("No PGP keys found in local keyring\n")
 		return nil
@@ -0,0 +1,66 @@
+// Copyright 2015 Keybase, Inc. All rights reserved. Use of
+// this source code is governed by the included BSD license.
+
+package client
+
+import (
+	"fmt"
+
+	"golang.org/x/net/context"
+
+	"github.com/keybase/cli"
+	"github.com/keybase/client/go/libcmdline"
+	"github.com/keybase/client/go/libkb"
+	keybase1 "github.com/keybase/client/go/protocol/keybase1"
+)
+
+type CmdPGPPushRemote struct {
+	libkb.Contextified
+	query string
+}
+
+func (v *CmdPGPPushRemote) ParseArgv(ctx *cli.Context) error {
+	nargs := len(ctx.Args())
+	if nargs > 1 {
+		return fmt.Errorf("push-remote takes at most one argument: a fingerprint or key ID")
+	}
+	if nargs == 1 {
+		v.query = ctx.Args()[0]
+	}
+	return nil
+}
+
+func (v *CmdPGPPushRemote) Run() (err error) {
+	cli, err := GetPGPClient(v.G())
+	if err != nil {
+		return err
+	}
+	return cli.PGPPushRemote(context.TODO(), keybase1.PGPPushRemoteArg{
+		Query: v.query,
+	})
+}
+
+func NewCmdPGPPushRemote(cl *libcmdline.CommandLine, g *libkb.GlobalContext) cli.Command {
+	return cli.Command{
+		Name:         "push-remote",
+		ArgumentHelp: "[<fingerprint>]",
+		Usage:        "Publish your PGP key(s) to the configured HKP keyserver.",
+		Flags:        []cli.Flag{},
+		Action: func(c *cli.Context) {
+			cl.ChooseCommand(&CmdPGPPushRemote{Contextified: libkb.NewContextified(g)}, "push-remote", c)
+		},
+		Description: `"keybase pgp push-remote" publishes your active Keybase
+   PGP public key(s) to an HKP keyserver (https://keys.openpgp.org by
+   default; override with --pgp-key-server-uri or the "pgp.key_server_uri"
+   config setting). With no argument, all of your active keys are pushed;
+   with a fingerprint or key ID argument, only the matching key is pushed.`,
+	}
+}
+
+func (v *CmdPGPPushRemote) GetUsage() libkb.Usage {
+	return libkb.Usage{
+		Config:    true,
+		API:       true,
+		KbKeyring: true,
+	}
+}
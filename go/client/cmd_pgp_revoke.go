@@ -0,0 +1,120 @@
+// Copyright 2015 Keybase, Inc. All rights reserved. Use of
+// this source code is governed by the included BSD license.
+
+package client
+
+import (
+	"fmt"
+
+	"golang.org/x/net/context"
+
+	"github.com/keybase/cli"
+	"github.com/keybase/client/go/libcmdline"
+	"github.com/keybase/client/go/libkb"
+	keybase1 "github.com/keybase/client/go/protocol/keybase1"
+	"github.com/keybase/go-framed-msgpack-rpc/rpc"
+)
+
+var pgpRevocationReasons = map[string]int{
+	"unspecified": int(libkb.PGPRevocationReasonUnspecified),
+	"superseded":  int(libkb.PGPRevocationReasonKeySuperseded),
+	"compromised": int(libkb.PGPRevocationReasonKeyCompromised),
+	"retired":     int(libkb.PGPRevocationReasonKeyRetired),
+}
+
+func NewCmdPGPRevoke(cl *libcmdline.CommandLine, g *libkb.GlobalContext) cli.Command {
+	return cli.Command{
+		Name:         "revoke",
+		ArgumentHelp: "<fingerprint or query>",
+		Usage:        "Generate a standalone PGP revocation certificate",
+		Action: func(c *cli.Context) {
+			cl.ChooseCommand(&CmdPGPRevoke{Contextified: libkb.NewContextified(g)}, "revoke", c)
+		},
+		Flags: []cli.Flag{
+			cli.StringFlag{
+				Name:  "o, outfile",
+				Usage: "Specify an outfile (stdout by default).",
+			},
+			cli.IntFlag{
+				Name:  "subkey",
+				Value: -1,
+				Usage: "Revoke the subkey at this index instead of the primary key.",
+			},
+			cli.StringFlag{
+				Name:  "reason",
+				Value: "unspecified",
+				Usage: "Reason for the revocation: unspecified, superseded, compromised, or retired.",
+			},
+			cli.StringFlag{
+				Name:  "description",
+				Usage: "Free-form text describing the reason for the revocation.",
+			},
+		},
+		Description: `"keybase pgp revoke" generates a standalone, armored revocation
+   certificate for a PGP key (or one of its subkeys) without pushing
+   anything to the server. The certificate isn't stored anywhere by this
+   command; import it into the recipients' keyrings (or push it yourself
+   with "keybase pgp update") whenever you actually want to revoke the key.`,
+	}
+}
+
+type CmdPGPRevoke struct {
+	arg     keybase1.PGPGenRevokeArg
+	outfile string
+	libkb.Contextified
+}
+
+func (c *CmdPGPRevoke) ParseArgv(ctx *cli.Context) error {
+	nargs := len(ctx.Args())
+	if nargs != 1 {
+		return fmt.Errorf("revoke takes exactly one fingerprint or query argument")
+	}
+
+	reason, ok := pgpRevocationReasons[ctx.String("reason")]
+	if !ok {
+		return fmt.Errorf("unknown --reason %q", ctx.String("reason"))
+	}
+
+	c.arg.KeyQuery = ctx.Args()[0]
+	c.arg.SubkeyIndex = ctx.Int("subkey")
+	c.arg.Reason = reason
+	c.arg.Description = ctx.String("description")
+	c.outfile = ctx.String("outfile")
+	return nil
+}
+
+func (c *CmdPGPRevoke) Run() (err error) {
+	protocols := []rpc.Protocol{
+		NewSecretUIProtocol(c.G()),
+	}
+	if err = RegisterProtocolsWithContext(protocols, c.G()); err != nil {
+		return err
+	}
+
+	cli, err := GetPGPClient(c.G())
+	if err != nil {
+		return err
+	}
+
+	armored, err := cli.PGPGenRevoke(context.TODO(), c.arg)
+	if err != nil {
+		return err
+	}
+
+	snk := initSink(c.G(), c.outfile)
+	if err := snk.Open(); err != nil {
+		return err
+	}
+	if _, err := snk.Write([]byte(armored)); err != nil {
+		return err
+	}
+	return snk.Close()
+}
+
+func (c *CmdPGPRevoke) GetUsage() libkb.Usage {
+	return libkb.Usage{
+		Config:    true,
+		API:       true,
+		KbKeyring: true,
+	}
+}
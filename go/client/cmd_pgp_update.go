@@ -4,6 +4,9 @@
 package client
 
 import (
+	"fmt"
+	"time"
+
 	"github.com/keybase/cli"
 	"github.com/keybase/client/go/libcmdline"
 	"github.com/keybase/client/go/libkb"
@@ -15,12 +18,24 @@ import (
 type CmdPGPUpdate struct {
 	fingerprints []string
 	all          bool
+	extend       time.Duration
+	addUids      []string
+	revokeUids   []string
 	libkb.Contextified
 }
 
 func (v *CmdPGPUpdate) ParseArgv(ctx *cli.Context) error {
 	v.fingerprints = ctx.Args()
 	v.all = ctx.Bool("all")
+	if extend := ctx.String("extend"); extend != "" {
+		duration, err := time.ParseDuration(extend)
+		if err != nil {
+			return fmt.Errorf("bad --extend duration %q: %s", extend, err)
+		}
+		v.extend = duration
+	}
+	v.addUids = ctx.StringSlice("add-uid")
+	v.revokeUids = ctx.StringSlice("revoke-uid")
 	return nil
 }
 
@@ -40,6 +55,9 @@ func (v *CmdPGPUpdate) Run() (err error) {
 	return cli.PGPUpdate(context.TODO(), keybase1.PGPUpdateArg{
 		Fingerprints: v.fingerprints,
 		All:          v.all,
+		Extend:       int(v.extend / time.Second),
+		AddUids:      v.addUids,
+		RevokeUids:   v.revokeUids,
 	})
 }
 
@@ -53,6 +71,20 @@ func NewCmdPGPUpdate(cl *libcmdline.CommandLine, g *libkb.GlobalContext) cli.Com
 				Name:  "all",
 				Usage: "Update all available keys.",
 			},
+			cli.StringFlag{
+				Name:  "extend",
+				Usage: "Extend each key's expiration by this much (e.g. \"17520h\" for two years) and re-sign it, pulling the secret key from GPG to do so.",
+			},
+			cli.StringSliceFlag{
+				Name:  "add-uid",
+				Usage: "Add a PGP-style identity (e.g. \"Alice (work) <alice@example.com>\") to each key, pulling the secret key from GPG to do so.",
+				Value: &cli.StringSlice{},
+			},
+			cli.StringSliceFlag{
+				Name:  "revoke-uid",
+				Usage: "Revoke a UID on each key, pulling the secret key from GPG to do so.",
+				Value: &cli.StringSlice{},
+			},
 		},
 		Action: func(c *cli.Context) {
 			cl.ChooseCommand(&CmdPGPUpdate{Contextified: libkb.NewContextified(g)}, "update", c)
@@ -64,7 +96,15 @@ func NewCmdPGPUpdate(cl *libcmdline.CommandLine, g *libkb.GlobalContext) cli.Com
    and signatures, but cannot be used to change PGP primary keys.
 
    Only keys with the specified PGP fingerprints will be updated, unless the
-   '--all' flag is specified, in which case all PGP keys will be updated.`,
+   '--all' flag is specified, in which case all PGP keys will be updated.
+
+   With '--extend', instead of pushing whatever's already in GPG, the key's
+   expiration is pushed out by the given duration and the result re-signed
+   and pushed.
+
+   '--add-uid' and '--revoke-uid' add and revoke identities on the key
+   before pushing it, same as '--extend' re-signing it with the secret key
+   pulled from GPG rather than pushing whatever's already there.`,
 	}
 }
 
@@ -166,6 +166,46 @@ func (d *notificationDisplay) FSSyncStatusResponse(
 	return d.printf("KBFS sync status response: %+v\n", arg)
 }
 
+func (d *notificationDisplay) FSKeyGenerationRotated(
+	_ context.Context, arg keybase1.FSKeyGenerationRotatedArg) error {
+	return d.printf("KBFS key generation rotated: %+v\n", arg)
+}
+
+func (d *notificationDisplay) FSDirListProgress(
+	_ context.Context, arg keybase1.FSDirListProgressArg) error {
+	return d.printf("KBFS directory list progress: %+v\n", arg)
+}
+
+func (d *notificationDisplay) FSRemoteFileUpdated(
+	_ context.Context, arg keybase1.FSRemoteFileUpdatedArg) error {
+	return d.printf("KBFS remote file updated: %+v\n", arg)
+}
+
+func (d *notificationDisplay) FSSnapshotComplete(
+	_ context.Context, arg keybase1.FSSnapshotCompleteArg) error {
+	return d.printf("KBFS snapshot complete: %+v\n", arg)
+}
+
+func (d *notificationDisplay) FSFolderPathChanged(
+	_ context.Context, arg keybase1.FSFolderPathChangedArg) error {
+	return d.printf("KBFS folder path changed: %+v\n", arg)
+}
+
+func (d *notificationDisplay) FSOfflineEditsAtRisk(
+	_ context.Context, arg keybase1.FSOfflineEditsAtRiskArg) error {
+	return d.printf("KBFS offline edits at risk: %+v\n", arg)
+}
+
+func (d *notificationDisplay) FSStagedChangesCount(
+	_ context.Context, arg keybase1.FSStagedChangesCountArg) error {
+	return d.printf("KBFS staged changes count: %+v\n", arg)
+}
+
+func (d *notificationDisplay) FSForegroundFetchComplete(
+	_ context.Context, arg keybase1.FSForegroundFetchCompleteArg) error {
+	return d.printf("KBFS foreground fetch complete: %+v\n", arg)
+}
+
 func (d *notificationDisplay) TrackingChanged(_ context.Context, arg keybase1.TrackingChangedArg) error {
 	return d.printf("Tracking changed for %s (%s)\n", arg.Username, arg.Uid)
 }
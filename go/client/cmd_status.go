@@ -140,6 +140,8 @@ type jsonStatus struct {
 	PlatformInfo           keybase1.PlatformInfo
 	OSVersion              string
 	DeviceEKNames          []string
+	LocalDbDegraded        bool   `json:",omitempty"`
+	LocalDbDegradedReason  string `json:",omitempty"`
 	LocalDbStats           []string
 	LocalChatDbStats       []string
 	LocalBlockCacheDbStats []string `json:",omitempty"`
@@ -175,6 +177,8 @@ func (c *CmdStatus) outputJSON(fstatus *keybase1.FullStatus) error {
 	status.PlatformInfo = fstatus.ExtStatus.PlatformInfo
 	status.OSVersion = fstatus.ExtStatus.PlatformInfo.OsVersion
 	status.DeviceEKNames = fstatus.ExtStatus.DeviceEkNames
+	status.LocalDbDegraded = fstatus.ExtStatus.LocalDbDegraded
+	status.LocalDbDegradedReason = fstatus.ExtStatus.LocalDbDegradedReason
 	status.LocalDbStats = fstatus.ExtStatus.LocalDbStats
 	status.LocalChatDbStats = fstatus.ExtStatus.LocalChatDbStats
 	status.LocalBlockCacheDbStats = fstatus.ExtStatus.LocalBlockCacheDbStats
@@ -306,6 +310,9 @@ func (c *CmdStatus) outputTerminal(status *keybase1.FullStatus) error {
 	}
 	dui.Printf("Known DeviceEKs:\n")
 	dui.Printf("    %s \n", strings.Join(extStatus.DeviceEkNames, "\n    "))
+	if extStatus.LocalDbDegraded {
+		dui.Printf("LocalDb: DEGRADED (%s)\n", extStatus.LocalDbDegradedReason)
+	}
 	dui.Printf("LocalDbStats:\n%s \n", strings.Join(extStatus.LocalDbStats, "\n"))
 	dui.Printf("LocalChatDbStats:\n%s \n", strings.Join(extStatus.LocalChatDbStats, "\n"))
 	dui.Printf("LocalBlockCacheDbStats:\n%s \n", strings.Join(extStatus.LocalBlockCacheDbStats, "\n"))
@@ -535,7 +535,7 @@ func (ui *BaseIdentifyUI) DisplayStellarAccount(_ libkb.MetaContext, l keybase1.
 func (ui *BaseIdentifyUI) DisplayKey(_ libkb.MetaContext, key keybase1.IdentifyKey) error {
 	var fpq string
 	if fp := libkb.ImportPGPFingerprintSlice(key.PGPFingerprint); fp != nil {
-		fpq = fp.ToQuads()
+		fpq = fp.FormatFingerprint(libkb.TerminalFingerprintFormat)
 	}
 	if key.TrackDiff != nil {
 		mark := CHECK
@@ -205,7 +205,7 @@ func (e *GPGImportKeyEngine) Run(mctx libkb.MetaContext) (err error) {
 
 	tty, err := mctx.UIs().GPGUI.GetTTY(mctx.Ctx())
 	if err != nil {
-		mctx.Warning("error getting TTY for GPG: %s", err)
+		mctx.Warningw("error getting TTY for GPG", "kid", selected.GetFingerprint().ToKeyID(), "err", err)
 		err = nil
 	}
 
@@ -243,7 +243,7 @@ func (e *GPGImportKeyEngine) Run(mctx libkb.MetaContext) (err error) {
 		}
 	}
 
-	mctx.Debug("Bundle unlocked: %s", selected.GetFingerprint().ToKeyID())
+	mctx.Debugw("Bundle unlocked", "kid", selected.GetFingerprint().ToKeyID())
 
 	eng := NewPGPKeyImportEngine(mctx.G(), PGPKeyImportEngineArg{
 		Pregen:      bundle,
@@ -267,7 +267,7 @@ func (e *GPGImportKeyEngine) Run(mctx libkb.MetaContext) (err error) {
 		return
 	}
 
-	mctx.Debug("Key %s imported", selected.GetFingerprint().ToKeyID())
+	mctx.Debugw("Key imported", "kid", selected.GetFingerprint().ToKeyID())
 
 	e.last = bundle
 
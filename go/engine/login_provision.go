@@ -1130,6 +1130,17 @@ func (e *loginProvision) gpgImportKey(m libkb.MetaContext, fp *libkb.PGPFingerpr
 		return nil, err
 	}
 
+	if bundle.IsStubbedSecretKey() {
+		// gpg only gave us a stub: the real key lives on a smart card (a
+		// YubiKey, say), so there's nothing here for Unlock to decrypt.
+		// Sign through gpg-agent/scdaemon instead of giving up.
+		m.Debug("gpgImportKey: %s is a stubbed secret key, wiring up gpg as a signing fallback", fp)
+		if err := bundle.InitGPGKey(m); err != nil {
+			return nil, err
+		}
+		return bundle, nil
+	}
+
 	// unlock it
 	if err := bundle.Unlock(m, "sign new device", m.UIs().SecretUI); err != nil {
 		return nil, err
@@ -110,13 +110,14 @@ func (e *PGPEncrypt) Run(m libkb.MetaContext) error {
 		signer = mykey
 	}
 
-	usernames, err := e.verifyUsers(m, e.arg.Recips, ok)
+	usernames, emails, err := e.verifyUsers(m, e.arg.Recips, ok)
 	if err != nil {
 		return err
 	}
 
 	kfarg := &PGPKeyfinderArg{
 		Usernames: usernames,
+		Emails:    emails,
 	}
 
 	kf := NewPGPKeyfinder(e.G(), kfarg)
@@ -213,8 +214,11 @@ func (e *PGPEncrypt) loadSelfKey() (*libkb.PGPKeyBundle, error) {
 	return keys[0], nil
 }
 
-func (e *PGPEncrypt) verifyUsers(m libkb.MetaContext, assertions []string, loggedIn bool) ([]string, error) {
-	var names []string
+// verifyUsers resolves assertions to Keybase usernames by identifying each
+// one. A bare email address that doesn't resolve to a Keybase user is
+// returned in emails instead of failing outright, so the caller can fall
+// back to a Web Key Directory lookup.
+func (e *PGPEncrypt) verifyUsers(m libkb.MetaContext, assertions []string, loggedIn bool) (names []string, emails []string, err error) {
 	for _, userAssert := range assertions {
 		arg := keybase1.Identify2Arg{
 			UserAssertion: userAssert,
@@ -226,15 +230,20 @@ func (e *PGPEncrypt) verifyUsers(m libkb.MetaContext, assertions []string, logge
 		}
 		eng := NewResolveThenIdentify2(e.G(), &arg)
 		if err := RunEngine2(m, eng); err != nil {
-			return nil, libkb.IdentifyFailedError{Assertion: userAssert, Reason: err.Error()}
+			if libkb.CheckEmail.F(userAssert) {
+				m.Debug("verifyUsers: %s is not a Keybase user, trying WKD: %s", userAssert, err)
+				emails = append(emails, userAssert)
+				continue
+			}
+			return nil, nil, libkb.IdentifyFailedError{Assertion: userAssert, Reason: err.Error()}
 		}
 		res, err := eng.Result(m)
 		if err != nil {
-			return nil, err
+			return nil, nil, err
 		}
 		names = append(names, res.Upk.GetName())
 	}
-	return names, nil
+	return names, emails, nil
 }
 
 // keyset maintains a set of pgp keys, preserving insertion order.
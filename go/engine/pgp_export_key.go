@@ -10,7 +10,6 @@ package engine
 
 import (
 	"bytes"
-	"errors"
 	"fmt"
 
 	"github.com/keybase/client/go/kbcrypto"
@@ -31,6 +30,7 @@ type PGPKeyExportEngine struct {
 	libkb.Contextified
 	arg       keybase1.PGPQuery
 	encrypted bool
+	s2k       keybase1.PGPS2KOptions
 	qtype     queryType
 	res       []keybase1.KeyInfo
 	me        *libkb.User
@@ -65,6 +65,7 @@ func NewPGPKeyExportEngine(g *libkb.GlobalContext, arg keybase1.PGPExportArg) *P
 		arg:          arg.Options,
 		qtype:        either,
 		encrypted:    arg.Encrypted,
+		s2k:          arg.S2k,
 		Contextified: libkb.NewContextified(g),
 	}
 }
@@ -74,6 +75,7 @@ func NewPGPKeyExportByKIDEngine(g *libkb.GlobalContext, arg keybase1.PGPExportBy
 		arg:          arg.Options,
 		qtype:        kid,
 		encrypted:    arg.Encrypted,
+		s2k:          arg.S2k,
 		Contextified: libkb.NewContextified(g),
 	}
 }
@@ -83,6 +85,7 @@ func NewPGPKeyExportByFingerprintEngine(g *libkb.GlobalContext, arg keybase1.PGP
 		arg:          arg.Options,
 		qtype:        fingerprint,
 		encrypted:    arg.Encrypted,
+		s2k:          arg.S2k,
 		Contextified: libkb.NewContextified(g),
 	}
 }
@@ -182,19 +185,23 @@ func (e *PGPKeyExportEngine) exportSecret(m libkb.MetaContext) error {
 	return nil
 }
 
-func GetPGPExportPassphrase(m libkb.MetaContext, ui libkb.SecretUI, desc string) (keybase1.GetPassphraseRes, error) {
-	pRes, err := libkb.GetSecret(m, ui, "PGP key passphrase", desc, "", false)
+// GetPGPExportPassphrase prompts for a passphrase to protect an exported PGP
+// key, with confirmation, and estimates its strength. If refuseWeak is true,
+// a passphrase scoring below libkb.MinStrongPassphraseStrength is rejected
+// with an error instead of just a logged warning.
+func GetPGPExportPassphrase(m libkb.MetaContext, ui libkb.SecretUI, desc string, refuseWeak bool) (keybase1.GetPassphraseRes, error) {
+	pRes, strength, err := libkb.GetNewPassphraseWithConfirmation(m, ui, "PGP key passphrase", desc,
+		"Please reenter your passphrase for confirmation")
 	if err != nil {
 		return keybase1.GetPassphraseRes{}, err
 	}
 
-	desc = "Please reenter your passphrase for confirmation"
-	pRes2, err := libkb.GetSecret(m, ui, "PGP key passphrase", desc, "", false)
-	if err != nil {
-		return keybase1.GetPassphraseRes{}, err
-	}
-	if pRes.Passphrase != pRes2.Passphrase {
-		return keybase1.GetPassphraseRes{}, errors.New("Passphrase mismatch")
+	if strength < libkb.MinStrongPassphraseStrength {
+		_, hint := libkb.EstimatePassphraseStrength(pRes.Passphrase)
+		if refuseWeak {
+			return keybase1.GetPassphraseRes{}, fmt.Errorf("passphrase is too weak (%s): %s", strength, hint)
+		}
+		m.UIs().LogUI.Warning("Your PGP key passphrase is %s: %s", strength, hint)
 	}
 
 	return pRes, nil
@@ -211,12 +218,16 @@ func (e *PGPKeyExportEngine) encryptKey(m libkb.MetaContext, raw []byte) ([]byte
 	}
 
 	desc := "Enter passphrase to protect your PGP key. Secure passphrases have at least 8 characters."
-	pRes, err := GetPGPExportPassphrase(m, m.UIs().SecretUI, desc)
+	pRes, err := GetPGPExportPassphrase(m, m.UIs().SecretUI, desc, false)
 	if err != nil {
 		return nil, err
 	}
 
-	if err = libkb.EncryptPGPKey(entity.Entity, pRes.Passphrase); err != nil {
+	opts := libkb.PGPEncryptOptions{
+		S2KCount:     e.s2k.Iterations,
+		StrongCipher: e.s2k.StrongCipher,
+	}
+	if err = libkb.EncryptPGPKeyWithOptions(entity.Entity, pRes.Passphrase, opts); err != nil {
 		return nil, err
 	}
 
@@ -10,6 +10,7 @@ package engine
 
 import (
 	"bytes"
+	"encoding/hex"
 	"errors"
 	"strings"
 
@@ -42,6 +43,13 @@ type PGPKeyImportEngineArg struct {
 	GPGFallback      bool
 	PreloadTsec      libkb.Triplesec
 	PreloadStreamGen libkb.PassphraseGeneration
+	// ReEncryptWithPassphraseStream re-encrypts an unlocked Pregen key under
+	// a passphrase derived from the Keybase passphrase stream, instead of
+	// leaving it decrypted in memory until saveKey's LKS wrap. It's applied
+	// right after unlock and before push, so it's only safe to combine with
+	// OnlySave -- push signs a sibkey delegation with the bundle itself,
+	// which needs it to still be unlocked.
+	ReEncryptWithPassphraseStream bool
 }
 
 func NewPGPKeyImportEngineFromBytes(g *libkb.GlobalContext, key []byte, pushPrivate bool) (eng *PGPKeyImportEngine, err error) {
@@ -67,6 +75,13 @@ func NewPGPKeyImportEngineFromBytes(g *libkb.GlobalContext, key []byte, pushPriv
 	return
 }
 
+// SetReEncryptWithPassphraseStream opts an already-constructed engine into
+// re-protecting an unlocked Pregen key under the Keybase passphrase stream
+// before it's saved; see PGPKeyImportEngineArg.ReEncryptWithPassphraseStream.
+func (e *PGPKeyImportEngine) SetReEncryptWithPassphraseStream(v bool) {
+	e.arg.ReEncryptWithPassphraseStream = v
+}
+
 func (e *PGPKeyImportEngine) loadMe(m libkb.MetaContext) (err error) {
 	if e.me = e.arg.Me; e.me != nil {
 		return
@@ -212,6 +227,10 @@ func (e *PGPKeyImportEngine) Run(m libkb.MetaContext) (err error) {
 		return err
 	}
 
+	if err = e.reEncryptWithPassphraseStream(m); err != nil {
+		return err
+	}
+
 	if err := e.checkExistingKey(m); err != nil {
 		return err
 	}
@@ -288,7 +307,7 @@ func (e *PGPKeyImportEngine) exportToGPG(m libkb.MetaContext) (err error) {
 	if e.arg.ExportEncrypted {
 		m.Debug("Encrypting key with passphrase before exporting")
 		desc := "Exporting key to GPG keychain. Enter passphrase to protect the key. Secure passphrases have at least 8 characters."
-		pRes, err := GetPGPExportPassphrase(m, m.UIs().SecretUI, desc)
+		pRes, err := GetPGPExportPassphrase(m, m.UIs().SecretUI, desc, false)
 		if err != nil {
 			return err
 		}
@@ -320,6 +339,30 @@ func (e *PGPKeyImportEngine) unlock(m libkb.MetaContext) (err error) {
 	return err
 }
 
+// reEncryptWithPassphraseStream re-protects an imported key's decrypted
+// private key material (e.g. from a passphrase-protected GPG export) under a
+// passphrase derived from the user's Keybase passphrase stream, instead of
+// leaving it sitting around unencrypted until saveLKS wraps it. It's a
+// no-op unless the caller opted in, since most importers either generated
+// the key themselves (never encrypted) or want LKS's own encryption to be
+// the only layer.
+func (e *PGPKeyImportEngine) reEncryptWithPassphraseStream(m libkb.MetaContext) (err error) {
+	defer m.Trace("PGPKeyImportEngine::reEncryptWithPassphraseStream", &err)()
+	if !e.arg.ReEncryptWithPassphraseStream || e.bundle == nil || !e.bundle.HasSecretKey() {
+		return nil
+	}
+	pps, err := libkb.GetPassphraseStreamStored(m)
+	if err != nil {
+		return err
+	}
+	passphrase := hex.EncodeToString(pps.PWHash())
+	if err := libkb.EncryptPGPKeyWithOptions(e.bundle.Entity, passphrase, libkb.PGPEncryptOptions{StrongCipher: true}); err != nil {
+		return err
+	}
+	e.bundle.Invalidate()
+	return nil
+}
+
 func (e *PGPKeyImportEngine) loadDelegator(m libkb.MetaContext) (err error) {
 
 	e.del = &libkb.Delegator{
@@ -299,6 +299,30 @@ func testImportKey(t *testing.T, which string, armor string, pp string) {
 	}
 }
 
+// TestPGPImportReEncryptWithPassphraseStream imports an unencrypted PGP
+// key with ReEncryptWithPassphraseStream set, and checks that the engine's
+// in-memory bundle ends up re-encrypted rather than sitting around
+// decrypted until LKS wraps it.
+func TestPGPImportReEncryptWithPassphraseStream(t *testing.T) {
+	tc := SetupEngineTest(t, "pgpimportreencrypt")
+	defer tc.Cleanup()
+
+	u := CreateAndSignupFakeUser(tc, "login")
+	secui := &libkb.TestSecretUI{Passphrase: u.Passphrase}
+	uis := libkb.UIs{LogUI: tc.G.UI.GetLogUI(), SecretUI: secui}
+
+	_, _, key := genPGPKeyAndArmor(t, tc, u.Email)
+	eng, err := NewPGPKeyImportEngineFromBytes(tc.G, []byte(key), false)
+	require.NoError(t, err)
+	eng.arg.OnlySave = true
+	eng.SetReEncryptWithPassphraseStream(true)
+
+	m := NewMetaContextForTest(tc).WithUIs(uis)
+	require.NoError(t, RunEngine2(m, eng))
+
+	require.Error(t, eng.bundle.CheckSecretKey(), "the in-memory bundle should come out re-encrypted")
+}
+
 // Issue CORE-2063: check that generated secret key is exported
 // to user's GPG keyring.
 func TestPGPImportGPGExport(t *testing.T) {
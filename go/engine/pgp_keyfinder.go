@@ -18,6 +18,7 @@ type PGPKeyfinder struct {
 
 type PGPKeyfinderArg struct {
 	Usernames []string // must be keybase usernames
+	Emails    []string // looked up via Web Key Directory, bypassing Keybase entirely
 }
 
 // NewPGPKeyfinder creates a PGPKeyfinder engine.
@@ -52,6 +53,7 @@ func (e *PGPKeyfinder) SubConsumers() []libkb.UIConsumer {
 func (e *PGPKeyfinder) Run(m libkb.MetaContext) error {
 	e.loadUsers(m)
 	e.loadKeys(m)
+	e.loadEmailKeys(m)
 	return e.runerr
 }
 
@@ -98,6 +100,27 @@ func (e *PGPKeyfinder) loadKeys(m libkb.MetaContext) {
 	}
 }
 
+// loadEmailKeys resolves e.arg.Emails via Web Key Directory. These
+// recipients have no Keybase user, so User is left nil on their
+// UserPlusKeys; callers that only look at Keys (like PGPEncrypt) are
+// unaffected, but callers that assume User is always set should not pass
+// Emails.
+func (e *PGPKeyfinder) loadEmailKeys(m libkb.MetaContext) {
+	if e.runerr != nil {
+		return
+	}
+
+	wkd := libkb.NewWKDClient(e.G())
+	for _, email := range e.arg.Emails {
+		bundle, err := wkd.Fetch(m, email)
+		if err != nil {
+			e.runerr = err
+			return
+		}
+		e.uplus = append(e.uplus, &UserPlusKeys{Keys: []*libkb.PGPKeyBundle{bundle}})
+	}
+}
+
 type UserPlusKeys struct {
 	User      *libkb.User
 	IsTracked bool
@@ -0,0 +1,67 @@
+// Copyright 2015 Keybase, Inc. All rights reserved. Use of
+// this source code is governed by the included BSD license.
+
+package engine
+
+import (
+	"github.com/keybase/client/go/libkb"
+)
+
+type PGPPullRemoteEngineArg struct {
+	Query string
+}
+
+// PGPPullRemoteEngine fetches a PGP key from the configured HKP keyserver
+// and imports it into the local GnuPG keyring. Unlike PGPPullEngine, it
+// doesn't go through Keybase at all, so it works for anyone with a key on
+// the keyserver, tracked or not.
+type PGPPullRemoteEngine struct {
+	libkb.Contextified
+	query     string
+	gpgClient *libkb.GpgCLI
+}
+
+func NewPGPPullRemoteEngine(g *libkb.GlobalContext, arg *PGPPullRemoteEngineArg) *PGPPullRemoteEngine {
+	return &PGPPullRemoteEngine{
+		query:        arg.Query,
+		Contextified: libkb.NewContextified(g),
+	}
+}
+
+func (e *PGPPullRemoteEngine) Name() string {
+	return "PGPPullRemote"
+}
+
+func (e *PGPPullRemoteEngine) Prereqs() Prereqs {
+	return Prereqs{}
+}
+
+func (e *PGPPullRemoteEngine) RequiredUIs() []libkb.UIKind {
+	return []libkb.UIKind{
+		libkb.LogUIKind,
+	}
+}
+
+func (e *PGPPullRemoteEngine) SubConsumers() []libkb.UIConsumer {
+	return nil
+}
+
+func (e *PGPPullRemoteEngine) Run(m libkb.MetaContext) (err error) {
+	defer m.Trace("PGPPullRemoteEngine::Run", &err)()
+
+	armored, err := libkb.NewHKPClient(e.G()).Fetch(m, e.query)
+	if err != nil {
+		return err
+	}
+
+	e.gpgClient = libkb.NewGpgCLI(e.G(), m.UIs().LogUI)
+	if err = e.gpgClient.Configure(m); err != nil {
+		return err
+	}
+	if err = e.gpgClient.ExportKeyArmored(m, armored); err != nil {
+		return err
+	}
+
+	m.Info("Imported key matching %q into your GnuPG keyring.", e.query)
+	return nil
+}
@@ -16,9 +16,10 @@ import (
 // PGPPurge is an engine.
 type PGPPurge struct {
 	libkb.Contextified
-	arg       keybase1.PGPPurgeArg
-	me        *libkb.User
-	filenames []string
+	arg             keybase1.PGPPurgeArg
+	me              *libkb.User
+	filenames       []string
+	purgedCacheKIDs []keybase1.KID
 }
 
 // NewPGPPurge creates a PGPPurge engine.
@@ -88,6 +89,30 @@ func (e *PGPPurge) Run(m libkb.MetaContext) error {
 		}
 	}
 
+	if e.arg.DoPurgeLocalCache {
+		if err := e.purgeLocalCache(m); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// purgeLocalCache drops every PGP public key this user has cached in the
+// local key-value store, so LoadPublicKeys and friends refetch from the
+// server next time they're needed.
+func (e *PGPPurge) purgeLocalCache(m libkb.MetaContext) error {
+	kids, err := e.G().ListStoredPGPKeys(m)
+	if err != nil {
+		return err
+	}
+	for _, kid := range kids {
+		if err := e.G().DeleteStoredPGPKey(m, kid); err != nil {
+			m.Debug("| PGPPurge: failed to delete cached key %s: %s", kid, err)
+			continue
+		}
+		e.purgedCacheKIDs = append(e.purgedCacheKIDs, kid)
+	}
 	return nil
 }
 
@@ -96,6 +121,12 @@ func (e *PGPPurge) KeyFiles() []string {
 	return e.filenames
 }
 
+// PurgedCacheKIDs returns the KIDs of the locally cached public keys that
+// were purged, if DoPurgeLocalCache was set.
+func (e *PGPPurge) PurgedCacheKIDs() []keybase1.KID {
+	return e.purgedCacheKIDs
+}
+
 func (e *PGPPurge) exportBlocks(m libkb.MetaContext, blocks []*libkb.SKB) error {
 	sstore := libkb.NewSecretStore(m, e.me.GetNormalizedName())
 	promptArg := libkb.SecretKeyPromptArg{
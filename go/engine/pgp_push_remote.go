@@ -0,0 +1,83 @@
+// Copyright 2015 Keybase, Inc. All rights reserved. Use of
+// this source code is governed by the included BSD license.
+
+package engine
+
+import (
+	"github.com/keybase/client/go/libkb"
+)
+
+type PGPPushRemoteEngineArg struct {
+	Query string
+}
+
+// PGPPushRemoteEngine publishes the caller's own active PGP public key(s) to
+// the configured HKP keyserver. If Query is non-empty, only keys matching it
+// (by fingerprint, KID, or key ID, per libkb.KeyMatchesQuery) are pushed.
+type PGPPushRemoteEngine struct {
+	libkb.Contextified
+	query string
+	me    *libkb.User
+}
+
+func NewPGPPushRemoteEngine(g *libkb.GlobalContext, arg *PGPPushRemoteEngineArg) *PGPPushRemoteEngine {
+	return &PGPPushRemoteEngine{
+		query:        arg.Query,
+		Contextified: libkb.NewContextified(g),
+	}
+}
+
+func (e *PGPPushRemoteEngine) Name() string {
+	return "PGPPushRemote"
+}
+
+func (e *PGPPushRemoteEngine) Prereqs() Prereqs {
+	return Prereqs{
+		Device: true,
+	}
+}
+
+func (e *PGPPushRemoteEngine) RequiredUIs() []libkb.UIKind {
+	return []libkb.UIKind{
+		libkb.LogUIKind,
+	}
+}
+
+func (e *PGPPushRemoteEngine) SubConsumers() []libkb.UIConsumer {
+	return nil
+}
+
+func (e *PGPPushRemoteEngine) loadMe(m libkb.MetaContext) (err error) {
+	e.me, err = libkb.LoadMe(libkb.NewLoadUserArgWithMetaContext(m).WithPublicKeyOptional())
+	return err
+}
+
+func (e *PGPPushRemoteEngine) Run(m libkb.MetaContext) (err error) {
+	defer m.Trace("PGPPushRemoteEngine::Run", &err)()
+
+	if err = e.loadMe(m); err != nil {
+		return err
+	}
+
+	hkp := libkb.NewHKPClient(e.G())
+	var pushed int
+	for _, bundle := range e.me.GetActivePGPKeys(false) {
+		if e.query != "" && !libkb.KeyMatchesQuery(bundle, e.query, false) {
+			continue
+		}
+		armored, err := bundle.Encode()
+		if err != nil {
+			return err
+		}
+		if err := hkp.Publish(m, armored); err != nil {
+			return err
+		}
+		m.Info("Published key %s.", bundle.GetFingerprint())
+		pushed++
+	}
+
+	if pushed == 0 {
+		return libkb.NoKeyError{Msg: "no matching PGP keys to push"}
+	}
+	return nil
+}
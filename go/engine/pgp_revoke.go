@@ -0,0 +1,102 @@
+// Copyright 2015 Keybase, Inc. All rights reserved. Use of
+// this source code is governed by the included BSD license.
+
+package engine
+
+import (
+	"fmt"
+
+	"github.com/keybase/client/go/libkb"
+)
+
+// PGPRevokeArg selects the PGP key to generate a revocation certificate
+// for (by fingerprint, KID, or any other libkb.SecretKeyArg.KeyQuery term),
+// and which part of it to revoke. SubkeyIndex selects an offset into the
+// key's subkeys; pass -1 to revoke the primary key itself.
+type PGPRevokeArg struct {
+	KeyQuery    string
+	SubkeyIndex int
+	Reason      libkb.PGPRevocationReasonCode
+	Description string
+}
+
+// PGPRevoke produces a standalone armored revocation certificate for one of
+// the user's existing PGP keys, without pushing anything to the server --
+// the resulting certificate is the caller's to export or apply as they see
+// fit.
+type PGPRevoke struct {
+	libkb.Contextified
+	arg     PGPRevokeArg
+	armored string
+}
+
+// NewPGPRevoke creates a PGPRevoke engine.
+func NewPGPRevoke(g *libkb.GlobalContext, arg PGPRevokeArg) *PGPRevoke {
+	return &PGPRevoke{
+		Contextified: libkb.NewContextified(g),
+		arg:          arg,
+	}
+}
+
+func (e *PGPRevoke) Name() string {
+	return "PGPRevoke"
+}
+
+func (e *PGPRevoke) Prereqs() Prereqs {
+	return Prereqs{
+		Device: true,
+	}
+}
+
+func (e *PGPRevoke) RequiredUIs() []libkb.UIKind {
+	return []libkb.UIKind{
+		libkb.LogUIKind,
+		libkb.SecretUIKind,
+	}
+}
+
+func (e *PGPRevoke) SubConsumers() []libkb.UIConsumer {
+	return []libkb.UIConsumer{}
+}
+
+// Armored returns the generated revocation certificate, once Run has
+// completed successfully.
+func (e *PGPRevoke) Armored() (string, error) {
+	if e.armored == "" {
+		return "", fmt.Errorf("PGPRevoke: no revocation certificate generated yet")
+	}
+	return e.armored, nil
+}
+
+func (e *PGPRevoke) Run(m libkb.MetaContext) (err error) {
+	defer m.Trace("PGPRevoke#Run", &err)()
+
+	me, err := libkb.LoadMe(libkb.NewLoadUserArgWithMetaContext(m))
+	if err != nil {
+		return err
+	}
+
+	ska := libkb.SecretKeyArg{
+		Me:       me,
+		KeyType:  libkb.PGPKeyType,
+		KeyQuery: e.arg.KeyQuery,
+	}
+	key, err := m.G().Keyrings.GetSecretKeyWithPrompt(m, m.SecretKeyPromptArg(ska, "revocation certificate"))
+	if err != nil {
+		return err
+	}
+	bundle, ok := key.(*libkb.PGPKeyBundle)
+	if !ok {
+		return fmt.Errorf("can only generate a revocation certificate for a PGP key")
+	}
+
+	if e.arg.SubkeyIndex < 0 {
+		m.UIs().LogUI.Info("Generating a revocation certificate for %s", bundle.GetFingerprint())
+		e.armored, err = bundle.GenerateRevocationCertificate(e.arg.Reason, e.arg.Description)
+		return err
+	}
+
+	m.UIs().LogUI.Info("Generating a revocation certificate for subkey %d of %s", e.arg.SubkeyIndex, bundle.GetFingerprint())
+	e.armored, err = bundle.GenerateSubkeyRevocationCertificate(e.arg.SubkeyIndex, e.arg.Reason, e.arg.Description)
+	return err
+}
@@ -6,12 +6,12 @@ package engine
 import (
 	"fmt"
 	"io"
+	"io/ioutil"
 
 	"github.com/keybase/client/go/libkb"
 	keybase1 "github.com/keybase/client/go/protocol/keybase1"
 
 	"github.com/keybase/go-crypto/openpgp"
-	"github.com/keybase/go-crypto/openpgp/clearsign"
 )
 
 type PGPSignEngine struct {
@@ -113,6 +113,22 @@ func (p *PGPSignEngine) Run(m libkb.MetaContext) (err error) {
 	pgpe := pgp.Entity
 	mode := p.arg.Opts.Mode
 
+	if mode == keybase1.SignMode_CLEAR {
+		var msg []byte
+		if msg, err = ioutil.ReadAll(p.arg.Source); err != nil {
+			return err
+		}
+		if len(msg) == 0 {
+			p.G().Log.Debug("Empty source file.")
+		}
+		var out string
+		if out, err = pgp.ClearSignToString(msg); err != nil {
+			return err
+		}
+		_, err = p.arg.Sink.Write([]byte(out))
+		return err
+	}
+
 	switch mode {
 	case keybase1.SignMode_ATTACHED:
 		dumpTo, err = libkb.AttachedSignWrapper(p.arg.Sink, *pgp, !bo)
@@ -127,8 +143,6 @@ func (p *PGPSignEngine) Run(m libkb.MetaContext) (err error) {
 		default:
 			err = openpgp.ArmoredDetachSignText(p.arg.Sink, pgpe, p.arg.Source, nil)
 		}
-	case keybase1.SignMode_CLEAR:
-		dumpTo, err = clearsign.Encode(p.arg.Sink, pgp.PrivateKey, nil)
 	default:
 		err = fmt.Errorf("unrecognized sign mode: %d", int(mode))
 	}
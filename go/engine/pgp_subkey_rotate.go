@@ -0,0 +1,113 @@
+// Copyright 2015 Keybase, Inc. All rights reserved. Use of
+// this source code is governed by the included BSD license.
+
+package engine
+
+import (
+	"fmt"
+
+	"github.com/keybase/client/go/libkb"
+)
+
+// PGPSubkeyRotateArg selects the PGP key to rotate (by fingerprint,
+// KID, or any other libkb.SecretKeyArg.KeyQuery term) and the parameters
+// of its fresh subkey.
+type PGPSubkeyRotateArg struct {
+	KeyQuery string
+	Bits     int
+	Lifetime int
+}
+
+// PGPSubkeyRotate generates and binds a fresh encryption subkey to one of
+// the user's existing PGP keys, then posts the updated bundle to the
+// server -- all without round-tripping the key through GPG the way
+// PGPUpdateEngine does.
+type PGPSubkeyRotate struct {
+	libkb.Contextified
+	arg    PGPSubkeyRotateArg
+	bundle *libkb.PGPKeyBundle
+}
+
+// NewPGPSubkeyRotate creates a PGPSubkeyRotate engine.
+func NewPGPSubkeyRotate(g *libkb.GlobalContext, arg PGPSubkeyRotateArg) *PGPSubkeyRotate {
+	return &PGPSubkeyRotate{
+		Contextified: libkb.NewContextified(g),
+		arg:          arg,
+	}
+}
+
+func (e *PGPSubkeyRotate) Name() string {
+	return "PGPSubkeyRotate"
+}
+
+func (e *PGPSubkeyRotate) Prereqs() Prereqs {
+	return Prereqs{
+		Device: true,
+	}
+}
+
+func (e *PGPSubkeyRotate) RequiredUIs() []libkb.UIKind {
+	return []libkb.UIKind{
+		libkb.LogUIKind,
+		libkb.SecretUIKind,
+	}
+}
+
+func (e *PGPSubkeyRotate) SubConsumers() []libkb.UIConsumer {
+	return []libkb.UIConsumer{}
+}
+
+// ArmoredBundle returns the public half of the rotated key, for display,
+// once Run has completed successfully.
+func (e *PGPSubkeyRotate) ArmoredBundle() (string, error) {
+	if e.bundle == nil {
+		return "", fmt.Errorf("PGPSubkeyRotate: no key rotated yet")
+	}
+	return e.bundle.Encode()
+}
+
+func (e *PGPSubkeyRotate) Run(m libkb.MetaContext) (err error) {
+	defer m.Trace("PGPSubkeyRotate#Run", &err)()
+
+	me, err := libkb.LoadMe(libkb.NewLoadUserArgWithMetaContext(m))
+	if err != nil {
+		return err
+	}
+
+	ska := libkb.SecretKeyArg{
+		Me:       me,
+		KeyType:  libkb.PGPKeyType,
+		KeyQuery: e.arg.KeyQuery,
+	}
+	key, err := m.G().Keyrings.GetSecretKeyWithPrompt(m, m.SecretKeyPromptArg(ska, "subkey rotation"))
+	if err != nil {
+		return err
+	}
+	bundle, ok := key.(*libkb.PGPKeyBundle)
+	if !ok {
+		return fmt.Errorf("can only rotate subkeys on a PGP key")
+	}
+
+	m.UIs().LogUI.Info("Generating a fresh subkey for %s", bundle.GetFingerprint())
+	if _, err := bundle.RotateSubkey(libkb.RotateSubkeyArg{
+		Bits:     e.arg.Bits,
+		Lifetime: e.arg.Lifetime,
+	}); err != nil {
+		return err
+	}
+	e.bundle = bundle
+
+	del := &libkb.Delegator{
+		DelegationType: libkb.DelegationTypePGPUpdate,
+		Me:             me,
+		Expire:         libkb.KeyExpireIn,
+		Contextified:   libkb.NewContextified(e.G()),
+		NewKey:         bundle,
+	}
+	if err := del.LoadSigningKey(m, m.UIs().SecretUI); err != nil {
+		return err
+	}
+
+	m.UIs().LogUI.Info("Posting updated key %s.", bundle.GetFingerprint())
+	return del.Run(m)
+}
@@ -6,6 +6,7 @@ package engine
 import (
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/keybase/client/go/libkb"
 )
@@ -13,6 +14,9 @@ import (
 type PGPUpdateEngine struct {
 	selectedFingerprints   map[string]bool
 	all                    bool
+	extend                 time.Duration
+	addIdentities          []libkb.Identity
+	revokeUIDs             []string
 	duplicatedFingerprints []libkb.PGPFingerprint
 	libkb.Contextified
 }
@@ -29,6 +33,32 @@ func NewPGPUpdateEngine(g *libkb.GlobalContext, fingerprints []string, all bool)
 	}
 }
 
+// SetExtend arranges for each updated key's expiration to be pushed out by
+// extend from now. Since extending a key's expiration means re-signing its
+// self-signatures (see PGPKeyBundle.ExtendExpiration), setting this makes
+// Run import each key's secret half from GPG rather than just its public
+// half.
+func (e *PGPUpdateEngine) SetExtend(extend time.Duration) {
+	e.extend = extend
+}
+
+// SetIdentityChanges arranges for each updated key to have the given
+// identities added (via PGPKeyBundle.AddIdentity) and the given UIDs
+// revoked (via PGPKeyBundle.RevokeIdentity) before it's pushed. Like
+// SetExtend, this makes Run import each key's secret half from GPG, since
+// both operations require the signing key.
+func (e *PGPUpdateEngine) SetIdentityChanges(add []libkb.Identity, revokeUIDs []string) {
+	e.addIdentities = add
+	e.revokeUIDs = revokeUIDs
+}
+
+// needsSecret reports whether any of the requested changes require the
+// key's private half, as opposed to PGPUpdateEngine's default of only
+// pushing whatever public key material is already in GPG.
+func (e *PGPUpdateEngine) needsSecret() bool {
+	return e.extend != 0 || len(e.addIdentities) > 0 || len(e.revokeUIDs) > 0
+}
+
 func (e *PGPUpdateEngine) Name() string {
 	return "PGPUpdate"
 }
@@ -87,7 +117,7 @@ func (e *PGPUpdateEngine) Run(m libkb.MetaContext) error {
 			m.UIs().LogUI.Warning("Skipping update for key %s", fingerprint.String())
 			continue
 		}
-		bundle, err := gpgCLI.ImportKey(m, false /* secret */, fingerprint, "")
+		bundle, err := gpgCLI.ImportKey(m, e.needsSecret(), fingerprint, "")
 		if err != nil {
 			_, isNoKey := err.(libkb.NoKeyError)
 			if isNoKey {
@@ -100,7 +130,28 @@ func (e *PGPUpdateEngine) Run(m libkb.MetaContext) error {
 			}
 		}
 
-		bundle.InitGPGKey()
+		if e.needsSecret() {
+			for _, ident := range e.addIdentities {
+				m.UIs().LogUI.Info("Adding identity %s to key %s.", ident, fingerprint.String())
+				if _, err := bundle.AddIdentity(ident); err != nil {
+					return err
+				}
+			}
+			for _, uid := range e.revokeUIDs {
+				m.UIs().LogUI.Info("Revoking identity %s on key %s.", uid, fingerprint.String())
+				if err := bundle.RevokeIdentity(uid, ""); err != nil {
+					return err
+				}
+			}
+			if e.extend != 0 {
+				m.UIs().LogUI.Info("Extending expiration for key %s.", fingerprint.String())
+				if _, err := bundle.ExtendExpiration(e.extend); err != nil {
+					return err
+				}
+			}
+		} else if err := bundle.InitGPGKey(m); err != nil {
+			m.UIs().LogUI.Warning("gpg fallback unavailable for %s: %s", fingerprint.String(), err)
+		}
 		del.NewKey = bundle
 
 		m.UIs().LogUI.Info("Posting update for key %s.", fingerprint.String())
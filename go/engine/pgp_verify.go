@@ -183,7 +183,10 @@ func (e *PGPVerify) runDetached(m libkb.MetaContext) error {
 			e.signStatus.SignatureTime = val.CreationTime
 		}
 
-		if warnings := libkb.NewPGPKeyBundle(signer).SecurityWarnings(
+		fingerprint := libkb.PGPFingerprint(signer.PrimaryKey.Fingerprint)
+		signerBundle := libkb.NewPGPKeyBundle(signer)
+
+		if warnings := signerBundle.SecurityWarnings(
 			libkb.HashSecurityWarningSignersIdentityHash,
 		); len(warnings) > 0 {
 			e.signStatus.Warnings = append(
@@ -192,7 +195,13 @@ func (e *PGPVerify) runDetached(m libkb.MetaContext) error {
 			)
 		}
 
-		fingerprint := libkb.PGPFingerprint(signer.PrimaryKey.Fingerprint)
+		if warnings := libkb.AuditKey(signerBundle).Warnings(&fingerprint); len(warnings) > 0 {
+			e.signStatus.Warnings = append(
+				e.signStatus.Warnings,
+				warnings...,
+			)
+		}
+
 		err = OutputSignatureSuccess(m, fingerprint, e.signer, e.signStatus.SignatureTime, e.signStatus.Warnings)
 		if err != nil {
 			return err
@@ -272,7 +281,10 @@ func (e *PGPVerify) runClearsign(m libkb.MetaContext) error {
 			e.signStatus.SignatureTime = val.CreationTime
 		}
 
-		if warnings := libkb.NewPGPKeyBundle(signer).SecurityWarnings(
+		fingerprint := libkb.PGPFingerprint(signer.PrimaryKey.Fingerprint)
+		signerBundle := libkb.NewPGPKeyBundle(signer)
+
+		if warnings := signerBundle.SecurityWarnings(
 			libkb.HashSecurityWarningSignersIdentityHash,
 		); len(warnings) > 0 {
 			e.signStatus.Warnings = append(
@@ -281,7 +293,13 @@ func (e *PGPVerify) runClearsign(m libkb.MetaContext) error {
 			)
 		}
 
-		fingerprint := libkb.PGPFingerprint(signer.PrimaryKey.Fingerprint)
+		if warnings := libkb.AuditKey(signerBundle).Warnings(&fingerprint); len(warnings) > 0 {
+			e.signStatus.Warnings = append(
+				e.signStatus.Warnings,
+				warnings...,
+			)
+		}
+
 		err = OutputSignatureSuccess(m, fingerprint, e.signer, e.signStatus.SignatureTime, e.signStatus.Warnings)
 		if err != nil {
 			return err
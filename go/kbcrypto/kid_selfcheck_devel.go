@@ -0,0 +1,23 @@
+// Copyright 2020 Keybase, Inc. All rights reserved. Use of
+// this source code is governed by the included BSD license.
+
+// +build !production
+
+package kbcrypto
+
+import (
+	"fmt"
+
+	keybase1 "github.com/keybase/client/go/protocol/keybase1"
+)
+
+// debugCheckBinaryKID asserts that a BinaryKID we just built ourselves is
+// well-formed. It's only compiled into non-production builds: a failure here
+// means a bug in our own KID-construction code, not bad input, so it's safe
+// (and useful) to panic loudly in devel and tests rather than silently ship
+// a malformed KID.
+func debugCheckBinaryKID(b keybase1.BinaryKID) {
+	if err := keybase1.ValidateBinaryKID(b); err != nil {
+		panic(fmt.Sprintf("constructed an invalid BinaryKID: %s", err))
+	}
+}
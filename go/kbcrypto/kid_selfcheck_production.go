@@ -0,0 +1,12 @@
+// Copyright 2020 Keybase, Inc. All rights reserved. Use of
+// this source code is governed by the included BSD license.
+
+// +build production
+
+package kbcrypto
+
+import keybase1 "github.com/keybase/client/go/protocol/keybase1"
+
+// debugCheckBinaryKID is a no-op in production; see the devel build for what
+// it checks.
+func debugCheckBinaryKID(keybase1.BinaryKID) {}
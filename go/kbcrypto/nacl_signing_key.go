@@ -34,7 +34,9 @@ func (k NaclSigningKeyPublic) GetBinaryKID() keybase1.BinaryKID {
 	suffix := byte(IDSuffixKID)
 	out := append(prefix, k[:]...)
 	out = append(out, suffix)
-	return keybase1.BinaryKID(out)
+	bkid := keybase1.BinaryKID(out)
+	debugCheckBinaryKID(bkid)
+	return bkid
 }
 
 func (k NaclSigningKeyPublic) GetKID() keybase1.KID {
@@ -316,11 +316,11 @@ type SyncedTlfMD struct {
 // a per-top-level-folder basis.
 //
 // There are two types of operations that could block:
-//   * remote-sync operations, that need to synchronously update the
+//   - remote-sync operations, that need to synchronously update the
 //     MD for the corresponding top-level folder.  When these
 //     operations return successfully, they will have guaranteed to
 //     have successfully written the modification to the KBFS servers.
-//   * remote-access operations, that don't sync any modifications to KBFS
+//   - remote-access operations, that don't sync any modifications to KBFS
 //     servers, but may block on reading data from the servers.
 //
 // KBFSOps implementations are supposed to give git-like consistency
@@ -729,6 +729,62 @@ type KeybaseService interface {
 	// changed.
 	NotifyFavoritesChanged(ctx context.Context) error
 
+	// NotifyKeyGenerationRotated sends a notification that a TLF's
+	// encryption key has been rotated to a new generation, e.g. after a
+	// member was removed.
+	NotifyKeyGenerationRotated(
+		ctx context.Context, folder keybase1.Folder, newKeyGeneration int) error
+
+	// NotifyDirListProgress sends a notification that KBFS is still
+	// enumerating a large directory, so the GUI can show a progressive
+	// listing instead of blocking until the full enumeration completes.
+	NotifyDirListProgress(
+		ctx context.Context, path string, entriesLoaded int,
+		complete bool) error
+
+	// NotifyFolderPathChanged sends a notification that a folder's
+	// canonical path has changed, e.g. because a member of an implicit
+	// team folder changed usernames, so the GUI can update breadcrumbs
+	// and open handles to the new path.
+	NotifyFolderPathChanged(
+		ctx context.Context, oldPath string, newPath string) error
+
+	// NotifyRemoteFileUpdated sends a notification that the server version
+	// of a file open locally has advanced past what the client has seen,
+	// so an editor can prompt the user to reload instead of silently
+	// overwriting the newer version on save.
+	NotifyRemoteFileUpdated(
+		ctx context.Context, path string, revision int64,
+		modifiedBy string) error
+
+	// NotifySnapshotComplete sends a notification that a background-archive
+	// snapshot of a folder has been fully persisted, so the GUI can confirm
+	// to the user that the snapshot is ready to restore from.
+	NotifySnapshotComplete(
+		ctx context.Context, folder keybase1.Folder, revision int64,
+		sizeBytes int64) error
+
+	// NotifyOfflineEditsAtRisk sends a notification that a conflict
+	// resolution is about to discard (or has already discarded) edits made
+	// while offline, so the GUI can warn the user to save a copy before
+	// they're lost.
+	NotifyOfflineEditsAtRisk(
+		ctx context.Context, folder keybase1.Folder, numEdits int,
+		discarded bool) error
+
+	// NotifyStagedChangesCount sends a notification that the number of
+	// locally staged-but-not-yet-flushed operations for a folder has
+	// changed, so the GUI can show an "N unsaved changes" badge.
+	NotifyStagedChangesCount(
+		ctx context.Context, folder keybase1.Folder, count int) error
+
+	// NotifyForegroundFetchComplete sends a notification that a foreground
+	// fetch triggered by a user opening a not-yet-cached file has finished,
+	// so the GUI can transition from a loading spinner to showing the file
+	// (or an error) instead of waiting on a background sync event.
+	NotifyForegroundFetchComplete(
+		ctx context.Context, path string, success bool, errMsg string) error
+
 	// FlushUserFromLocalCache instructs this layer to clear any
 	// KBFS-side, locally-cached information about the given user.
 	// This does NOT involve communication with the daemon, this is
@@ -2161,13 +2217,13 @@ type OnlineStatusTracker interface {
 //
 // The two Subscribe methods are for path and non-path subscriptions
 // respectively. Notes on some common arguments:
-// 1) subscriptionID needs to be unique among all subscriptions that happens
-//    with this process. A UUID or even just a timestamp might work. If
-//    duplicate subscriptionIDs are used, an error is returned.
-// 2) Optionally a deduplicateInterval can be used. When this arg is set, we
-//    debounce the events so it doesn't send more frequently than the interval.
-//    If deduplicateInterval is not set, i.e. nil, no deduplication is done and
-//    all events will be delivered.
+//  1. subscriptionID needs to be unique among all subscriptions that happens
+//     with this process. A UUID or even just a timestamp might work. If
+//     duplicate subscriptionIDs are used, an error is returned.
+//  2. Optionally a deduplicateInterval can be used. When this arg is set, we
+//     debounce the events so it doesn't send more frequently than the interval.
+//     If deduplicateInterval is not set, i.e. nil, no deduplication is done and
+//     all events will be delivered.
 type SubscriptionManager interface {
 	// SubscribePath subscribes to changes about path, when topic happens.
 	SubscribePath(
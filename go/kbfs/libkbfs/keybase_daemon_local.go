@@ -386,6 +386,59 @@ func (k *KeybaseDaemonLocal) NotifyFavoritesChanged(ctx context.Context) error {
 	return checkContext(ctx)
 }
 
+// NotifyKeyGenerationRotated implements KeybaseDaemon for KeybaseDeamonLocal.
+func (k *KeybaseDaemonLocal) NotifyKeyGenerationRotated(
+	ctx context.Context, folder keybase1.Folder, newKeyGeneration int) error {
+	return checkContext(ctx)
+}
+
+// NotifyDirListProgress implements KeybaseDaemon for KeybaseDeamonLocal.
+func (k *KeybaseDaemonLocal) NotifyDirListProgress(
+	ctx context.Context, path string, entriesLoaded int,
+	complete bool) error {
+	return checkContext(ctx)
+}
+
+// NotifyFolderPathChanged implements KeybaseDaemon for KeybaseDeamonLocal.
+func (k *KeybaseDaemonLocal) NotifyFolderPathChanged(
+	ctx context.Context, oldPath string, newPath string) error {
+	return checkContext(ctx)
+}
+
+// NotifyRemoteFileUpdated implements KeybaseDaemon for KeybaseDeamonLocal.
+func (k *KeybaseDaemonLocal) NotifyRemoteFileUpdated(
+	ctx context.Context, path string, revision int64,
+	modifiedBy string) error {
+	return checkContext(ctx)
+}
+
+// NotifySnapshotComplete implements KeybaseDaemon for KeybaseDeamonLocal.
+func (k *KeybaseDaemonLocal) NotifySnapshotComplete(
+	ctx context.Context, folder keybase1.Folder, revision int64,
+	sizeBytes int64) error {
+	return checkContext(ctx)
+}
+
+// NotifyOfflineEditsAtRisk implements KeybaseDaemon for KeybaseDeamonLocal.
+func (k *KeybaseDaemonLocal) NotifyOfflineEditsAtRisk(
+	ctx context.Context, folder keybase1.Folder, numEdits int,
+	discarded bool) error {
+	return checkContext(ctx)
+}
+
+// NotifyStagedChangesCount implements KeybaseDaemon for KeybaseDeamonLocal.
+func (k *KeybaseDaemonLocal) NotifyStagedChangesCount(
+	ctx context.Context, folder keybase1.Folder, count int) error {
+	return checkContext(ctx)
+}
+
+// NotifyForegroundFetchComplete implements KeybaseDaemon for
+// KeybaseDeamonLocal.
+func (k *KeybaseDaemonLocal) NotifyForegroundFetchComplete(
+	ctx context.Context, path string, success bool, errMsg string) error {
+	return checkContext(ctx)
+}
+
 // Notify implements KeybaseDaemon for KeybaseDeamonLocal.
 func (k *KeybaseDaemonLocal) Notify(ctx context.Context, notification *keybase1.FSNotification) error {
 	return checkContext(ctx)
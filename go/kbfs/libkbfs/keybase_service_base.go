@@ -1234,6 +1234,105 @@ func (k *KeybaseServiceBase) NotifyFavoritesChanged(ctx context.Context) error {
 	return k.kbfsClient.FSFavoritesChangedEvent(ctx)
 }
 
+// NotifyKeyGenerationRotated implements the KeybaseService interface for
+// KeybaseServiceBase. It tells the service a TLF's encryption key has been
+// rotated to a new generation, for the service to note in a
+// security-audit log.
+func (k *KeybaseServiceBase) NotifyKeyGenerationRotated(
+	ctx context.Context, folder keybase1.Folder, newKeyGeneration int) error {
+	return k.kbfsClient.FSKeyGenerationRotated(ctx, keybase1.KeyGenerationRotatedArg{
+		Folder:           folder,
+		NewKeyGeneration: newKeyGeneration,
+	})
+}
+
+// NotifyDirListProgress implements the KeybaseService interface for
+// KeybaseServiceBase. It tells the service that a large directory listing
+// is still in progress, for the GUI to show a progressive listing.
+func (k *KeybaseServiceBase) NotifyDirListProgress(
+	ctx context.Context, path string, entriesLoaded int,
+	complete bool) error {
+	return k.kbfsClient.FSDirListProgress(ctx, keybase1.DirListProgressArg{
+		Path:          path,
+		EntriesLoaded: entriesLoaded,
+		Complete:      complete,
+	})
+}
+
+// NotifyFolderPathChanged implements the KeybaseService interface for
+// KeybaseServiceBase. It tells the service that a folder's canonical path
+// has changed, so the GUI can update breadcrumbs and open handles.
+func (k *KeybaseServiceBase) NotifyFolderPathChanged(
+	ctx context.Context, oldPath string, newPath string) error {
+	return k.kbfsClient.FSFolderPathChanged(ctx, keybase1.FolderPathChangedArg{
+		OldPath: oldPath,
+		NewPath: newPath,
+	})
+}
+
+// NotifyRemoteFileUpdated implements the KeybaseService interface for
+// KeybaseServiceBase. It tells the service that the server version of an
+// open file has advanced past what the client has seen.
+func (k *KeybaseServiceBase) NotifyRemoteFileUpdated(
+	ctx context.Context, path string, revision int64,
+	modifiedBy string) error {
+	return k.kbfsClient.FSRemoteFileUpdated(ctx, keybase1.RemoteFileUpdatedArg{
+		Path:       path,
+		Revision:   revision,
+		ModifiedBy: modifiedBy,
+	})
+}
+
+// NotifySnapshotComplete implements the KeybaseService interface for
+// KeybaseServiceBase. It tells the service that a background-archive
+// snapshot of a folder has been fully persisted.
+func (k *KeybaseServiceBase) NotifySnapshotComplete(
+	ctx context.Context, folder keybase1.Folder, revision int64,
+	sizeBytes int64) error {
+	return k.kbfsClient.FSSnapshotComplete(ctx, keybase1.SnapshotCompleteArg{
+		Folder:    folder,
+		Revision:  revision,
+		SizeBytes: sizeBytes,
+	})
+}
+
+// NotifyOfflineEditsAtRisk implements the KeybaseService interface for
+// KeybaseServiceBase. It tells the service that conflict resolution is about
+// to discard (or has already discarded) edits made while offline.
+func (k *KeybaseServiceBase) NotifyOfflineEditsAtRisk(
+	ctx context.Context, folder keybase1.Folder, numEdits int,
+	discarded bool) error {
+	return k.kbfsClient.FSOfflineEditsAtRisk(ctx, keybase1.OfflineEditsAtRiskArg{
+		Folder:    folder,
+		NumEdits:  numEdits,
+		Discarded: discarded,
+	})
+}
+
+// NotifyStagedChangesCount implements the KeybaseService interface for
+// KeybaseServiceBase. It tells the service how many locally
+// staged-but-not-yet-flushed operations are pending for a folder.
+func (k *KeybaseServiceBase) NotifyStagedChangesCount(
+	ctx context.Context, folder keybase1.Folder, count int) error {
+	return k.kbfsClient.FSStagedChangesCount(ctx, keybase1.StagedChangesCountArg{
+		Folder: folder,
+		Count:  count,
+	})
+}
+
+// NotifyForegroundFetchComplete implements the KeybaseService interface for
+// KeybaseServiceBase. It tells the service that a user-initiated foreground
+// fetch has finished, so the GUI can transition from a loading spinner to
+// showing the file (or an error).
+func (k *KeybaseServiceBase) NotifyForegroundFetchComplete(
+	ctx context.Context, path string, success bool, errMsg string) error {
+	return k.kbfsClient.FSForegroundFetchComplete(ctx, keybase1.ForegroundFetchCompleteArg{
+		Path:    path,
+		Success: success,
+		Error:   errMsg,
+	})
+}
+
 // OnPathChange implements the SubscriptionNotifier interface.
 func (k *KeybaseServiceBase) OnPathChange(
 	clientID SubscriptionManagerClientID,
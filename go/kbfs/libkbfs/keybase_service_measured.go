@@ -346,6 +346,90 @@ func (k KeybaseServiceMeasured) NotifyFavoritesChanged(
 	return err
 }
 
+// NotifyKeyGenerationRotated implements the KeybaseService interface for
+// KeybaseServiceMeasured.
+func (k KeybaseServiceMeasured) NotifyKeyGenerationRotated(
+	ctx context.Context, folder keybase1.Folder, newKeyGeneration int) (err error) {
+	k.notifyTimer.Time(func() {
+		err = k.delegate.NotifyKeyGenerationRotated(ctx, folder, newKeyGeneration)
+	})
+	return err
+}
+
+// NotifyDirListProgress implements the KeybaseService interface for
+// KeybaseServiceMeasured.
+func (k KeybaseServiceMeasured) NotifyDirListProgress(
+	ctx context.Context, path string, entriesLoaded int,
+	complete bool) (err error) {
+	k.notifyTimer.Time(func() {
+		err = k.delegate.NotifyDirListProgress(ctx, path, entriesLoaded, complete)
+	})
+	return err
+}
+
+// NotifyFolderPathChanged implements the KeybaseService interface for
+// KeybaseServiceMeasured.
+func (k KeybaseServiceMeasured) NotifyFolderPathChanged(
+	ctx context.Context, oldPath string, newPath string) (err error) {
+	k.notifyTimer.Time(func() {
+		err = k.delegate.NotifyFolderPathChanged(ctx, oldPath, newPath)
+	})
+	return err
+}
+
+// NotifyRemoteFileUpdated implements the KeybaseService interface for
+// KeybaseServiceMeasured.
+func (k KeybaseServiceMeasured) NotifyRemoteFileUpdated(
+	ctx context.Context, path string, revision int64,
+	modifiedBy string) (err error) {
+	k.notifyTimer.Time(func() {
+		err = k.delegate.NotifyRemoteFileUpdated(ctx, path, revision, modifiedBy)
+	})
+	return err
+}
+
+// NotifySnapshotComplete implements the KeybaseService interface for
+// KeybaseServiceMeasured.
+func (k KeybaseServiceMeasured) NotifySnapshotComplete(
+	ctx context.Context, folder keybase1.Folder, revision int64,
+	sizeBytes int64) (err error) {
+	k.notifyTimer.Time(func() {
+		err = k.delegate.NotifySnapshotComplete(ctx, folder, revision, sizeBytes)
+	})
+	return err
+}
+
+// NotifyOfflineEditsAtRisk implements the KeybaseService interface for
+// KeybaseServiceMeasured.
+func (k KeybaseServiceMeasured) NotifyOfflineEditsAtRisk(
+	ctx context.Context, folder keybase1.Folder, numEdits int,
+	discarded bool) (err error) {
+	k.notifyTimer.Time(func() {
+		err = k.delegate.NotifyOfflineEditsAtRisk(ctx, folder, numEdits, discarded)
+	})
+	return err
+}
+
+// NotifyStagedChangesCount implements the KeybaseService interface for
+// KeybaseServiceMeasured.
+func (k KeybaseServiceMeasured) NotifyStagedChangesCount(
+	ctx context.Context, folder keybase1.Folder, count int) (err error) {
+	k.notifyTimer.Time(func() {
+		err = k.delegate.NotifyStagedChangesCount(ctx, folder, count)
+	})
+	return err
+}
+
+// NotifyForegroundFetchComplete implements the KeybaseService interface for
+// KeybaseServiceMeasured.
+func (k KeybaseServiceMeasured) NotifyForegroundFetchComplete(
+	ctx context.Context, path string, success bool, errMsg string) (err error) {
+	k.notifyTimer.Time(func() {
+		err = k.delegate.NotifyForegroundFetchComplete(ctx, path, success, errMsg)
+	})
+	return err
+}
+
 // FlushUserFromLocalCache implements the KeybaseService interface for
 // KeybaseServiceMeasured.
 func (k KeybaseServiceMeasured) FlushUserFromLocalCache(
@@ -2476,6 +2476,118 @@ func (mr *MockKeybaseServiceMockRecorder) NotifyFavoritesChanged(arg0 interface{
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NotifyFavoritesChanged", reflect.TypeOf((*MockKeybaseService)(nil).NotifyFavoritesChanged), arg0)
 }
 
+// NotifyKeyGenerationRotated mocks base method.
+func (m *MockKeybaseService) NotifyKeyGenerationRotated(arg0 context.Context, arg1 keybase1.Folder, arg2 int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NotifyKeyGenerationRotated", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// NotifyKeyGenerationRotated indicates an expected call of NotifyKeyGenerationRotated.
+func (mr *MockKeybaseServiceMockRecorder) NotifyKeyGenerationRotated(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NotifyKeyGenerationRotated", reflect.TypeOf((*MockKeybaseService)(nil).NotifyKeyGenerationRotated), arg0, arg1, arg2)
+}
+
+// NotifyDirListProgress mocks base method.
+func (m *MockKeybaseService) NotifyDirListProgress(arg0 context.Context, arg1 string, arg2 int, arg3 bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NotifyDirListProgress", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// NotifyDirListProgress indicates an expected call of NotifyDirListProgress.
+func (mr *MockKeybaseServiceMockRecorder) NotifyDirListProgress(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NotifyDirListProgress", reflect.TypeOf((*MockKeybaseService)(nil).NotifyDirListProgress), arg0, arg1, arg2, arg3)
+}
+
+// NotifyFolderPathChanged mocks base method.
+func (m *MockKeybaseService) NotifyFolderPathChanged(arg0 context.Context, arg1, arg2 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NotifyFolderPathChanged", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// NotifyFolderPathChanged indicates an expected call of NotifyFolderPathChanged.
+func (mr *MockKeybaseServiceMockRecorder) NotifyFolderPathChanged(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NotifyFolderPathChanged", reflect.TypeOf((*MockKeybaseService)(nil).NotifyFolderPathChanged), arg0, arg1, arg2)
+}
+
+// NotifyRemoteFileUpdated mocks base method.
+func (m *MockKeybaseService) NotifyRemoteFileUpdated(arg0 context.Context, arg1 string, arg2 int64, arg3 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NotifyRemoteFileUpdated", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// NotifyRemoteFileUpdated indicates an expected call of NotifyRemoteFileUpdated.
+func (mr *MockKeybaseServiceMockRecorder) NotifyRemoteFileUpdated(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NotifyRemoteFileUpdated", reflect.TypeOf((*MockKeybaseService)(nil).NotifyRemoteFileUpdated), arg0, arg1, arg2, arg3)
+}
+
+// NotifySnapshotComplete mocks base method.
+func (m *MockKeybaseService) NotifySnapshotComplete(arg0 context.Context, arg1 keybase1.Folder, arg2 int64, arg3 int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NotifySnapshotComplete", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// NotifySnapshotComplete indicates an expected call of NotifySnapshotComplete.
+func (mr *MockKeybaseServiceMockRecorder) NotifySnapshotComplete(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NotifySnapshotComplete", reflect.TypeOf((*MockKeybaseService)(nil).NotifySnapshotComplete), arg0, arg1, arg2, arg3)
+}
+
+// NotifyOfflineEditsAtRisk mocks base method.
+func (m *MockKeybaseService) NotifyOfflineEditsAtRisk(arg0 context.Context, arg1 keybase1.Folder, arg2 int, arg3 bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NotifyOfflineEditsAtRisk", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// NotifyOfflineEditsAtRisk indicates an expected call of NotifyOfflineEditsAtRisk.
+func (mr *MockKeybaseServiceMockRecorder) NotifyOfflineEditsAtRisk(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NotifyOfflineEditsAtRisk", reflect.TypeOf((*MockKeybaseService)(nil).NotifyOfflineEditsAtRisk), arg0, arg1, arg2, arg3)
+}
+
+// NotifyStagedChangesCount mocks base method.
+func (m *MockKeybaseService) NotifyStagedChangesCount(arg0 context.Context, arg1 keybase1.Folder, arg2 int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NotifyStagedChangesCount", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// NotifyStagedChangesCount indicates an expected call of NotifyStagedChangesCount.
+func (mr *MockKeybaseServiceMockRecorder) NotifyStagedChangesCount(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NotifyStagedChangesCount", reflect.TypeOf((*MockKeybaseService)(nil).NotifyStagedChangesCount), arg0, arg1, arg2)
+}
+
+// NotifyForegroundFetchComplete mocks base method.
+func (m *MockKeybaseService) NotifyForegroundFetchComplete(arg0 context.Context, arg1 string, arg2 bool, arg3 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NotifyForegroundFetchComplete", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// NotifyForegroundFetchComplete indicates an expected call of NotifyForegroundFetchComplete.
+func (mr *MockKeybaseServiceMockRecorder) NotifyForegroundFetchComplete(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NotifyForegroundFetchComplete", reflect.TypeOf((*MockKeybaseService)(nil).NotifyForegroundFetchComplete), arg0, arg1, arg2, arg3)
+}
+
 // NotifyOnlineStatusChanged mocks base method.
 func (m *MockKeybaseService) NotifyOnlineStatusChanged(arg0 context.Context, arg1 bool) error {
 	m.ctrl.T.Helper()
@@ -123,9 +123,24 @@ func (p CommandLine) GetDebugJourneycard() (bool, bool) {
 func (p CommandLine) GetDisplayRawUntrustedOutput() (bool, bool) {
 	return p.GetBool("display-raw-untrusted-output", true)
 }
+func (p CommandLine) GetRPCTraceEnabled() (bool, bool) {
+	return p.GetBool("rpc-trace", true)
+}
+func (p CommandLine) GetVerifyTraceEnabled() (bool, bool) {
+	return p.GetBool("verify-trace", true)
+}
+func (p CommandLine) GetPGPRefuseWeakSigning() (bool, bool) {
+	return p.GetBool("pgp-refuse-weak-signing", true)
+}
+func (p CommandLine) GetPGPRefuseWeakVerify() (bool, bool) {
+	return p.GetBool("pgp-refuse-weak-verify", true)
+}
 func (p CommandLine) GetVDebugSetting() string {
 	return p.GetGString("vdebug")
 }
+func (p CommandLine) GetLogModuleLevels() string {
+	return p.GetGString("log-module-levels")
+}
 func (p CommandLine) GetPGPFingerprint() *libkb.PGPFingerprint {
 	return libkb.PGPFingerprintFromHexNoError(p.GetGString("fingerprint"))
 }
@@ -216,6 +231,9 @@ func (p CommandLine) GetFeatureFlags() (libkb.FeatureFlags, error) {
 func (p CommandLine) GetPinentry() string {
 	return p.GetGString("pinentry")
 }
+func (p CommandLine) GetPGPKeyServerURI() string {
+	return p.GetGString("pgp-key-server-uri")
+}
 func (p CommandLine) GetAppType() libkb.AppType {
 	return libkb.DesktopAppType
 }
@@ -363,6 +381,14 @@ func (p CommandLine) GetPayloadCacheSize() (int, bool) {
 	return 0, false
 }
 
+func (p CommandLine) GetPGPEntityCacheSize() (int, bool) {
+	ret := p.GetGInt("pgp-entity-cache-size")
+	if ret != 0 {
+		return ret, true
+	}
+	return 0, false
+}
+
 func (p CommandLine) GetLocalTrackMaxAge() (time.Duration, bool) {
 	ret, err := p.GetGDuration("local-track-maxage")
 	if err != nil {
@@ -643,6 +669,10 @@ func (p *CommandLine) PopulateApp(addHelp bool, extraFlags []cli.Flag) {
 			Name:  "pinentry",
 			Usage: "Specify a path to find a pinentry program.",
 		},
+		cli.StringFlag{
+			Name:  "pgp-key-server-uri",
+			Usage: "Specify the HKP keyserver used by \"pgp pull-remote\" and \"pgp push-remote\".",
+		},
 		cli.IntFlag{
 			Name:  "proof-cache-size",
 			Usage: "Number of proof entries to cache.",
@@ -0,0 +1,86 @@
+// Copyright 2026 Keybase, Inc. All rights reserved. Use of
+// this source code is governed by the included BSD license.
+
+// A platform-specific package (macOS Touch ID + keychain, iOS/Android
+// Keystore, ...) registers a BiometricUnlockProvider here so KeyUnlocker can
+// try it before ever showing a passphrase prompt. This file only defines
+// the provider interface, the registry, and per-identifier opt-in
+// persistence; the actual biometric check lives in the platform package that
+// registers itself.
+
+package libkb
+
+import (
+	"fmt"
+	"sync"
+)
+
+// BiometricUnlockProvider lets KeyUnlocker ask a platform-specific package
+// whether it can supply a secret after whatever biometric check the
+// platform requires (Touch ID, device Keystore, ...), instead of always
+// prompting the user to type a passphrase.
+type BiometricUnlockProvider interface {
+	// Name identifies the provider in debug logs, e.g. "macos-touchid".
+	Name() string
+
+	// IsAvailable reports whether the provider can be used right now (the
+	// hardware is present, the user is enrolled, etc.) without itself
+	// triggering a biometric prompt.
+	IsAvailable(m MetaContext) bool
+
+	// FetchSecret runs whatever platform biometric check is appropriate
+	// and, on success, returns the secret previously stored under
+	// identifier. ok is false if the user declined, failed the check, or
+	// nothing is stored under identifier; err is reserved for unexpected
+	// failures worth logging.
+	FetchSecret(m MetaContext, identifier string) (secret string, ok bool, err error)
+
+	// StoreSecret stashes secret under identifier, gated behind a future
+	// biometric check.
+	StoreSecret(m MetaContext, identifier string, secret string) error
+
+	// ClearSecret removes whatever is stored under identifier, if
+	// anything. It is not an error for there to be nothing to clear.
+	ClearSecret(m MetaContext, identifier string) error
+}
+
+var biometricProviderMu sync.Mutex
+var biometricProvider BiometricUnlockProvider
+
+// RegisterBiometricUnlockProvider installs p as the process-wide biometric
+// unlock provider. Platform packages call this from their own init(); the
+// last registration wins, which in practice only matters in tests.
+func RegisterBiometricUnlockProvider(p BiometricUnlockProvider) {
+	biometricProviderMu.Lock()
+	defer biometricProviderMu.Unlock()
+	biometricProvider = p
+}
+
+// GetBiometricUnlockProvider returns the currently registered provider, or
+// nil if none has registered (e.g. this platform has no biometric support,
+// or a test didn't set one up).
+func GetBiometricUnlockProvider() BiometricUnlockProvider {
+	biometricProviderMu.Lock()
+	defer biometricProviderMu.Unlock()
+	return biometricProvider
+}
+
+func biometricUnlockConfigPath(identifier string) string {
+	return fmt.Sprintf("biometric_unlock_map.%s", identifier)
+}
+
+// IsBiometricUnlockEnabled reports whether the user has opted in to
+// biometric unlock for identifier (by convention, the same identifier
+// passed to KeyUnlocker.WithBiometricUnlock, e.g. a PGP key's fingerprint
+// label). A registered, available provider is still only tried for
+// identifiers the user has explicitly opted in to; the default is false.
+func IsBiometricUnlockEnabled(m MetaContext, identifier string) bool {
+	enabled, _ := m.G().Env.GetConfig().GetBoolAtPath(biometricUnlockConfigPath(identifier))
+	return enabled
+}
+
+// SetBiometricUnlockEnabled records the user's opt-in/opt-out choice for
+// identifier.
+func SetBiometricUnlockEnabled(m MetaContext, identifier string, enabled bool) error {
+	return m.G().Env.GetConfigWriter().SetBoolAtPath(biometricUnlockConfigPath(identifier), enabled)
+}
@@ -0,0 +1,117 @@
+// Copyright 2026 Keybase, Inc. All rights reserved. Use of
+// this source code is governed by the included BSD license.
+
+package libkb
+
+import (
+	"errors"
+	"testing"
+
+	keybase1 "github.com/keybase/client/go/protocol/keybase1"
+	"github.com/stretchr/testify/require"
+)
+
+// errFakeUIPrompted marks that the test's fake SecretUI was actually
+// reached, i.e. the biometric pre-prompt hook declined or had nothing to
+// offer.
+var errFakeUIPrompted = errors.New("fake ui prompted")
+
+type fakeSecretUI struct{}
+
+func (fakeSecretUI) GetPassphrase(keybase1.GUIEntryArg, *keybase1.SecretEntryArg) (keybase1.GetPassphraseRes, error) {
+	return keybase1.GetPassphraseRes{}, errFakeUIPrompted
+}
+
+// fakeBiometricProvider is a test-only BiometricUnlockProvider with an
+// in-memory store and switches to simulate unavailability or a declined
+// biometric check, for exercising KeyUnlocker without any real platform
+// bindings.
+type fakeBiometricProvider struct {
+	available bool
+	declined  bool
+	secrets   map[string]string
+}
+
+func newFakeBiometricProvider() *fakeBiometricProvider {
+	return &fakeBiometricProvider{available: true, secrets: make(map[string]string)}
+}
+
+func (f *fakeBiometricProvider) Name() string { return "fake" }
+
+func (f *fakeBiometricProvider) IsAvailable(m MetaContext) bool { return f.available }
+
+func (f *fakeBiometricProvider) FetchSecret(m MetaContext, identifier string) (string, bool, error) {
+	if f.declined {
+		return "", false, nil
+	}
+	secret, found := f.secrets[identifier]
+	return secret, found, nil
+}
+
+func (f *fakeBiometricProvider) StoreSecret(m MetaContext, identifier string, secret string) error {
+	f.secrets[identifier] = secret
+	return nil
+}
+
+func (f *fakeBiometricProvider) ClearSecret(m MetaContext, identifier string) error {
+	delete(f.secrets, identifier)
+	return nil
+}
+
+func TestBiometricUnlockOptInPersistence(t *testing.T) {
+	tc := SetupTest(t, "biometric unlock", 1)
+	defer tc.Cleanup()
+
+	m := NewMetaContextForTest(tc)
+	const identifier = "tusername.pgp_passphrase_deadbeef"
+
+	require.False(t, IsBiometricUnlockEnabled(m, identifier), "opt-in should default to false")
+
+	require.NoError(t, SetBiometricUnlockEnabled(m, identifier, true))
+	require.True(t, IsBiometricUnlockEnabled(m, identifier))
+
+	require.NoError(t, SetBiometricUnlockEnabled(m, identifier, false))
+	require.False(t, IsBiometricUnlockEnabled(m, identifier))
+}
+
+func TestKeyUnlockerBiometricProvider(t *testing.T) {
+	tc := SetupTest(t, "biometric unlock", 1)
+	defer tc.Cleanup()
+	m := NewMetaContextForTest(tc)
+
+	const identifier = "tusername.pgp_passphrase_deadbeef"
+	provider := newFakeBiometricProvider()
+	RegisterBiometricUnlockProvider(provider)
+	defer RegisterBiometricUnlockProvider(nil)
+
+	var gotPassphrase string
+	unlocker := func(pw string, storeSecret bool) (GenericKey, error) {
+		gotPassphrase = pw
+		return nil, nil
+	}
+	ku := NewKeyUnlocker(1, "test", "test key", PassphraseTypePGP, false, fakeSecretUI{}, unlocker).
+		WithBiometricUnlock(identifier)
+
+	// Not opted in yet: the biometric check is skipped and the prompt (our
+	// fake UI) is reached.
+	_, err := ku.Run(m)
+	require.Equal(t, errFakeUIPrompted, err)
+
+	require.NoError(t, SetBiometricUnlockEnabled(m, identifier, true))
+
+	// Opted in, but nothing stored yet: still falls through to the prompt.
+	_, err = ku.Run(m)
+	require.Equal(t, errFakeUIPrompted, err)
+
+	require.NoError(t, provider.StoreSecret(m, identifier, "hunter2"))
+
+	ret, err := ku.Run(m)
+	require.NoError(t, err)
+	require.Nil(t, ret)
+	require.Equal(t, "hunter2", gotPassphrase)
+
+	// A declined biometric check falls back to the prompt too.
+	provider.declined = true
+	_, err = ku.Run(m)
+	require.Equal(t, errFakeUIPrompted, err)
+}
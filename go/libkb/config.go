@@ -456,6 +456,10 @@ func (f *JSONConfigFile) GetGpg() string {
 	res, _ := f.GetStringAtPath("gpg.command")
 	return res
 }
+func (f *JSONConfigFile) GetPGPKeyServerURI() string {
+	res, _ := f.GetStringAtPath("pgp.key_server_uri")
+	return res
+}
 func (f *JSONConfigFile) GetLocalRPCDebug() string {
 	return f.GetTopLevelString("local_rpc_debug")
 }
@@ -577,9 +581,24 @@ func (f *JSONConfigFile) GetDebugJourneycard() (bool, bool) {
 func (f *JSONConfigFile) GetDisplayRawUntrustedOutput() (bool, bool) {
 	return f.GetTopLevelBool("display_raw_untrusted_output")
 }
+func (f *JSONConfigFile) GetRPCTraceEnabled() (bool, bool) {
+	return f.GetTopLevelBool("rpc_trace_enabled")
+}
+func (f *JSONConfigFile) GetVerifyTraceEnabled() (bool, bool) {
+	return f.GetTopLevelBool("verify_trace_enabled")
+}
+func (f *JSONConfigFile) GetPGPRefuseWeakSigning() (bool, bool) {
+	return f.GetTopLevelBool("pgp_refuse_weak_signing")
+}
+func (f *JSONConfigFile) GetPGPRefuseWeakVerify() (bool, bool) {
+	return f.GetTopLevelBool("pgp_refuse_weak_verify")
+}
 func (f *JSONConfigFile) GetVDebugSetting() string {
 	return f.GetTopLevelString("vdebug")
 }
+func (f *JSONConfigFile) GetLogModuleLevels() string {
+	return f.GetTopLevelString("log_module_levels")
+}
 func (f *JSONConfigFile) GetAutoFork() (bool, bool) {
 	return f.GetTopLevelBool("auto_fork")
 }
@@ -690,6 +709,10 @@ func (f *JSONConfigFile) GetPayloadCacheSize() (int, bool) {
 	return f.getCacheSize("cache.limits.payloads")
 }
 
+func (f *JSONConfigFile) GetPGPEntityCacheSize() (int, bool) {
+	return f.getCacheSize("cache.limits.pgp_entities")
+}
+
 func (f *JSONConfigFile) GetLevelDBNumFiles() (int, bool) {
 	return f.GetIntAtPath("leveldb.num_files")
 }
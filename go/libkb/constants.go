@@ -26,6 +26,11 @@ const (
 	ProductionSiteURI = "https://keybase.io"
 )
 
+// DefaultPGPKeyServerURI is the HKP keyserver that "keybase pgp pull-remote"
+// and "keybase pgp push-remote" talk to when the user hasn't configured one
+// of their own.
+const DefaultPGPKeyServerURI = "https://keys.openpgp.org"
+
 var TorProxy = "localhost:9050"
 
 // TODO (CORE-6576): Remove these aliases once everything outside of
@@ -153,7 +158,8 @@ const (
 	ImplicitTeamConflictInfoCacheSize = 10000
 	ImplicitTeamCacheSize             = 10000
 
-	PayloadCacheSize = 1000
+	PayloadCacheSize   = 1000
+	PGPEntityCacheSize = 1000
 
 	SigShortIDBytes  = 27
 	LocalTrackMaxAge = 48 * time.Hour
@@ -755,6 +761,7 @@ const (
 	MaxStellarPaymentNoteLength       = 500
 	MaxStellarPaymentBoxedNoteLength  = 2000
 	MaxStellarPaymentPublicNoteLength = 28
+	MaxStellarPaymentCategoryLength   = 100
 )
 
 const ClientTriplesecVersion = 3
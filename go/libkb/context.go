@@ -100,6 +100,20 @@ func (m MetaContext) PerfDebug(f string, args ...interface{}) {
 func (m MetaContext) Warning(f string, args ...interface{}) {
 	m.g.Log.CloneWithAddedDepth(1).CWarningf(m.ctx, f, args...)
 }
+
+// Debugw is Debug with a stable "key=value ..." suffix appended (see
+// formatKV), for call sites a support tooling parser or an ad hoc grep
+// needs to reliably find by a field like a Bid or a KID rather than by
+// matching English prose. As with Debug, a sensitive value passed as one of
+// keysAndValues must go through Redact/RedactString first.
+func (m MetaContext) Debugw(msg string, keysAndValues ...interface{}) {
+	m.g.Log.CloneWithAddedDepth(1).CDebugf(m.ctx, "%s", formatKV(msg, keysAndValues))
+}
+
+// Warningw is Warning with Debugw's structured key=value suffix.
+func (m MetaContext) Warningw(msg string, keysAndValues ...interface{}) {
+	m.g.Log.CloneWithAddedDepth(1).CWarningf(m.ctx, "%s", formatKV(msg, keysAndValues))
+}
 func (m MetaContext) Error(f string, args ...interface{}) {
 	m.g.Log.CloneWithAddedDepth(1).CErrorf(m.ctx, f, args...)
 }
@@ -107,6 +121,18 @@ func (m MetaContext) Info(f string, args ...interface{}) {
 	m.g.Log.CloneWithAddedDepth(1).CInfof(m.ctx, f, args...)
 }
 
+// DebugModule logs a debug-level message tagged with module, but only if
+// module is at LogLevel_DEBUG or more verbose -- see ModuleLogLevels. Meant
+// for noisy subsystems (e.g. "stellar", "kbfs-notify") that would flood the
+// log if their debug output couldn't be toggled independently of the global
+// level.
+func (m MetaContext) DebugModule(module string, f string, args ...interface{}) {
+	if !m.g.ModuleLogLevels.IsEnabled(module, keybase1.LogLevel_DEBUG) {
+		return
+	}
+	m.g.Log.CloneWithAddedDepth(1).CDebugf(m.ctx, f, args...)
+}
+
 func (m MetaContext) ActiveDevice() *ActiveDevice {
 	if m.activeDevice != nil {
 		m.Debug("MetaContext#ActiveDevice: thread local")
@@ -5,6 +5,7 @@ package libkb
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"regexp"
 	"strconv"
@@ -301,10 +302,98 @@ func (j *JSONLocalDb) KeysWithPrefixes(prefixes ...[]byte) (DBKeySet, error) {
 	return j.engine.KeysWithPrefixes(prefixes...)
 }
 
+// ErrStopScan can be returned by a ScanPrefix callback to stop iterating
+// early without that being treated as a failed scan.
+var ErrStopScan = errors.New("libkb: stop scan")
+
+// ScanPrefix invokes fn once per row of type typ whose key has the given
+// prefix, in key order, with each row's value parsed as JSON. fn may
+// return ErrStopScan to stop early, or safely Delete the row it was just
+// given -- see LocalDb.ScanPrefix.
+func (j *JSONLocalDb) ScanPrefix(typ ObjType, prefix string, fn func(DbKey, *jsonw.Wrapper) error) error {
+	return j.engine.ScanPrefix(typ, prefix, func(key DbKey, value []byte) error {
+		w, err := jsonw.Unmarshal(value)
+		if err != nil {
+			return err
+		}
+		return fn(key, w)
+	})
+}
+
+// CountKeysWithPrefix returns the number of rows of type typ whose key has
+// the given prefix, for use by `db stats`-style diagnostics. It's a thin
+// consumer of ScanPrefix and pays the same cost as a full scan.
+func (j *JSONLocalDb) CountKeysWithPrefix(typ ObjType, prefix string) (count int, err error) {
+	err = j.ScanPrefix(typ, prefix, func(DbKey, *jsonw.Wrapper) error {
+		count++
+		return nil
+	})
+	return count, err
+}
+
+// SweepExpired deletes every row of type typ whose key has the given
+// prefix and for which isExpired returns true, returning the number
+// removed. It's built directly on ScanPrefix's delete-during-scan
+// guarantee.
+func (j *JSONLocalDb) SweepExpired(typ ObjType, prefix string, isExpired func(DbKey, *jsonw.Wrapper) bool) (removed int, err error) {
+	err = j.ScanPrefix(typ, prefix, func(key DbKey, w *jsonw.Wrapper) error {
+		if !isExpired(key, w) {
+			return nil
+		}
+		if err := j.Delete(key); err != nil {
+			return err
+		}
+		removed++
+		return nil
+	})
+	return removed, err
+}
+
 func (j *JSONLocalDb) PutRaw(id DbKey, b []byte) error       { return j.engine.Put(id, nil, b) }
 func (j *JSONLocalDb) GetRaw(id DbKey) ([]byte, bool, error) { return j.engine.Get(id) }
 func (j *JSONLocalDb) Delete(id DbKey) error                 { return j.engine.Delete(id) }
 
+// JSONPutManyItem is one row of a JSONLocalDb.PutMany batch.
+type JSONPutManyItem struct {
+	Key     DbKey
+	Aliases []DbKey
+	Value   *jsonw.Wrapper
+}
+
+// GetMany reads several rows at once, instead of looping over single-row
+// Gets and paying transaction overhead on each one.
+func (j *JSONLocalDb) GetMany(ids []DbKey) ([]*jsonw.Wrapper, []bool, error) {
+	raws, found, err := j.engine.GetMany(ids)
+	if err != nil {
+		return nil, nil, err
+	}
+	ret := make([]*jsonw.Wrapper, len(ids))
+	for i, raw := range raws {
+		if !found[i] {
+			continue
+		}
+		w, err := jsonw.Unmarshal(raw)
+		if err != nil {
+			return nil, nil, err
+		}
+		ret[i] = w
+	}
+	return ret, found, nil
+}
+
+// PutMany writes several rows as a single atomic batch.
+func (j *JSONLocalDb) PutMany(items []JSONPutManyItem) error {
+	dbItems := make([]DbPutManyItem, len(items))
+	for i, item := range items {
+		b, err := item.Value.Marshal()
+		if err != nil {
+			return err
+		}
+		dbItems[i] = DbPutManyItem{Key: item.Key, Aliases: item.Aliases, Value: b}
+	}
+	return j.engine.PutMany(dbItems)
+}
+
 func (j *JSONLocalDb) Put(id DbKey, aliases []DbKey, val *jsonw.Wrapper) error {
 	return jsonLocalDbPut(j.engine, id, aliases, val)
 }
@@ -0,0 +1,134 @@
+// Copyright 2015 Keybase, Inc. All rights reserved. Use of
+// this source code is governed by the included BSD license.
+
+package libkb
+
+import (
+	"sync"
+	"time"
+
+	"github.com/syndtr/goleveldb/leveldb/errors"
+	"github.com/syndtr/goleveldb/leveldb/storage"
+)
+
+// IsDbLockOrCorruptionError reports whether err is the kind of LocalDb
+// failure that a second process holding the lock file, or an on-disk
+// corruption, would produce -- as opposed to something like a logic bug or
+// a transient I/O error that retrying the same call wouldn't fix. Callers
+// that can treat the local cache as optional use this to decide whether to
+// fall back to degraded mode instead of failing outright.
+func IsDbLockOrCorruptionError(err error) bool {
+	if err == nil {
+		return false
+	}
+	if err == storage.ErrLocked {
+		return true
+	}
+	if _, ok := err.(*errors.ErrCorrupted); ok {
+		return true
+	}
+	if _, ok := err.(LevelDBOpenClosedError); ok {
+		return true
+	}
+	return false
+}
+
+// DbDegradationState tracks whether LocalDb-backed optional caches (PGP key
+// lookups, and anything else that opts in) have fallen back to memory-only
+// operation because LocalDb itself couldn't be used -- e.g. another process
+// is holding its lock file, or its files are corrupted. While degraded,
+// reads and writes against mem fall through to an in-process cache instead
+// of blocking or failing the caller.
+type DbDegradationState struct {
+	sync.RWMutex
+	degraded     bool
+	reason       string
+	since        time.Time
+	retryStarted bool
+	mem          map[DbKey][]byte
+}
+
+func newDbDegradationState() *DbDegradationState {
+	return &DbDegradationState{
+		mem: make(map[DbKey][]byte),
+	}
+}
+
+// MarkLocalDbDegraded switches the process into degraded mode (if it isn't
+// already), logging once prominently, and starts a single background
+// goroutine that periodically retries the real DB until it comes back, at
+// which point degraded mode is cleared. It's safe to call repeatedly -- only
+// the first call in a degraded spell logs or starts the retry loop.
+func (g *GlobalContext) MarkLocalDbDegraded(mctx MetaContext, reason string) {
+	d := g.dbDegradation
+	d.Lock()
+	alreadyDegraded := d.degraded
+	d.degraded = true
+	d.reason = reason
+	if d.since.IsZero() {
+		d.since = g.Clock().Now()
+	}
+	startRetry := !d.retryStarted
+	if startRetry {
+		d.retryStarted = true
+	}
+	d.Unlock()
+
+	if !alreadyDegraded {
+		mctx.G().Log.Errorf("LocalDb is unavailable (%s); falling back to an in-memory cache for optional data until it recovers", reason)
+	}
+	if startRetry {
+		go g.retryLocalDbUntilRecovered(mctx.BackgroundWithLogTags())
+	}
+}
+
+// retryLocalDbUntilRecovered polls LocalDb in the background while degraded,
+// and clears degraded mode (allowing a future failure to start a fresh retry
+// loop) as soon as a trivial round trip succeeds.
+func (g *GlobalContext) retryLocalDbUntilRecovered(mctx MetaContext) {
+	ticker := time.NewTicker(30 * time.Second)
+	defer ticker.Stop()
+	for range ticker.C {
+		if err := g.LocalDb.ForceOpen(); err == nil {
+			d := g.dbDegradation
+			d.Lock()
+			d.degraded = false
+			d.reason = ""
+			d.since = time.Time{}
+			d.retryStarted = false
+			d.Unlock()
+			mctx.Debug("LocalDb has recovered; leaving degraded mode")
+			return
+		}
+	}
+}
+
+// LocalDbDegraded reports whether LocalDb-backed optional caches are
+// currently running in memory-only degraded mode, why, and since when --
+// for surfacing in diagnostics.
+func (g *GlobalContext) LocalDbDegraded() (degraded bool, reason string, since time.Time) {
+	d := g.dbDegradation
+	d.RLock()
+	defer d.RUnlock()
+	return d.degraded, d.reason, d.since
+}
+
+// DegradedCachePut records val for key in the in-memory fallback used while
+// LocalDb is degraded. Entries here are best-effort only -- they are never
+// persisted and are dropped on restart.
+func (g *GlobalContext) DegradedCachePut(key DbKey, val []byte) {
+	d := g.dbDegradation
+	d.Lock()
+	defer d.Unlock()
+	d.mem[key] = val
+}
+
+// DegradedCacheGet returns the value last recorded for key via
+// DegradedCachePut, if any.
+func (g *GlobalContext) DegradedCacheGet(key DbKey) (val []byte, found bool) {
+	d := g.dbDegradation
+	d.RLock()
+	defer d.RUnlock()
+	val, found = d.mem[key]
+	return val, found
+}
@@ -0,0 +1,95 @@
+// Copyright 2015 Keybase, Inc. All rights reserved. Use of
+// this source code is governed by the included BSD license.
+
+package libkb
+
+import (
+	stderrors "errors"
+	"testing"
+
+	keybase1 "github.com/keybase/client/go/protocol/keybase1"
+	"github.com/stretchr/testify/require"
+	"github.com/syndtr/goleveldb/leveldb/storage"
+)
+
+// lockedLocalDb is a LocalDb that behaves as though another process is
+// holding its lock file: every operation fails with storage.ErrLocked.
+type lockedLocalDb struct {
+	locked bool
+}
+
+func (d *lockedLocalDb) err() error {
+	if d.locked {
+		return storage.ErrLocked
+	}
+	return nil
+}
+
+func (d *lockedLocalDb) Put(id DbKey, aliases []DbKey, value []byte) error { return d.err() }
+func (d *lockedLocalDb) Delete(id DbKey) error                             { return d.err() }
+func (d *lockedLocalDb) Get(id DbKey) ([]byte, bool, error)                { return nil, false, d.err() }
+func (d *lockedLocalDb) Lookup(alias DbKey) ([]byte, bool, error)          { return nil, false, d.err() }
+func (d *lockedLocalDb) GetMany(ids []DbKey) ([][]byte, []bool, error)     { return nil, nil, d.err() }
+func (d *lockedLocalDb) PutMany(items []DbPutManyItem) error               { return d.err() }
+func (d *lockedLocalDb) Open() error                                       { return nil }
+func (d *lockedLocalDb) Stats() string                                     { return "" }
+func (d *lockedLocalDb) CompactionStats() (bool, bool, error)              { return false, false, nil }
+func (d *lockedLocalDb) ForceOpen() error                                  { return d.err() }
+func (d *lockedLocalDb) Close() error                                      { return nil }
+func (d *lockedLocalDb) Nuke() (string, error)                             { return "", nil }
+func (d *lockedLocalDb) Clean(force bool) error                            { return nil }
+func (d *lockedLocalDb) OpenTransaction() (LocalDbTransaction, error)      { return nil, d.err() }
+func (d *lockedLocalDb) KeysWithPrefixes(prefixes ...[]byte) (DBKeySet, error) {
+	return nil, d.err()
+}
+func (d *lockedLocalDb) ScanPrefix(typ ObjType, prefix string, fn func(key DbKey, value []byte) error) error {
+	return d.err()
+}
+
+func TestIsDbLockOrCorruptionError(t *testing.T) {
+	require.True(t, IsDbLockOrCorruptionError(storage.ErrLocked))
+	require.True(t, IsDbLockOrCorruptionError(LevelDBOpenClosedError{}))
+	require.False(t, IsDbLockOrCorruptionError(nil))
+	require.False(t, IsDbLockOrCorruptionError(stderrors.New("some other error")))
+}
+
+func TestPGPKeyCacheDegradesOnLockedDb(t *testing.T) {
+	tc := SetupTest(t, "dbdegrade", 1)
+	defer tc.Cleanup()
+
+	tc.G.LocalDb = NewJSONLocalDb(&lockedLocalDb{locked: true})
+	mctx := NewMetaContextForTest(tc)
+
+	bundle, err := tc.MakePGPKey("dbdegrade@keybase.io")
+	require.NoError(t, err)
+
+	// Writes must not fail the caller just because LocalDb is unavailable.
+	require.NoError(t, bundle.StoreToLocalDb(mctx))
+
+	degraded, reason, _ := tc.G.LocalDbDegraded()
+	require.True(t, degraded)
+	require.NotEmpty(t, reason)
+
+	// Reads must never block an identify-style flow on LocalDb being down;
+	// this one should be served out of the in-memory fallback instead.
+	loaded, err := LoadPGPKeyFromLocalDB(mctx, bundle.GetKID())
+	require.NoError(t, err)
+	require.NotNil(t, loaded)
+	require.Equal(t, bundle.GetFingerprint(), loaded.GetFingerprint())
+}
+
+func TestLoadPGPKeyFromLocalDBMissOnLockedDbWithoutFallback(t *testing.T) {
+	tc := SetupTest(t, "dbdegrade", 1)
+	defer tc.Cleanup()
+
+	tc.G.LocalDb = NewJSONLocalDb(&lockedLocalDb{locked: true})
+	mctx := NewMetaContextForTest(tc)
+	kid := keybase1.KID("0808080808080808080808080808080808080808080808080808080808080808080808080808080808080808080808080808080808080808080808080808080808")
+
+	bundle, err := LoadPGPKeyFromLocalDB(mctx, kid)
+	require.NoError(t, err, "a locked DB with nothing cached should look like a miss, not an error")
+	require.Nil(t, bundle)
+
+	degraded, _, _ := tc.G.LocalDbDegraded()
+	require.True(t, degraded)
+}
@@ -0,0 +1,53 @@
+package libkb
+
+import (
+	"fmt"
+	"testing"
+
+	jsonw "github.com/keybase/go-jsonw"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJSONLocalDbScanPrefix(t *testing.T) {
+	db := NewJSONLocalDb(NewMemDb(100))
+
+	for i := 0; i < 5; i++ {
+		key := DbKey{Typ: 1, Key: fmt.Sprintf("row-%d", i)}
+		require.NoError(t, db.Put(key, nil, jsonw.NewInt(i)))
+	}
+
+	var total int
+	err := db.ScanPrefix(1, "row-", func(key DbKey, w *jsonw.Wrapper) error {
+		n, err := w.GetInt()
+		require.NoError(t, err)
+		total += n
+		return nil
+	})
+	require.NoError(t, err)
+	require.Equal(t, 0+1+2+3+4, total)
+
+	count, err := db.CountKeysWithPrefix(1, "row-")
+	require.NoError(t, err)
+	require.Equal(t, 5, count)
+}
+
+func TestJSONLocalDbSweepExpired(t *testing.T) {
+	db := NewJSONLocalDb(NewMemDb(100))
+
+	for i := 0; i < 5; i++ {
+		key := DbKey{Typ: 1, Key: fmt.Sprintf("row-%d", i)}
+		require.NoError(t, db.Put(key, nil, jsonw.NewInt(i)))
+	}
+
+	removed, err := db.SweepExpired(1, "row-", func(key DbKey, w *jsonw.Wrapper) bool {
+		n, err := w.GetInt()
+		require.NoError(t, err)
+		return n%2 == 0
+	})
+	require.NoError(t, err)
+	require.Equal(t, 3, removed)
+
+	count, err := db.CountKeysWithPrefix(1, "row-")
+	require.NoError(t, err)
+	require.Equal(t, 2, count)
+}
@@ -53,9 +53,11 @@ func (n NullConfiguration) GetLinkCacheCleanDur() (time.Duration, bool)
 func (n NullConfiguration) GetUPAKCacheSize() (int, bool)                                  { return 0, false }
 func (n NullConfiguration) GetUIDMapFullNameCacheSize() (int, bool)                        { return 0, false }
 func (n NullConfiguration) GetPayloadCacheSize() (int, bool)                               { return 0, false }
+func (n NullConfiguration) GetPGPEntityCacheSize() (int, bool)                             { return 0, false }
 func (n NullConfiguration) GetMerkleKIDs() []string                                        { return nil }
 func (n NullConfiguration) GetCodeSigningKIDs() []string                                   { return nil }
 func (n NullConfiguration) GetPinentry() string                                            { return "" }
+func (n NullConfiguration) GetPGPKeyServerURI() string                                     { return "" }
 func (n NullConfiguration) GetUID() (ret keybase1.UID)                                     { return }
 func (n NullConfiguration) GetGpg() string                                                 { return "" }
 func (n NullConfiguration) GetGpgOptions() []string                                        { return nil }
@@ -100,6 +102,7 @@ func (n NullConfiguration) GetUpdatePreferenceSkip() string                 { re
 func (n NullConfiguration) GetUpdateURL() string                            { return "" }
 func (n NullConfiguration) GetUpdateDisabled() (bool, bool)                 { return false, false }
 func (n NullConfiguration) GetVDebugSetting() string                        { return "" }
+func (n NullConfiguration) GetLogModuleLevels() string                      { return "" }
 func (n NullConfiguration) GetLocalTrackMaxAge() (time.Duration, bool)      { return 0, false }
 func (n NullConfiguration) GetGregorURI() string                            { return "" }
 func (n NullConfiguration) GetGregorSaveInterval() (time.Duration, bool)    { return 0, false }
@@ -163,6 +166,14 @@ func (n NullConfiguration) GetDebugJourneycard() (bool, bool) { return false, fa
 func (n NullConfiguration) GetDisplayRawUntrustedOutput() (bool, bool) {
 	return false, false
 }
+func (n NullConfiguration) GetRPCTraceEnabled() (bool, bool)    { return false, false }
+func (n NullConfiguration) GetVerifyTraceEnabled() (bool, bool) { return false, false }
+func (n NullConfiguration) GetPGPRefuseWeakSigning() (bool, bool) {
+	return false, false
+}
+func (n NullConfiguration) GetPGPRefuseWeakVerify() (bool, bool) {
+	return false, false
+}
 func (n NullConfiguration) GetLogFormat() string {
 	return ""
 }
@@ -398,6 +409,17 @@ func (e *Env) GetMountDir() (string, error) {
 	), nil
 }
 
+// GetPGPKeyServerURI returns the HKP keyserver that "keybase pgp
+// pull-remote" and "keybase pgp push-remote" talk to.
+func (e *Env) GetPGPKeyServerURI() string {
+	return e.GetString(
+		func() string { return e.cmd.GetPGPKeyServerURI() },
+		func() string { return os.Getenv("KEYBASE_PGP_KEY_SERVER_URI") },
+		func() string { return e.GetConfig().GetPGPKeyServerURI() },
+		func() string { return DefaultPGPKeyServerURI },
+	)
+}
+
 func NewEnv(cmd CommandLine, config ConfigReader, getLog LogGetter) *Env {
 	return newEnv(cmd, config, runtime.GOOS, getLog)
 }
@@ -842,6 +864,54 @@ func (e *Env) GetDisplayRawUntrustedOutput() bool {
 	)
 }
 
+// GetRPCTraceEnabled reports whether outgoing RPC clients should be wrapped
+// with a tracing GenericClient that tags each call with a trace ID. Off by
+// default since it's a debugging aid, not something every install needs.
+func (e *Env) GetRPCTraceEnabled() bool {
+	return e.GetBool(false,
+		func() (bool, bool) { return e.getEnvBool("KEYBASE_RPC_TRACE_ENABLED") },
+		func() (bool, bool) { return e.GetConfig().GetRPCTraceEnabled() },
+	)
+}
+
+// GetVerifyTraceEnabled reports whether signature verification attempts
+// should be recorded as structured debug-log lines (see VerifyTrace in
+// verify_trace.go). Off by default since it's a debugging aid, not
+// something every install needs -- turn it on to get verification detail
+// into a `keybase log send` bundle while chasing a signature bug.
+func (e *Env) GetVerifyTraceEnabled() bool {
+	return e.GetBool(false,
+		func() (bool, bool) { return e.getEnvBool("KEYBASE_VERIFY_TRACE_ENABLED") },
+		func() (bool, bool) { return e.GetConfig().GetVerifyTraceEnabled() },
+	)
+}
+
+// GetPGPRefuseWeakSigning reports whether SimpleSign should refuse to
+// produce a PGP signature hashed with an algorithm IsHashSecure considers
+// weak (e.g. SHA-1), returning a WeakHashError instead. Off by default: this
+// package has never produced a weak signature (go-crypto defaults to
+// SHA-256 on its own), so this is a belt-and-suspenders check for installs
+// that want it enforced explicitly rather than relied upon.
+func (e *Env) GetPGPRefuseWeakSigning() bool {
+	return e.GetBool(false,
+		func() (bool, bool) { return e.getEnvBool("KEYBASE_PGP_REFUSE_WEAK_SIGNING") },
+		func() (bool, bool) { return e.GetConfig().GetPGPRefuseWeakSigning() },
+	)
+}
+
+// GetPGPRefuseWeakVerify reports whether ParsedSig.Verify should fail a
+// signature hashed with a weak algorithm (surfacing
+// VerifyFailureWeakDigest) instead of accepting it with a
+// HashSecurityWarning, which is this package's long-standing default. Off
+// by default, since turning it on means older SHA-1 signatures -- self-sigs
+// in particular -- stop verifying outright rather than just being flagged.
+func (e *Env) GetPGPRefuseWeakVerify() bool {
+	return e.GetBool(false,
+		func() (bool, bool) { return e.getEnvBool("KEYBASE_PGP_REFUSE_WEAK_VERIFY") },
+		func() (bool, bool) { return e.GetConfig().GetPGPRefuseWeakVerify() },
+	)
+}
+
 func (e *Env) GetAutoFork() bool {
 	// On !Darwin, we auto-fork by default
 	def := (runtime.GOOS != "darwin")
@@ -1368,6 +1438,14 @@ func (e *Env) GetPayloadCacheSize() int {
 	)
 }
 
+func (e *Env) GetPGPEntityCacheSize() int {
+	return e.GetInt(PGPEntityCacheSize,
+		e.cmd.GetPGPEntityCacheSize,
+		func() (int, bool) { return e.getEnvInt("KEYBASE_PGP_ENTITY_CACHE_SIZE") },
+		e.GetConfig().GetPGPEntityCacheSize,
+	)
+}
+
 func (e *Env) GetEmailOrUsername() string {
 	un := e.GetUsername().String()
 	if len(un) > 0 {
@@ -1780,6 +1858,7 @@ type AppConfig struct {
 	LocalRPCDebug                  string
 	ServerURI                      string
 	VDebugSetting                  string
+	LogModuleLevels                string
 	SecurityAccessGroupOverride    bool
 	ChatInboxSourceLocalizeThreads int
 	MobileExtension                bool
@@ -1788,6 +1867,8 @@ type AppConfig struct {
 	LinkCacheSize                  int
 	UPAKCacheSize                  int
 	PayloadCacheSize               int
+	PGPEntityCacheSize             int
+	PGPKeyServerURI                string
 	ProofCacheSize                 int
 	DisableTeamAuditor             bool
 	DisableMerkleAuditor           bool
@@ -1847,6 +1928,10 @@ func (c AppConfig) GetServerURI() (string, error) {
 	return c.ServerURI, nil
 }
 
+func (c AppConfig) GetPGPKeyServerURI() string {
+	return c.PGPKeyServerURI
+}
+
 func (c AppConfig) GetSecurityAccessGroupOverride() (bool, bool) {
 	return c.SecurityAccessGroupOverride, c.SecurityAccessGroupOverride
 }
@@ -1871,6 +1956,10 @@ func (c AppConfig) GetVDebugSetting() string {
 	return c.VDebugSetting
 }
 
+func (c AppConfig) GetLogModuleLevels() string {
+	return c.LogModuleLevels
+}
+
 func (c AppConfig) GetChatInboxSourceLocalizeThreads() (int, bool) {
 	return c.ChatInboxSourceLocalizeThreads, true
 }
@@ -1917,6 +2006,13 @@ func (c AppConfig) GetPayloadCacheSize() (int, bool) {
 	return 0, false
 }
 
+func (c AppConfig) GetPGPEntityCacheSize() (int, bool) {
+	if c.PGPEntityCacheSize != 0 {
+		return c.PGPEntityCacheSize, true
+	}
+	return 0, false
+}
+
 func (c AppConfig) GetProofCacheSize() (int, bool) {
 	if c.ProofCacheSize != 0 {
 		return c.ProofCacheSize, true
@@ -1993,6 +2089,17 @@ func (e *Env) GetVDebugSetting() string {
 	)
 }
 
+// GetLogModuleLevels returns a "module=level,module=level" startup setting
+// for per-module log level overrides (see ModuleLogLevels).
+func (e *Env) GetLogModuleLevels() string {
+	return e.GetString(
+		func() string { return e.cmd.GetLogModuleLevels() },
+		func() string { return os.Getenv("KEYBASE_LOG_MODULE_LEVELS") },
+		func() string { return e.GetConfig().GetLogModuleLevels() },
+		func() string { return "" },
+	)
+}
+
 func (e *Env) GetRunModeAsString() string {
 	return string(e.GetRunMode())
 }
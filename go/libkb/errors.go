@@ -435,8 +435,38 @@ func (k KeyExistsError) Error() string {
 
 //=============================================================================
 
+// PassphraseErrorComponent identifies which key component rejected a
+// passphrase, for callers that type-assert PassphraseError to build a more
+// informative unlock UI.
+type PassphraseErrorComponent string
+
+const (
+	PassphraseErrorComponentUnknown PassphraseErrorComponent = ""
+	PassphraseErrorComponentPrimary PassphraseErrorComponent = "primary"
+	PassphraseErrorComponentSubkey  PassphraseErrorComponent = "subkey"
+)
+
+// PassphraseError indicates that a passphrase failed to unlock a secret
+// key. Its Error() string stays terse ("Bad password"-ish); callers that
+// want to drive a richer unlock UI (attempts remaining, lockout backoff,
+// which key component rejected it) should type-assert to this type and
+// read the fields below, which are all best-effort and may be zero-valued
+// when that context isn't available.
 type PassphraseError struct {
 	Msg string
+
+	// KID of the key that rejected the passphrase, if known.
+	KID keybase1.KID
+	// Component is which part of the key rejected it (primary vs a
+	// specific subkey), if known.
+	Component PassphraseErrorComponent
+	// AttemptsRemaining is how many more tries the KeyUnlocker policy will
+	// allow before giving up, if the caller is tracking that. nil means
+	// unknown/not applicable.
+	AttemptsRemaining *int
+	// RetryAfter is when a lockout backoff expires, if one is in effect.
+	// Zero means no backoff is imposed.
+	RetryAfter time.Time
 }
 
 func (p PassphraseError) Error() string {
@@ -14,6 +14,89 @@ type VerifyContext interface {
 	Debug(format string, args ...interface{})
 }
 
+// VerifyFailureReason classifies why a signature verification attempt
+// failed, for callers (like the identify engine) that want to aggregate or
+// display *why* a proof is broken instead of just that it is.
+type VerifyFailureReason int
+
+const (
+	VerifyFailureUnknown VerifyFailureReason = iota
+	VerifyFailureBadSignature
+	VerifyFailureWrongKey
+	VerifyFailureExpiredKey
+	VerifyFailureWeakDigest
+	VerifyFailureParseError
+)
+
+func (r VerifyFailureReason) String() string {
+	switch r {
+	case VerifyFailureBadSignature:
+		return "bad signature"
+	case VerifyFailureWrongKey:
+		return "wrong key"
+	case VerifyFailureExpiredKey:
+		return "expired key"
+	case VerifyFailureWeakDigest:
+		return "weak digest rejected"
+	case VerifyFailureParseError:
+		return "parse failure"
+	default:
+		return "unknown"
+	}
+}
+
+// VerifyContextHooks is an optional extension of VerifyContext for callers
+// that want structured telemetry about signature verification -- what key
+// and signature ID were checked, and if it failed, a coarse reason why. It's
+// kept separate from VerifyContext, and detected with a type assertion at
+// each call site, so the many existing VerifyContext implementations (which
+// are usually just a bare Debug-only logger) keep compiling unchanged.
+//
+// Implementers should embed BaseVerifyContextHooks and override only the
+// methods they care about.
+type VerifyContextHooks interface {
+	// OnVerifyAttempt is called before a signature is checked.
+	OnVerifyAttempt(keyFingerprint string, sigID keybase1.SigIDBase)
+	// OnVerifyFailure is called when a signature fails to check. details is
+	// for logging/telemetry, not for display to the user.
+	OnVerifyFailure(reason VerifyFailureReason, details string)
+	// OnVerifyWarning is called after a signature checks out if Verify
+	// noticed something about it worth surfacing anyway, such as a
+	// cryptographically weak hash algorithm.
+	OnVerifyWarning(keyFingerprint string, sigID keybase1.SigIDBase, warnings HashSecurityWarnings)
+}
+
+// BaseVerifyContextHooks is a no-op VerifyContextHooks. Embed it in a
+// VerifyContext implementation to pick up the optional hooks without having
+// to implement every method.
+type BaseVerifyContextHooks struct{}
+
+func (BaseVerifyContextHooks) OnVerifyAttempt(keyFingerprint string, sigID keybase1.SigIDBase) {}
+func (BaseVerifyContextHooks) OnVerifyFailure(reason VerifyFailureReason, details string)      {}
+func (BaseVerifyContextHooks) OnVerifyWarning(keyFingerprint string, sigID keybase1.SigIDBase, warnings HashSecurityWarnings) {
+}
+
+func verifyAttempt(ctx VerifyContext, keyFingerprint string, sigID keybase1.SigIDBase) {
+	if hooks, ok := ctx.(VerifyContextHooks); ok {
+		hooks.OnVerifyAttempt(keyFingerprint, sigID)
+	}
+}
+
+func verifyFailure(ctx VerifyContext, reason VerifyFailureReason, details string) {
+	if hooks, ok := ctx.(VerifyContextHooks); ok {
+		hooks.OnVerifyFailure(reason, details)
+	}
+}
+
+func verifyWarning(ctx VerifyContext, keyFingerprint string, sigID keybase1.SigIDBase, warnings HashSecurityWarnings) {
+	if len(warnings) == 0 {
+		return
+	}
+	if hooks, ok := ctx.(VerifyContextHooks); ok {
+		hooks.OnVerifyWarning(keyFingerprint, sigID, warnings)
+	}
+}
+
 type RawPublicKey []byte
 type RawPrivateKey []byte
 
@@ -52,6 +52,7 @@ type GlobalContext struct {
 	Log                              logger.Logger         // Handles all logging
 	PerfLog                          logger.Logger         // Handles all performance event logging
 	VDL                              *VDebugLog            // verbose debug log
+	ModuleLogLevels                  *ModuleLogLevels      // per-module log level overrides
 	GUILogFile                       *logger.LogFileWriter // GUI logs
 	Env                              *Env                  // Env variables, cmdline args & config
 	SKBKeyringMu                     *sync.Mutex           // Protects all attempts to mutate the SKBKeyringFile
@@ -64,6 +65,7 @@ type GlobalContext struct {
 	RemoteNetworkInstrumenterStorage *DiskInstrumentationStorage // Instrument Remote API/RPC calls
 	LocalDb                          *JSONLocalDb                // Local DB for cache
 	LocalChatDb                      *JSONLocalDb                // Local DB for cache
+	dbDegradation                    *DbDegradationState         // Tracks whether LocalDb-backed optional caches have fallen back to memory-only
 	MerkleClient                     MerkleClientInterface       // client for querying server's merkle sig tree
 	XAPI                             ExternalAPI                 // for contacting Twitter, Github, etc.
 	DNSNSFetcher                     DNSNameServerFetcher        // The mobile apps potentially pass an implementor of this interface which is used to grab currently configured DNS name servers
@@ -107,6 +109,7 @@ type GlobalContext struct {
 	paramProofStore        MerkleStore        // a cache and fetcher for param proofs
 	externalURLStore       MerkleStore        // a cache and fetcher for external urls
 	PayloadCache           *PayloadCache      // cache of ChainLink payload json wrappers
+	PGPEntityCache         *PGPEntityCache    // cache of parsed PGP entities, keyed by armor hash
 	kvRevisionCache        KVRevisionCacher   // cache of revisions for verifying key-value store results
 	Pegboard               *Pegboard
 
@@ -195,6 +198,7 @@ func (g *GlobalContext) GetLog() logger.Logger                         { return
 func (g *GlobalContext) GetPerfLog() logger.Logger                     { return g.PerfLog }
 func (g *GlobalContext) GetGUILogWriter() io.Writer                    { return g.GUILogFile }
 func (g *GlobalContext) GetVDebugLog() *VDebugLog                      { return g.VDL }
+func (g *GlobalContext) GetModuleLogLevels() *ModuleLogLevels          { return g.ModuleLogLevels }
 func (g *GlobalContext) GetAPI() API                                   { return g.API }
 func (g *GlobalContext) GetExternalAPI() ExternalAPI                   { return g.XAPI }
 func (g *GlobalContext) GetServerURI() (string, error)                 { return g.Env.GetServerURI() }
@@ -225,6 +229,7 @@ func NewGlobalContext() *GlobalContext {
 		Log:                log,
 		PerfLog:            log,
 		VDL:                NewVDebugLog(log),
+		ModuleLogLevels:    NewModuleLogLevels(),
 		SKBKeyringMu:       new(sync.Mutex),
 		perUserKeyringMu:   new(sync.Mutex),
 		vidMu:              new(sync.Mutex),
@@ -247,6 +252,7 @@ func NewGlobalContext() *GlobalContext {
 		Pegboard:           NewPegboard(),
 		random:             &SecureRandom{},
 		RuntimeStats:       NewDummyRuntimeStats(),
+		dbDegradation:      newDbDegradationState(),
 	}
 	ret.TeamMemberCountCache = newTeamMemberCountCache(ret)
 	return ret
@@ -381,6 +387,7 @@ func (g *GlobalContext) ConfigureLogging(usage *Usage) error {
 		}
 	}
 	g.VDL.Configure(g.Env.GetVDebugSetting())
+	g.ModuleLogLevels.ConfigureFromString(g.Env.GetLogModuleLevels())
 
 	// On Linux, the post-install script calls `keybase --use-root-config-file
 	// config get --direct` to figure out if the redirector should be enabled or not.
@@ -630,6 +637,7 @@ func (g *GlobalContext) configureMemCachesLocked(isFlush bool) {
 	g.upakLoader = NewCachedUPAKLoader(g, CachedUserTimeout)
 	g.Log.Debug("made a new cached UPAK loader (timeout=%v)", CachedUserTimeout)
 	g.PayloadCache = NewPayloadCache(g, g.Env.GetPayloadCacheSize())
+	g.PGPEntityCache = NewPGPEntityCache(g, g.Env.GetPGPEntityCacheSize())
 }
 
 func (g *GlobalContext) ConfigureCaches() error {
@@ -0,0 +1,143 @@
+// Copyright 2015 Keybase, Inc. All rights reserved. Use of
+// this source code is governed by the included BSD license.
+
+package libkb
+
+import (
+	"fmt"
+	"sync"
+)
+
+// gpgMinVersion is the oldest gpg we're willing to use as a signing
+// fallback. Older gpgs (notably 1.4) and broken agents fail deep inside
+// signing with inscrutable exec errors, so we'd rather catch it up front.
+const gpgMinVersion = "2.1.0"
+
+// GPGCapabilities records what we found out about the local gpg
+// installation the last time we probed it.
+type GPGCapabilities struct {
+	Version         string
+	MinVersionOK    bool
+	AgentAvailable  bool
+	HasFingerprint  bool
+	CanSignDetached bool
+}
+
+// GPGCapabilityError explains precisely why the gpg fallback can't be used,
+// instead of letting SignToString attempt it and fail with a raw exec error.
+type GPGCapabilityError struct {
+	Fingerprint PGPFingerprint
+	Reason      string
+}
+
+func (e GPGCapabilityError) Error() string {
+	return fmt.Sprintf("gpg fallback unavailable for %s: %s", e.Fingerprint.ToKeyID(), e.Reason)
+}
+
+// gpgCapabilityProbe does the actual work of probing gpg. It's a package
+// var so tests can swap in a mock and avoid shelling out.
+var gpgCapabilityProbe = probeGPGCapabilities
+
+var gpgCapabilityCache struct {
+	sync.Mutex
+	byFingerprint map[string]gpgCapabilityResult
+}
+
+type gpgCapabilityResult struct {
+	caps GPGCapabilities
+	err  error
+}
+
+// Capabilities probes the configured gpg binary for everything SignToString's
+// fallback path needs to know, caching the result for the rest of the
+// process's lifetime (the probe shells out, so it's not something we want to
+// redo on every sign).
+func (g *GPGKey) Capabilities(mctx MetaContext) (GPGCapabilities, error) {
+	key := ""
+	if g.fp != nil {
+		key = g.fp.String()
+	}
+
+	gpgCapabilityCache.Lock()
+	if gpgCapabilityCache.byFingerprint == nil {
+		gpgCapabilityCache.byFingerprint = make(map[string]gpgCapabilityResult)
+	}
+	if res, ok := gpgCapabilityCache.byFingerprint[key]; ok {
+		gpgCapabilityCache.Unlock()
+		return res.caps, res.err
+	}
+	gpgCapabilityCache.Unlock()
+
+	caps, err := gpgCapabilityProbe(mctx, g.fp)
+
+	gpgCapabilityCache.Lock()
+	gpgCapabilityCache.byFingerprint[key] = gpgCapabilityResult{caps: caps, err: err}
+	gpgCapabilityCache.Unlock()
+
+	return caps, err
+}
+
+// CheckCapabilities returns a GPGCapabilityError describing the first thing
+// missing for signing with this key via the gpg fallback, or nil if gpg is
+// ready to go.
+func (g *GPGKey) CheckCapabilities(mctx MetaContext) error {
+	caps, err := g.Capabilities(mctx)
+	if err != nil {
+		return err
+	}
+	fp := PGPFingerprint{}
+	if g.fp != nil {
+		fp = *g.fp
+	}
+	switch {
+	case !caps.MinVersionOK:
+		return GPGCapabilityError{Fingerprint: fp, Reason: fmt.Sprintf("gpg %s found; version %s+ required", caps.Version, gpgMinVersion)}
+	case !caps.HasFingerprint:
+		return GPGCapabilityError{Fingerprint: fp, Reason: "fingerprint not found in gpg keyring"}
+	case !caps.CanSignDetached:
+		return GPGCapabilityError{Fingerprint: fp, Reason: "detached signing is not available (check gpg-agent)"}
+	}
+	return nil
+}
+
+func probeGPGCapabilities(mctx MetaContext, fp *PGPFingerprint) (caps GPGCapabilities, err error) {
+	gpg := mctx.G().GetGpgClient()
+	if gpg == nil {
+		return caps, GPGUnavailableError{}
+	}
+	ok, err := gpg.CanExec(mctx)
+	if err != nil {
+		return caps, err
+	}
+	if !ok {
+		return caps, GPGUnavailableError{}
+	}
+
+	caps.Version, err = gpg.Version()
+	if err != nil {
+		return caps, err
+	}
+
+	caps.MinVersionOK, err = gpg.VersionAtLeast(gpgMinVersion)
+	if err != nil {
+		mctx.Debug("| gpg capability probe: VersionAtLeast failed: %s", err)
+		caps.MinVersionOK = false
+		err = nil
+	}
+
+	if fp != nil {
+		res := gpg.Run2(mctx, RunGpg2Arg{Arguments: []string{"--list-keys", fp.String()}})
+		if res.Err == nil {
+			caps.HasFingerprint = res.Wait() == nil
+		}
+	}
+
+	// We consider the agent available, and detached signing workable,
+	// once we have a modern-enough gpg with the key actually present.
+	// There's no cheap way to probe the agent without attempting a real
+	// signature, which is exactly what we're trying to avoid doing blind.
+	caps.AgentAvailable = caps.MinVersionOK
+	caps.CanSignDetached = caps.MinVersionOK && caps.HasFingerprint
+
+	return caps, nil
+}
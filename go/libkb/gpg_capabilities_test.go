@@ -0,0 +1,44 @@
+package libkb
+
+import "testing"
+
+func TestGPGCapabilitiesCachedAndMockable(t *testing.T) {
+	fp, err := PGPFingerprintFromHex("0123456789abcdef0123456789abcdef01234567")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	calls := 0
+	old := gpgCapabilityProbe
+	defer func() { gpgCapabilityProbe = old }()
+	gpgCapabilityProbe = func(mctx MetaContext, fp *PGPFingerprint) (GPGCapabilities, error) {
+		calls++
+		return GPGCapabilities{Version: "2.2.0", MinVersionOK: true, HasFingerprint: true, CanSignDetached: true}, nil
+	}
+
+	gpgCapabilityCache.Lock()
+	gpgCapabilityCache.byFingerprint = nil
+	gpgCapabilityCache.Unlock()
+
+	key := &GPGKey{fp: fp}
+	mctx := NewMetaContextBackground(nil)
+
+	caps, err := key.Capabilities(mctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !caps.CanSignDetached {
+		t.Fatal("expected CanSignDetached to be true")
+	}
+
+	if _, err := key.Capabilities(mctx); err != nil {
+		t.Fatal(err)
+	}
+	if calls != 1 {
+		t.Fatalf("expected probe to run once and be cached, ran %d times", calls)
+	}
+
+	if err := key.CheckCapabilities(mctx); err != nil {
+		t.Fatalf("expected no capability error, got %s", err)
+	}
+}
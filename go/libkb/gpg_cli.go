@@ -304,6 +304,9 @@ type RunGpg2Arg struct {
 	Stderr    bool
 	Stdout    bool
 	TTY       string
+	// HomeDir, if set, runs gpg against this GNUPGHOME instead of the
+	// user's default one (e.g. a scratch keyring for one-off verification).
+	HomeDir string
 }
 
 type RunGpg2Res struct {
@@ -320,7 +323,11 @@ func (g *GpgCLI) Run2(mctx MetaContext, arg RunGpg2Arg) (res RunGpg2Res) {
 		return
 	}
 
-	cmd := g.MakeCmd(mctx, arg.Arguments, arg.TTY)
+	args := arg.Arguments
+	if arg.HomeDir != "" {
+		args = append([]string{"--homedir", arg.HomeDir}, args...)
+	}
+	cmd := g.MakeCmd(mctx, args, arg.TTY)
 
 	if arg.Stdin {
 		if res.Stdin, res.Err = cmd.StdinPipe(); res.Err != nil {
@@ -0,0 +1,106 @@
+// Copyright 2020 Keybase, Inc. All rights reserved. Use of
+// this source code is governed by the included BSD license.
+
+package libkb
+
+import (
+	"bytes"
+	"fmt"
+)
+
+// ImportSecretFromGPG fills in k's secret key material from the local gpg
+// keyring, for the GPGFallbackKey scenario where Keybase has only ever held
+// k's public half. Some operations -- exporting an encrypted backup,
+// provisioning another device -- genuinely need the secret material
+// in-process.
+//
+// gpg 2.x normally unlocks a secret key by popping its own pinentry, which
+// has nowhere to show itself from a headless service. We drive gpg with
+// --pinentry-mode loopback instead, so *we* supply the passphrase over a
+// pipe after prompting for it with secretUI, the same SecretUI a caller
+// would otherwise hand to gpg's pinentry. The exported secret is read
+// straight from gpg's stdout pipe, so there's no temp file to scrub.
+func (k *PGPKeyBundle) ImportSecretFromGPG(m MetaContext, secretUI SecretUI) (err error) {
+	defer m.Trace("PGPKeyBundle#ImportSecretFromGPG", &err)()
+
+	fp := k.GetFingerprint()
+
+	gpg := m.G().GetGpgClient()
+	if err = gpg.Configure(m); err != nil {
+		return err
+	}
+
+	passphraseRes, err := GetSecret(m, secretUI, "GPG Passphrase",
+		fmt.Sprintf("Enter the passphrase for PGP key %s to unlock it in GPG", fp), "", false)
+	if err != nil {
+		return err
+	}
+
+	res := gpg.Run2(m, RunGpg2Arg{
+		Arguments: []string{
+			"--batch", "--pinentry-mode", "loopback", "--passphrase-fd", "0",
+			"--armor", "--export-secret-key", fp.String(),
+		},
+		Stdin:  true,
+		Stdout: true,
+		Stderr: true,
+	})
+	if res.Err != nil {
+		return res.Err
+	}
+	if _, err = res.Stdin.Write([]byte(passphraseRes.Passphrase + "\n")); err != nil {
+		return err
+	}
+	if err = res.Stdin.Close(); err != nil {
+		return err
+	}
+
+	var armoredBuf, stderrBuf bytes.Buffer
+	stderrErrCh := make(chan error, 1)
+	go func() { _, e := stderrBuf.ReadFrom(res.Stderr); stderrErrCh <- e }()
+	_, armorErr := armoredBuf.ReadFrom(res.Stdout)
+	stderrErr := <-stderrErrCh
+	// gpg's exit code here isn't a reliable signal -- a cancelled pinentry
+	// and a key with no usable secret material both look like "gpg
+	// returned an error" to Wait. We classify based on what actually came
+	// back on the pipes instead.
+	waitErr := res.Wait()
+	if armorErr != nil {
+		return armorErr
+	}
+	if stderrErr != nil {
+		return stderrErr
+	}
+
+	if gpgPassphraseWasCanceled(stderrBuf.String()) {
+		return InputCanceledError{}
+	}
+
+	armored := PosixLineEndings(armoredBuf.String())
+	if len(armored) == 0 {
+		if waitErr != nil {
+			m.Debug("ImportSecretFromGPG: gpg export failed: %s", waitErr)
+		}
+		return NoKeyError{fmt.Sprintf("No secret key found in GPG keyring for fingerprint %s", fp)}
+	}
+
+	secretBundle, w, err := ReadPrivateKeyFromString(armored)
+	if err != nil {
+		return err
+	}
+	w.Warn(m.G())
+
+	if err = secretBundle.CheckFingerprint(&fp); err != nil {
+		return err
+	}
+
+	k.CopySecretKeyMaterial(secretBundle)
+	return nil
+}
+
+// gpgPassphraseWasCanceled reports whether gpg's stderr indicates the
+// passphrase prompt behind --passphrase-fd was cancelled, rather than simply
+// getting a wrong passphrase or hitting some other export failure.
+func gpgPassphraseWasCanceled(stderr string) bool {
+	return bytes.Contains([]byte(stderr), []byte("Operation cancelled"))
+}
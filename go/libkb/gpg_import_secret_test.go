@@ -0,0 +1,90 @@
+// Copyright 2020 Keybase, Inc. All rights reserved. Use of
+// this source code is governed by the included BSD license.
+
+package libkb
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestImportSecretFromGPG(t *testing.T) {
+	tc := SetupTest(t, "gpg_import_secret", 1)
+	defer tc.Cleanup()
+
+	err := tc.GenerateGPGKeyring("no@no.no")
+	require.NoError(t, err)
+
+	cli := NewGpgCLI(tc.G, nil)
+	require.NoError(t, cli.Configure(tc.MetaContext()))
+	tc.G.GpgClient = cli
+
+	index, _, err := cli.Index(tc.MetaContext(), true, "")
+	require.NoError(t, err)
+	fps := index.AllFingerprints()
+	require.Len(t, fps, 1)
+	fp := fps[0]
+
+	publicBundle, err := cli.ImportKey(tc.MetaContext(), false /* secret */, fp, "")
+	require.NoError(t, err)
+	require.False(t, publicBundle.HasSecretKey())
+
+	secretUI := &TestSecretUI{Passphrase: ""}
+	err = publicBundle.ImportSecretFromGPG(tc.MetaContext(), secretUI)
+	require.NoError(t, err)
+	require.True(t, secretUI.CalledGetPassphrase)
+	require.True(t, publicBundle.HasSecretKey())
+}
+
+func TestImportSecretFromGPGNoKey(t *testing.T) {
+	tc := SetupTest(t, "gpg_import_secret", 1)
+	defer tc.Cleanup()
+
+	err := tc.GenerateGPGKeyring("no@no.no")
+	require.NoError(t, err)
+
+	cli := NewGpgCLI(tc.G, nil)
+	require.NoError(t, cli.Configure(tc.MetaContext()))
+	tc.G.GpgClient = cli
+
+	index, _, err := cli.Index(tc.MetaContext(), true, "")
+	require.NoError(t, err)
+	fps := index.AllFingerprints()
+	require.Len(t, fps, 1)
+
+	otherBundle, err := tc.MakePGPKey("nobody@nowhere.com")
+	require.NoError(t, err)
+
+	secretUI := &TestSecretUI{Passphrase: ""}
+	err = otherBundle.ImportSecretFromGPG(tc.MetaContext(), secretUI)
+	require.Error(t, err)
+	require.IsType(t, NoKeyError{}, err)
+}
+
+func TestImportSecretFromGPGCanceled(t *testing.T) {
+	tc := SetupTest(t, "gpg_import_secret", 1)
+	defer tc.Cleanup()
+
+	err := tc.GenerateGPGKeyring("no@no.no")
+	require.NoError(t, err)
+
+	cli := NewGpgCLI(tc.G, nil)
+	require.NoError(t, cli.Configure(tc.MetaContext()))
+	tc.G.GpgClient = cli
+
+	index, _, err := cli.Index(tc.MetaContext(), true, "")
+	require.NoError(t, err)
+	fps := index.AllFingerprints()
+	require.Len(t, fps, 1)
+	fp := fps[0]
+
+	publicBundle, err := cli.ImportKey(tc.MetaContext(), false /* secret */, fp, "")
+	require.NoError(t, err)
+
+	secretUI := &TestCancelSecretUI{}
+	err = publicBundle.ImportSecretFromGPG(tc.MetaContext(), secretUI)
+	require.Error(t, err)
+	require.IsType(t, InputCanceledError{}, err)
+	require.False(t, publicBundle.HasSecretKey())
+}
@@ -80,12 +80,37 @@ func (g *GPGKey) SignToStringMctx(mctx MetaContext, msg []byte) (sig string, id
 	return sig, id, nil
 }
 
+// VerifyStringAndExtract verifies sig against g's fingerprint by driving
+// gpg, rather than our in-process openpgp fork -- useful for signatures
+// whose algorithm the fork can't handle but gpg itself can.
 func (g *GPGKey) VerifyStringAndExtract(ctx VerifyContext, sig string) (msg []byte, id keybase1.SigIDBase, err error) {
-	return msg, id, errors.New("VerifyStringAndExtract not implemented")
+	if g.fp == nil {
+		return nil, id, NoKeyError{"GPGKey has no fingerprint to verify against"}
+	}
+	mctx := NewMetaContext(context.TODO(), g.G())
+	msg, err = verifyWithGPG(mctx, *g.fp, sig)
+	if err != nil {
+		return nil, id, err
+	}
+	h := sha256.New()
+	if _, err = h.Write(msg); err != nil {
+		return nil, id, err
+	}
+	var hsh [32]byte
+	copy(hsh[:], h.Sum(nil))
+	id = keybase1.SigIDBaseFromBytes(hsh)
+	return msg, id, nil
 }
 
 func (g *GPGKey) VerifyString(ctx VerifyContext, sig string, msg []byte) (id keybase1.SigIDBase, err error) {
-	return id, errors.New("VerifyString not implemented")
+	extractedMsg, resID, err := g.VerifyStringAndExtract(ctx, sig)
+	if err != nil {
+		return id, err
+	}
+	if !FastByteArrayEq(extractedMsg, msg) {
+		return id, BadSigError{"wrong payload"}
+	}
+	return resID, nil
 }
 
 func (g *GPGKey) EncryptToString(plaintext []byte, sender GenericKey) (ciphertext string, err error) {
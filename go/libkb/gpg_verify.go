@@ -0,0 +1,145 @@
+// Copyright 2016 Keybase, Inc. All rights reserved. Use of
+// this source code is governed by the included BSD license.
+
+package libkb
+
+import (
+	"bufio"
+	"bytes"
+	"io/ioutil"
+	"os"
+	"strings"
+)
+
+// SigVerifyResult is the parsed outcome of driving `gpg --status-fd` against
+// a signature, for algorithms our in-process openpgp fork can't verify
+// itself.
+type SigVerifyResult struct {
+	// Good is true if gpg reported GOODSIG.
+	Good bool
+	// BadSig is true if gpg reported BADSIG.
+	BadSig bool
+	// Fingerprint is the signing key's fingerprint, from VALIDSIG. Nil if
+	// gpg never emitted a VALIDSIG line.
+	Fingerprint *PGPFingerprint
+}
+
+// verifyWithGPG shells out to gpg to verify sig, which is expected to have
+// been signed by expectedFP, and returns the literal message embedded in
+// sig. It drives gpg against a throwaway keyring containing only
+// expectedFP's public key, so a forged signature from some other key gpg
+// happens to know about can't pass. The throwaway keyring is deleted before
+// this returns; it never touches the user's real keyring and never holds
+// secret material.
+func verifyWithGPG(mctx MetaContext, expectedFP PGPFingerprint, sig string) (msg []byte, err error) {
+	gpg := mctx.G().GetGpgClient()
+	if err = gpg.Configure(mctx); err != nil {
+		return nil, err
+	}
+
+	armoredPub, err := gpg.ImportKeyArmored(mctx, false /* secret */, expectedFP, "")
+	if err != nil {
+		return nil, err
+	}
+
+	tmpDir, err := ioutil.TempDir("", "keybase-gpg-verify")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(tmpDir)
+
+	importRes := gpg.Run2(mctx, RunGpg2Arg{
+		Arguments: []string{"--import"},
+		Stdin:     true,
+		HomeDir:   tmpDir,
+	})
+	if importRes.Err != nil {
+		return nil, importRes.Err
+	}
+	if _, err = importRes.Stdin.Write([]byte(armoredPub)); err != nil {
+		return nil, err
+	}
+	if err = importRes.Stdin.Close(); err != nil {
+		return nil, err
+	}
+	if err = importRes.Wait(); err != nil {
+		return nil, err
+	}
+
+	verifyRes := gpg.Run2(mctx, RunGpg2Arg{
+		// For an inline (non-detached) signed message, gpg's --verify
+		// also emits the literal data to stdout, same as --decrypt
+		// would for an unencrypted signed message. Status lines go to
+		// stderr so they don't get mixed in with that literal data.
+		Arguments: []string{"--status-fd", "2", "--verify"},
+		Stdin:     true,
+		Stdout:    true,
+		Stderr:    true,
+		HomeDir:   tmpDir,
+	})
+	if verifyRes.Err != nil {
+		return nil, verifyRes.Err
+	}
+	if _, err = verifyRes.Stdin.Write([]byte(sig)); err != nil {
+		return nil, err
+	}
+	if err = verifyRes.Stdin.Close(); err != nil {
+		return nil, err
+	}
+
+	var msgBuf, statusBuf bytes.Buffer
+	msgErrCh := make(chan error, 1)
+	go func() { _, e := msgBuf.ReadFrom(verifyRes.Stdout); msgErrCh <- e }()
+	_, statusErr := statusBuf.ReadFrom(verifyRes.Stderr)
+	msgErr := <-msgErrCh
+	// gpg exits non-zero on a bad signature; we determine validity from
+	// the parsed status lines below, not the exit code, so ignore Wait's
+	// error here as long as we could read both pipes.
+	_ = verifyRes.Wait()
+	if msgErr != nil {
+		return nil, msgErr
+	}
+	if statusErr != nil {
+		return nil, statusErr
+	}
+
+	res := parseGPGVerifyStatus(statusBuf.String())
+	if res.BadSig {
+		return nil, BadSigError{"gpg reported a bad signature"}
+	}
+	if !res.Good {
+		return nil, BadSigError{"gpg did not report a good signature"}
+	}
+	if res.Fingerprint == nil || !res.Fingerprint.Eq(expectedFP) {
+		got := expectedFP
+		if res.Fingerprint != nil {
+			got = *res.Fingerprint
+		}
+		return nil, BadFingerprintError{got, expectedFP}
+	}
+
+	return msgBuf.Bytes(), nil
+}
+
+// parseGPGVerifyStatus picks the GOODSIG/BADSIG/VALIDSIG lines out of gpg's
+// --status-fd output. See gpg's DETAILS doc for the line formats.
+func parseGPGVerifyStatus(status string) (res SigVerifyResult) {
+	scanner := bufio.NewScanner(strings.NewReader(status))
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 3 || fields[0] != "[GNUPG:]" {
+			continue
+		}
+		switch fields[1] {
+		case "GOODSIG":
+			res.Good = true
+		case "BADSIG":
+			res.BadSig = true
+		case "VALIDSIG":
+			if fp, err := PGPFingerprintFromHex(fields[2]); err == nil {
+				res.Fingerprint = fp
+			}
+		}
+	}
+	return res
+}
@@ -0,0 +1,44 @@
+// Copyright 2016 Keybase, Inc. All rights reserved. Use of
+// this source code is governed by the included BSD license.
+
+package libkb
+
+import (
+	"testing"
+
+	keybase1 "github.com/keybase/client/go/protocol/keybase1"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGPGKeyVerifyStringAndExtract(t *testing.T) {
+	tc := SetupTest(t, "gpg_verify", 1)
+	defer tc.Cleanup()
+
+	err := tc.GenerateGPGKeyring("no@no.no")
+	require.NoError(t, err)
+
+	cli := NewGpgCLI(tc.G, nil)
+	require.NoError(t, cli.Configure(tc.MetaContext()))
+	tc.G.GpgClient = cli
+
+	index, _, err := cli.Index(tc.MetaContext(), true, "")
+	require.NoError(t, err)
+	fps := index.AllFingerprints()
+	require.Len(t, fps, 1)
+	fp := fps[0]
+
+	payload := []byte("test payload for gpg verify")
+	sig, err := cli.Sign(tc.MetaContext(), fp, payload)
+	require.NoError(t, err)
+
+	key := NewGPGKey(tc.G, &fp, "", nil, keybase1.ClientType_NONE)
+
+	msg, _, err := key.VerifyStringAndExtract(tc.MetaContext(), sig)
+	require.NoError(t, err)
+	require.Equal(t, payload, msg)
+
+	// VerifyString should reject a caller-supplied payload that doesn't
+	// match what's embedded in the signature.
+	_, err = key.VerifyString(tc.MetaContext(), sig, []byte("not the payload"))
+	require.Error(t, err)
+}
@@ -0,0 +1,95 @@
+// Copyright 2015 Keybase, Inc. All rights reserved. Use of
+// this source code is governed by the included BSD license.
+
+package libkb
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// HKPClient fetches and publishes OpenPGP keys against an HKP (or HKPS)
+// keyserver, per draft-shaw-openpgp-hkp-00. It's used by "keybase pgp
+// pull-remote" and "keybase pgp push-remote" to reach keys that live outside
+// of Keybase and the local GnuPG keyring.
+type HKPClient struct {
+	Contextified
+	baseURI string
+	cli     *http.Client
+}
+
+// NewHKPClient makes an HKPClient that talks to the user's configured
+// keyserver (libkb.Env.GetPGPKeyServerURI).
+func NewHKPClient(g *GlobalContext) *HKPClient {
+	return &HKPClient{
+		Contextified: NewContextified(g),
+		baseURI:      strings.TrimRight(g.Env.GetPGPKeyServerURI(), "/"),
+		cli:          &http.Client{Timeout: HTTPDefaultTimeout},
+	}
+}
+
+// Fetch looks up query (a fingerprint like "0xDEADBEEF", an email address,
+// or a bare key ID) via the keyserver's "op=get" HKP lookup, and returns the
+// matching key(s) as armored text, ready to hand to ReadOneKeyFromString or
+// GpgCLI.ExportKeyArmored.
+func (h *HKPClient) Fetch(mctx MetaContext, query string) (string, error) {
+	uri := fmt.Sprintf("%s/pks/lookup?op=get&options=mr&search=%s", h.baseURI, url.QueryEscape(query))
+	mctx.Debug("HKPClient.Fetch: GET %s", uri)
+
+	req, err := http.NewRequest("GET", uri, nil)
+	if err != nil {
+		return "", err
+	}
+	req = req.WithContext(mctx.Ctx())
+
+	resp, err := h.cli.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return "", err
+	}
+
+	if resp.StatusCode == http.StatusNotFound {
+		return "", NoKeyError{fmt.Sprintf("no key found on %s matching %q", h.baseURI, query)}
+	}
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("keyserver lookup failed: %s returned %s", h.baseURI, resp.Status)
+	}
+
+	return string(body), nil
+}
+
+// Publish submits armored (a single ASCII-armored public key) to the
+// keyserver's "op=add" HKP endpoint.
+func (h *HKPClient) Publish(mctx MetaContext, armored string) error {
+	uri := fmt.Sprintf("%s/pks/add", h.baseURI)
+	mctx.Debug("HKPClient.Publish: POST %s", uri)
+
+	form := url.Values{"keytext": {armored}}
+	req, err := http.NewRequest("POST", uri, strings.NewReader(form.Encode()))
+	if err != nil {
+		return err
+	}
+	req = req.WithContext(mctx.Ctx())
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := h.cli.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := ioutil.ReadAll(resp.Body)
+		return fmt.Errorf("keyserver publish failed: %s returned %s: %s", h.baseURI, resp.Status, strings.TrimSpace(string(body)))
+	}
+
+	return nil
+}
@@ -7,10 +7,15 @@ import (
 	"fmt"
 	"regexp"
 	"strings"
+	"unicode"
 
 	"github.com/keybase/go-crypto/openpgp/packet"
 )
 
+// identityMaxFieldLen bounds each field of an Identity so that a
+// pathological import can't produce an unreasonably large UID packet.
+const identityMaxFieldLen = 256
+
 type Identity struct {
 	Username string
 	Comment  string
@@ -35,6 +40,81 @@ func ParseIdentity(s string) (*Identity, error) {
 	return ret, nil
 }
 
+// ParsePGPUserID parses s as a PGP-style user ID, "Name (Comment) <email>",
+// with the comment and email both optional. Unlike ParseIdentity's single
+// non-greedy idRE match, it locates the trailing "<email>" and "(comment)"
+// by scanning for their closing delimiter and walking backward to find its
+// balanced opening one, so a comment containing its own parentheses (which
+// makes idRE's non-greedy ")" fail to match anything at all) parses
+// correctly instead of being rejected. Byte-level scanning for these
+// ASCII delimiters is safe on UTF-8 input -- no multi-byte UTF-8 sequence
+// contains a byte that collides with an ASCII one -- so names and comments
+// may freely contain non-ASCII text. Double quotes around the name (or
+// around the name and comment together) are dropped, and a '(', ')', '<'
+// or '>' left over outside the comment and email is rejected as unbalanced.
+func ParsePGPUserID(s string) (*Identity, error) {
+	unquote := func(x string) string {
+		x = strings.TrimSpace(x)
+		if len(x) >= 2 && x[0] == '"' && x[len(x)-1] == '"' {
+			return x[1 : len(x)-1]
+		}
+		return x
+	}
+	rest := strings.TrimRight(s, " ")
+
+	var email string
+	if strings.HasSuffix(rest, ">") {
+		openIdx, err := matchBackward(rest, '<', '>')
+		if err != nil {
+			return nil, fmt.Errorf("Bad PGP-style identity: %s", s)
+		}
+		email = rest[openIdx+1 : len(rest)-1]
+		rest = strings.TrimRight(rest[:openIdx], " ")
+	}
+	// A quoted display name may enclose the comment, as in
+	// `"Name (comment)" <email>`.
+	rest = unquote(rest)
+
+	var comment string
+	if strings.HasSuffix(rest, ")") {
+		openIdx, err := matchBackward(rest, '(', ')')
+		if err != nil {
+			return nil, fmt.Errorf("Bad PGP-style identity: %s", s)
+		}
+		comment = rest[openIdx+1 : len(rest)-1]
+		rest = rest[:openIdx]
+	}
+	rest = unquote(rest)
+	if strings.ContainsAny(rest, "()<>") {
+		return nil, fmt.Errorf("Bad PGP-style identity: %s", s)
+	}
+
+	return &Identity{
+		Username: rest,
+		Comment:  comment,
+		Email:    email,
+	}, nil
+}
+
+// matchBackward finds the open delimiter matching the close delimiter that
+// ends s, by scanning backward and tracking nesting depth. It returns an
+// error if s's delimiters aren't balanced.
+func matchBackward(s string, open, close byte) (openIdx int, err error) {
+	depth := 0
+	for j := len(s) - 1; j >= 0; j-- {
+		switch s[j] {
+		case close:
+			depth++
+		case open:
+			depth--
+			if depth == 0 {
+				return j, nil
+			}
+		}
+	}
+	return 0, fmt.Errorf("unbalanced %q/%q", open, close)
+}
+
 func (i Identity) Format() string {
 	var parts []string
 	if len(i.Username) > 0 {
@@ -58,6 +138,35 @@ func (i Identity) ToPGPUserID() *packet.UserId {
 
 }
 
+// Validate checks that i is sane enough to turn into a PGP UID packet: it
+// needs a non-empty Username or Email, and none of its fields may be
+// unreasonably long or contain a control character or one of "()<>\x00",
+// which GPG also refuses (see packet.NewUserId).
+func (i Identity) Validate() error {
+	if len(i.Username) == 0 && len(i.Email) == 0 {
+		return fmt.Errorf("PGP identity needs a non-empty username or email")
+	}
+	fields := []struct {
+		name  string
+		value string
+	}{
+		{"username", i.Username},
+		{"comment", i.Comment},
+		{"email", i.Email},
+	}
+	for _, field := range fields {
+		if len(field.value) > identityMaxFieldLen {
+			return fmt.Errorf("PGP identity %s is too long (%d > %d bytes)", field.name, len(field.value), identityMaxFieldLen)
+		}
+		for _, r := range field.value {
+			if unicode.IsControl(r) || strings.ContainsRune("()<>", r) {
+				return fmt.Errorf("PGP identity %s contains an invalid character: %q", field.name, r)
+			}
+		}
+	}
+	return nil
+}
+
 func KeybaseIdentity(g *GlobalContext, un NormalizedUsername) Identity {
 	if un.IsNil() {
 		un = g.Env.GetUsername()
@@ -4,6 +4,7 @@
 package libkb
 
 import (
+	"strings"
 	"testing"
 )
 
@@ -47,3 +48,106 @@ var sampleIdentities = []struct {
 	{`Barb`,
 		Identity{"Barb", "", ""}},
 }
+
+func TestPGPIdentityExportImportRoundTrip(t *testing.T) {
+	for _, ident := range []Identity{
+		{"Barb Akew", "bbq", "barb@example.com"},
+		{"Barb Akew", "", "barb@example.com"},
+		{"", "", "barb@example.com"},
+		{"Barb Akew", "", ""},
+	} {
+		wire := ident.Export()
+		back := ImportPGPIdentity(wire)
+		if back != ident {
+			t.Errorf("round trip changed identity: %+v != %+v", back, ident)
+		}
+		if wire2 := back.Export(); wire2 != wire {
+			t.Errorf("second export differs from first: %+v != %+v", wire2, wire)
+		}
+	}
+}
+
+func TestIdentityValidate(t *testing.T) {
+	good := []Identity{
+		{"Barb Akew", "bbq", "barb@example.com"},
+		{"", "", "barb@example.com"},
+		{"Barb Akew", "", ""},
+	}
+	for _, ident := range good {
+		if err := ident.Validate(); err != nil {
+			t.Errorf("expected %+v to be valid, got error: %s", ident, err)
+		}
+	}
+
+	bad := []Identity{
+		{"", "", ""},
+		{"Barb (Akew)", "", "barb@example.com"},
+		{"Barb Akew", "<admin>", "barb@example.com"},
+		{"Barb\x00Akew", "", "barb@example.com"},
+		{strings.Repeat("x", identityMaxFieldLen+1), "", "barb@example.com"},
+	}
+	for _, ident := range bad {
+		if err := ident.Validate(); err == nil {
+			t.Errorf("expected %+v to be invalid", ident)
+		}
+	}
+}
+
+func TestParsePGPUserID(t *testing.T) {
+	for _, idents := range sampleIdentities {
+		ident, err := ParsePGPUserID(idents.input)
+		if err != nil {
+			t.Errorf("error parsing user ID %q: %s", idents.input, err)
+			continue
+		}
+		if *ident != idents.expected {
+			t.Errorf("identity differs from expected\n%+v\n%+v", ident, idents.expected)
+		}
+	}
+}
+
+func TestParsePGPUserIDNestedComment(t *testing.T) {
+	ident, err := ParsePGPUserID(`Foo Bar (work (new) key) <foo@example.com>`)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	expected := Identity{"Foo Bar", "work (new) key", "foo@example.com"}
+	if *ident != expected {
+		t.Errorf("identity differs from expected\n%+v\n%+v", ident, expected)
+	}
+
+	// idRE, by contrast, can't handle the nested parens and rejects this
+	// input outright.
+	if _, err := ParseIdentity(`Foo Bar (work (new) key) <foo@example.com>`); err == nil {
+		t.Error("expected ParseIdentity to reject a nested-paren comment")
+	}
+}
+
+func TestParsePGPUserIDUnicode(t *testing.T) {
+	ident, err := ParsePGPUserID(`Thérèse Çelik (日本語) <therese@example.com>`)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	expected := Identity{"Thérèse Çelik", "日本語", "therese@example.com"}
+	if *ident != expected {
+		t.Errorf("identity differs from expected\n%+v\n%+v", ident, expected)
+	}
+}
+
+func TestParsePGPUserIDUnbalanced(t *testing.T) {
+	for _, input := range []string{
+		`Barb Akew (bbq <barb@example.com>`,
+		`Barb Akew (bbq) <barb@example.com`,
+	} {
+		if _, err := ParsePGPUserID(input); err == nil {
+			t.Errorf("expected %q to be rejected as unbalanced", input)
+		}
+	}
+}
+
+func TestPGPUserIDsRejectsInvalidIdentity(t *testing.T) {
+	arg := PGPGenArg{Ids: Identities{{Username: "", Comment: "", Email: ""}}}
+	if _, err := arg.PGPUserIDs(); err == nil {
+		t.Error("expected PGPUserIDs to reject an identity with no username or email")
+	}
+}
@@ -48,6 +48,10 @@ type configGetter interface {
 	GetDebug() (bool, bool)
 	GetDebugJourneycard() (bool, bool)
 	GetDisplayRawUntrustedOutput() (bool, bool)
+	GetRPCTraceEnabled() (bool, bool)
+	GetVerifyTraceEnabled() (bool, bool)
+	GetPGPRefuseWeakSigning() (bool, bool)
+	GetPGPRefuseWeakVerify() (bool, bool)
 	GetGpg() string
 	GetGpgHome() string
 	GetGpgOptions() []string
@@ -76,6 +80,7 @@ type configGetter interface {
 	GetMountDirDefault() string
 	GetPidFile() string
 	GetPinentry() string
+	GetPGPKeyServerURI() string
 	GetProofCacheSize() (int, bool)
 	GetProxy() string
 	GetProxyType() string
@@ -98,12 +103,14 @@ type configGetter interface {
 	GetDeviceCloneStateFilename() string
 	GetUserCacheMaxAge() (time.Duration, bool)
 	GetVDebugSetting() string
+	GetLogModuleLevels() string
 	GetChatDelivererInterval() (time.Duration, bool)
 	GetFeatureFlags() (FeatureFlags, error)
 	GetLevelDBNumFiles() (int, bool)
 	GetLevelDBWriteBufferMB() (int, bool)
 	GetChatInboxSourceLocalizeThreads() (int, bool)
 	GetPayloadCacheSize() (int, bool)
+	GetPGPEntityCacheSize() (int, bool)
 	GetRememberPassphrase(NormalizedUsername) (bool, bool)
 	GetAttachmentHTTPStartPort() (int, bool)
 	GetAttachmentDisableMulti() (bool, bool)
@@ -143,6 +150,19 @@ type LocalDbOps interface {
 	Delete(id DbKey) error
 	Get(id DbKey) ([]byte, bool, error)
 	Lookup(alias DbKey) ([]byte, bool, error)
+	// GetMany reads several rows at once, returning one value and found-flag
+	// per id, in the same order as ids.
+	GetMany(ids []DbKey) (values [][]byte, found []bool, err error)
+	// PutMany writes several rows (and their aliases) as a single atomic
+	// batch, rather than paying transaction overhead per row.
+	PutMany(items []DbPutManyItem) error
+}
+
+// DbPutManyItem is one row of a PutMany batch.
+type DbPutManyItem struct {
+	Key     DbKey
+	Aliases []DbKey
+	Value   []byte
 }
 
 type LocalDbTransaction interface {
@@ -162,6 +182,12 @@ type LocalDb interface {
 	Clean(force bool) error
 	OpenTransaction() (LocalDbTransaction, error)
 	KeysWithPrefixes(prefixes ...[]byte) (DBKeySet, error)
+	// ScanPrefix invokes fn once per row of type typ whose key has the
+	// given prefix. fn may return ErrStopScan to stop iterating early
+	// without it being treated as a failure; any other error aborts the
+	// scan and is returned from ScanPrefix. Rows are snapshotted before fn
+	// is first called, so fn may safely Delete the row it was just given.
+	ScanPrefix(typ ObjType, prefix string, fn func(key DbKey, value []byte) error) error
 }
 
 type KVStorer interface {
@@ -388,6 +414,14 @@ type SecretUI interface {
 	GetPassphrase(pinentry keybase1.GUIEntryArg, terminal *keybase1.SecretEntryArg) (keybase1.GetPassphraseRes, error)
 }
 
+// PGPUnlockProgressUI is an optional extension to SecretUI. A SecretUI that
+// also implements it is told about progress as PGPKeyBundle.Unlock unlocks a
+// key's subkeys in parallel, and can abort the rest of the unlock by
+// returning true.
+type PGPUnlockProgressUI interface {
+	PGPKeyUnlockProgress(unlocked, total int) (abort bool)
+}
+
 type SaltpackUI interface {
 	SaltpackPromptForDecrypt(context.Context, keybase1.SaltpackPromptForDecryptArg, bool) error
 	SaltpackVerifySuccess(context.Context, keybase1.SaltpackVerifySuccessArg) error
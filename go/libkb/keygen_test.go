@@ -132,3 +132,38 @@ func TestCreateIds(t *testing.T) {
 		}
 	}
 }
+
+func TestGeneratePGPKeyBundleEncodeIsDeterministic(t *testing.T) {
+	tc := SetupTest(t, "generatePGPKeyBundleEncodeIsDeterministic", 1)
+	defer tc.Cleanup()
+
+	arg := &PGPGenArg{
+		PrimaryBits: 1024,
+		SubkeyBits:  1024,
+		Ids: Identities{
+			{Username: "Alice", Email: "alice@example.com"},
+			{Username: "Alice", Email: "alice@keybase.io"},
+			{Username: "Alice", Email: "alice@work.example.com"},
+		},
+	}
+	if err := arg.Init(); err != nil {
+		t.Fatalf("arg init err: %s", err)
+	}
+
+	bundle, err := GeneratePGPKeyBundle(tc.G, *arg, tc.G.UI.GetLogUI())
+	if err != nil {
+		t.Fatalf("bundle error: %s", err)
+	}
+
+	first, err := bundle.Encode()
+	if err != nil {
+		t.Fatalf("first Encode error: %s", err)
+	}
+	second, err := bundle.Encode()
+	if err != nil {
+		t.Fatalf("second Encode error: %s", err)
+	}
+	if first != second {
+		t.Errorf("Encode was not deterministic across calls on a generated bundle")
+	}
+}
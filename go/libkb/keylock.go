@@ -22,6 +22,19 @@ type KeyUnlocker struct {
 	useSecretStore bool
 	ui             SecretUI
 	unlocker       UnlockerFunc
+
+	// secretStoreUsername and secretStoreLabel, if both set, make Run try
+	// the OS secret store silently before prompting, and store the
+	// passphrase back after a successful prompted unlock whose result asks
+	// us to remember it.
+	secretStoreUsername NormalizedUsername
+	secretStoreLabel    string
+
+	// biometricIdentifier, if set, makes Run try the registered
+	// BiometricUnlockProvider before prompting (if the user has opted in
+	// for this identifier), and store a successfully prompted-for
+	// passphrase with the provider for next time.
+	biometricIdentifier string
 }
 
 func NewKeyUnlocker(tries int, reason string, keyDesc string, which PassphraseType, useSecretStore bool, ui SecretUI, unlocker UnlockerFunc) KeyUnlocker {
@@ -36,6 +49,94 @@ func NewKeyUnlocker(tries int, reason string, keyDesc string, which PassphraseTy
 	}
 }
 
+// WithSecretStore makes Run check the OS secret store for username+label
+// before prompting, and offer to save a prompted-for passphrase back there.
+func (arg KeyUnlocker) WithSecretStore(username NormalizedUsername, label string) KeyUnlocker {
+	arg.secretStoreUsername = username
+	arg.secretStoreLabel = label
+	return arg
+}
+
+// hasSecretStore reports whether arg was configured via WithSecretStore.
+func (arg KeyUnlocker) hasSecretStore() bool {
+	return len(arg.secretStoreUsername) > 0 && len(arg.secretStoreLabel) > 0
+}
+
+// WithBiometricUnlock makes Run try the registered platform
+// BiometricUnlockProvider (Touch ID, OS keychain with biometric gating,
+// ...) before prompting, provided the user has opted in for identifier via
+// SetBiometricUnlockEnabled and a provider is registered and available.
+func (arg KeyUnlocker) WithBiometricUnlock(identifier string) KeyUnlocker {
+	arg.biometricIdentifier = identifier
+	return arg
+}
+
+// hasBiometricUnlock reports whether arg was configured via
+// WithBiometricUnlock.
+func (arg KeyUnlocker) hasBiometricUnlock() bool {
+	return len(arg.biometricIdentifier) > 0
+}
+
+// tryBiometricUnlock silently tries the registered biometric provider, if
+// any, the user has opted in, and it's currently available. It returns
+// ok=false on any decline or failure, in which case Run falls back to
+// prompting.
+func (arg KeyUnlocker) tryBiometricUnlock(m MetaContext) (ret GenericKey, ok bool) {
+	if !arg.hasBiometricUnlock() {
+		return nil, false
+	}
+	provider := GetBiometricUnlockProvider()
+	if provider == nil {
+		return nil, false
+	}
+	if !IsBiometricUnlockEnabled(m, arg.biometricIdentifier) {
+		m.Debugw("KeyUnlocker: biometric unlock not opted-in", "identifier", arg.biometricIdentifier)
+		return nil, false
+	}
+	if !provider.IsAvailable(m) {
+		m.Debugw("KeyUnlocker: biometric provider not available", "provider", provider.Name())
+		return nil, false
+	}
+	secret, found, err := provider.FetchSecret(m, arg.biometricIdentifier)
+	if err != nil {
+		m.Debugw("KeyUnlocker: biometric provider failed", "provider", provider.Name(), "err", err)
+		return nil, false
+	}
+	if !found {
+		m.Debugw("KeyUnlocker: biometric provider declined or had nothing stored",
+			"provider", provider.Name(), "identifier", arg.biometricIdentifier)
+		return nil, false
+	}
+	// Secrets obtained this way are handed to unlocker exactly like a typed
+	// passphrase, and aren't themselves retained anywhere beyond that call.
+	ret, err = arg.unlocker(secret, false)
+	if err != nil {
+		m.Debugw("KeyUnlocker: secret from biometric provider did not unlock",
+			"provider", provider.Name(), "err", err)
+		return nil, false
+	}
+	return ret, true
+}
+
+// tryStoredSecret silently tries the passphrase stashed in the OS secret
+// store, if any. It returns ok=false on a miss or any failure reading the
+// store or unlocking with it, in which case Run falls back to prompting.
+func (arg KeyUnlocker) tryStoredSecret(m MetaContext) (ret GenericKey, ok bool) {
+	pw, err := retrieveLabeledSecret(m, arg.secretStoreUsername, arg.secretStoreLabel)
+	if err != nil {
+		m.Debugw("KeyUnlocker: no stored secret",
+			"username", arg.secretStoreUsername, "label", arg.secretStoreLabel, "err", err)
+		return nil, false
+	}
+	ret, err = arg.unlocker(pw, false)
+	if err != nil {
+		m.Debugw("KeyUnlocker: stored secret did not unlock",
+			"username", arg.secretStoreUsername, "label", arg.secretStoreLabel, "err", err)
+		return nil, false
+	}
+	return ret, true
+}
+
 func (arg KeyUnlocker) Run(m MetaContext) (ret GenericKey, err error) {
 	var emsg string
 
@@ -44,6 +145,16 @@ func (arg KeyUnlocker) Run(m MetaContext) (ret GenericKey, err error) {
 		return nil, err
 	}
 
+	if arg.hasSecretStore() {
+		if ret, ok := arg.tryStoredSecret(m); ok {
+			return ret, nil
+		}
+	}
+
+	if ret, ok := arg.tryBiometricUnlock(m); ok {
+		return ret, nil
+	}
+
 	prompt := "Please enter your " + string(arg.which) + " passphrase to unlock the secret key for:\n" +
 		arg.keyDesc + "\n"
 	if len(arg.reason) > 0 {
@@ -55,17 +166,39 @@ func (arg KeyUnlocker) Run(m MetaContext) (ret GenericKey, err error) {
 	for i := 0; arg.tries <= 0 || i < arg.tries; i++ {
 		res, err := GetSecret(m, arg.ui, title, prompt, emsg, arg.useSecretStore)
 		if err != nil {
-			// probably canceled
+			// Most commonly InputCanceledError, from the user dismissing the
+			// prompt: don't burn any of the remaining tries retrying, and
+			// propagate the error unwrapped so callers can tell an explicit
+			// dismissal (quiet no-op) apart from GetSecret actually breaking.
 			return nil, err
 		}
 		ret, err = arg.unlocker(res.Passphrase, res.StoreSecret)
 		if err == nil {
+			if res.StoreSecret && arg.hasSecretStore() {
+				if serr := storeLabeledSecret(m, arg.secretStoreUsername, arg.secretStoreLabel, res.Passphrase); serr != nil {
+					m.Debugw("KeyUnlocker: failed to store secret",
+						"username", arg.secretStoreUsername, "label", arg.secretStoreLabel, "err", serr)
+				}
+			}
+			if arg.hasBiometricUnlock() {
+				if provider := GetBiometricUnlockProvider(); provider != nil && IsBiometricUnlockEnabled(m, arg.biometricIdentifier) {
+					if serr := provider.StoreSecret(m, arg.biometricIdentifier, res.Passphrase); serr != nil {
+						m.Debugw("KeyUnlocker: failed to store secret with biometric provider",
+							"provider", provider.Name(), "err", serr)
+					}
+				}
+			}
 			// success
 			return ret, nil
 		}
-		if _, ok := err.(PassphraseError); ok {
+		if pperr, ok := err.(PassphraseError); ok {
 			// keep trying
 			emsg = "Failed to unlock key; bad passphrase"
+			if arg.tries > 0 {
+				remaining := arg.tries - i - 1
+				pperr.AttemptsRemaining = &remaining
+				err = pperr
+			}
 		} else {
 			// unretryable error
 			return nil, err
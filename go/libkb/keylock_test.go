@@ -0,0 +1,31 @@
+// Copyright 2026 Keybase, Inc. All rights reserved. Use of
+// this source code is governed by the included BSD license.
+
+package libkb
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// TestKeyUnlockerCancelShortCircuit verifies that Run stops immediately when
+// the user dismisses the passphrase prompt, instead of burning through the
+// remaining configured tries, and that it hands back InputCanceledError
+// unwrapped.
+func TestKeyUnlockerCancelShortCircuit(t *testing.T) {
+	tc := SetupTest(t, "keyunlocker_cancel", 1)
+	defer tc.Cleanup()
+
+	ui := &TestCancelSecretUI{}
+	unlocker := func(pw string, storeSecret bool) (GenericKey, error) {
+		t.Fatal("unlocker should never be called when the prompt is canceled")
+		return nil, nil
+	}
+
+	ku := NewKeyUnlocker(5, "test", "test key", PassphraseTypePGP, false, ui, unlocker)
+	ret, err := ku.Run(NewMetaContextForTest(tc))
+	require.Nil(t, ret)
+	require.IsType(t, InputCanceledError{}, err)
+	require.Equal(t, 1, ui.CallCount)
+}
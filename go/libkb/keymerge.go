@@ -5,6 +5,7 @@ package libkb
 
 import (
 	"crypto"
+	"fmt"
 
 	"github.com/keybase/go-crypto/openpgp/packet"
 )
@@ -24,13 +25,43 @@ func combineSignatures(toSignatures []*packet.Signature, fromSignatures []*packe
 	return
 }
 
-// MergeKey adds the identities, revocations, and subkeys of another PGPKeyBundle to this key
-func (to *PGPKeyBundle) MergeKey(from *PGPKeyBundle) {
+// MergeReport summarizes what MergeKey actually pulled in from another
+// bundle for the same primary key: identities, subkeys, and signatures
+// (across identities and revocations) that to didn't already have. It
+// implements Warning so callers that collect it into a Warnings set --
+// e.g. to tell a user exactly what changed in a re-imported key that
+// differs from the cached one -- can push it directly.
+type MergeReport struct {
+	UIDsAdded       int
+	SubkeysAdded    int
+	SignaturesAdded int
+}
+
+// IsEmpty is true when the merge didn't actually change anything, i.e. from
+// was a strict subset of to.
+func (r MergeReport) IsEmpty() bool {
+	return r.UIDsAdded == 0 && r.SubkeysAdded == 0 && r.SignaturesAdded == 0
+}
+
+func (r MergeReport) Warning() string {
+	return fmt.Sprintf("Merged duplicate key: %d new identity(ies), %d new subkey(s), %d new signature(s)",
+		r.UIDsAdded, r.SubkeysAdded, r.SignaturesAdded)
+}
+
+func (r MergeReport) Warn(g *GlobalContext) {
+	g.Log.Warning(r.Warning())
+}
+
+// MergeKey adds the identities, revocations, and subkeys of another
+// PGPKeyBundle to this key, and reports what it actually added.
+func (to *PGPKeyBundle) MergeKey(from *PGPKeyBundle) (report MergeReport) {
 
 	// First, merge identities, adding any signatures found in matching identities
 	for name, fromIdentity := range from.Identities {
 		if toIdentity, ok := to.Identities[name]; ok {
+			before := len(toIdentity.Signatures)
 			to.Identities[name].Signatures = combineSignatures(toIdentity.Signatures, fromIdentity.Signatures)
+			report.SignaturesAdded += len(to.Identities[name].Signatures) - before
 
 			// There's a primary self-signature that we use. Always take the later
 			// of the two.
@@ -42,11 +73,14 @@ func (to *PGPKeyBundle) MergeKey(from *PGPKeyBundle) {
 
 		} else {
 			to.Identities[fromIdentity.Name] = fromIdentity
+			report.UIDsAdded++
 		}
 	}
 
 	// Then, merge revocations
+	beforeRevocations := len(to.Revocations)
 	to.Revocations = combineSignatures(to.Revocations, from.Revocations)
+	report.SignaturesAdded += len(to.Revocations) - beforeRevocations
 
 	// Finally, merge subkeys
 	existingSubkeys := make(map[[20]byte]int)
@@ -63,6 +97,56 @@ func (to *PGPKeyBundle) MergeKey(from *PGPKeyBundle) {
 			}
 		} else {
 			to.Subkeys = append(to.Subkeys, subkey)
+			report.SubkeysAdded++
+		}
+	}
+
+	return report
+}
+
+// CopySecretKeyMaterial copies the primary and subkey PrivateKey packets
+// from from into to, matching subkeys up by fingerprint the same way
+// CopySubkeyRevocations does. It's meant for merging a freshly gpg-exported
+// secret bundle into a PGPKeyBundle that previously only had the public
+// half, so it only ever adds PrivateKey packets; it doesn't touch
+// identities, revocations, or anything MergeKey already covers.
+func (to *PGPKeyBundle) CopySecretKeyMaterial(from *PGPKeyBundle) {
+	to.PrivateKey = from.PrivateKey
+
+	fromSubkeys := make(map[[20]byte]*packet.PrivateKey)
+	for _, subkey := range from.Subkeys {
+		if subkey.PrivateKey != nil {
+			fromSubkeys[subkey.PublicKey.Fingerprint] = subkey.PrivateKey
 		}
 	}
+	for i, subkey := range to.Subkeys {
+		if pk := fromSubkeys[subkey.PublicKey.Fingerprint]; pk != nil {
+			to.Subkeys[i].PrivateKey = pk
+		}
+	}
+}
+
+// MergeAndDedupe merges another PGPKeyBundle for the same primary key into
+// this one -- combining identities and subkeys and deduping any signatures
+// they share -- and reports whether anything actually changed. Import paths
+// that used to call MergeKey and then separately worry about duplicate
+// signatures can use this as a single idempotent primitive.
+func (to *PGPKeyBundle) MergeAndDedupe(other *PGPKeyBundle) (changed bool, err error) {
+	if other == nil {
+		return false, nil
+	}
+	if !to.SamePrimaryAs(*other) {
+		return false, BadFingerprintError{to.GetFingerprint(), other.GetFingerprint()}
+	}
+
+	report := to.MergeKey(other)
+	changed = !report.IsEmpty()
+
+	if changed {
+		// The armored export is no longer valid now that the underlying
+		// entity has new identities/subkeys/signatures.
+		to.ArmoredPublicKey = ""
+	}
+
+	return changed, nil
 }
@@ -0,0 +1,88 @@
+// Copyright 2015 Keybase, Inc. All rights reserved. Use of
+// this source code is governed by the included BSD license.
+
+package libkb
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMergeKeyReportsNewIdentity(t *testing.T) {
+	tc := SetupTest(t, "mergekeynewidentity", 1)
+	defer tc.Cleanup()
+
+	bundle, err := tc.MakePGPKey("mergeme@keybase.io")
+	require.NoError(t, err)
+
+	before, _, err := ReadOneKeyFromString(mustArmor(t, bundle))
+	require.NoError(t, err)
+
+	uid, err := bundle.AddIdentity(Identity{Username: "Second", Email: "second@keybase.io"})
+	require.NoError(t, err)
+
+	report := before.MergeKey(bundle)
+	require.False(t, report.IsEmpty())
+	require.Equal(t, 1, report.UIDsAdded)
+	require.Equal(t, 0, report.SubkeysAdded)
+	require.Contains(t, before.Identities, uid)
+	require.Contains(t, report.Warning(), "1 new identity")
+}
+
+func TestMergeKeyReportsNewSignature(t *testing.T) {
+	tc := SetupTest(t, "mergekeynewsig", 1)
+	defer tc.Cleanup()
+
+	const uid = "revokeme@keybase.io"
+	bundle, err := tc.MakePGPKey(uid)
+	require.NoError(t, err)
+
+	before, _, err := ReadOneKeyFromString(mustArmor(t, bundle))
+	require.NoError(t, err)
+
+	require.NoError(t, bundle.RevokeIdentity(uid, "no longer used"))
+
+	report := before.MergeKey(bundle)
+	require.False(t, report.IsEmpty())
+	require.Equal(t, 0, report.UIDsAdded)
+	require.Equal(t, 1, report.SignaturesAdded)
+}
+
+func TestMergeKeyNoChange(t *testing.T) {
+	tc := SetupTest(t, "mergekeynochange", 1)
+	defer tc.Cleanup()
+
+	bundle, err := tc.MakePGPKey("mergeme@keybase.io")
+	require.NoError(t, err)
+
+	clone, _, err := ReadOneKeyFromString(mustArmor(t, bundle))
+	require.NoError(t, err)
+
+	report := bundle.MergeKey(clone)
+	require.True(t, report.IsEmpty())
+}
+
+func TestMergeAndDedupeUsesMergeReport(t *testing.T) {
+	tc := SetupTest(t, "mergeanddedupereport", 1)
+	defer tc.Cleanup()
+
+	bundle, err := tc.MakePGPKey("mergeanddedupe@keybase.io")
+	require.NoError(t, err)
+
+	before, _, err := ReadOneKeyFromString(mustArmor(t, bundle))
+	require.NoError(t, err)
+
+	_, err = bundle.AddIdentity(Identity{Username: "Second", Email: "second@keybase.io"})
+	require.NoError(t, err)
+
+	before.ArmoredPublicKey = "stale cache"
+	changed, err := before.MergeAndDedupe(bundle)
+	require.NoError(t, err)
+	require.True(t, changed)
+	require.Empty(t, before.ArmoredPublicKey, "a real merge should invalidate the armored cache")
+
+	changed, err = before.MergeAndDedupe(bundle)
+	require.NoError(t, err)
+	require.False(t, changed, "merging the same bundle again should be a no-op")
+}
@@ -0,0 +1,105 @@
+// Copyright 2020 Keybase, Inc. All rights reserved. Use of
+// this source code is governed by the included BSD license.
+
+package libkb
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// formatKV appends a stable "key=value ..." suffix to msg, in the order the
+// pairs were given, so a support tooling parser (see ParseKV) or a plain
+// grep can reliably pull out every line tagged with a given key. An odd
+// number of keysAndValues pads the final value with "MISSING" rather than
+// panicking, since a logging call is never worth crashing over.
+func formatKV(msg string, keysAndValues []interface{}) string {
+	if len(keysAndValues) == 0 {
+		return msg
+	}
+	var b strings.Builder
+	b.WriteString(msg)
+	for i := 0; i < len(keysAndValues); i += 2 {
+		key := fmt.Sprint(keysAndValues[i])
+		val := "MISSING"
+		if i+1 < len(keysAndValues) {
+			val = fmt.Sprint(keysAndValues[i+1])
+		}
+		b.WriteByte(' ')
+		b.WriteString(kvQuote(key))
+		b.WriteByte('=')
+		b.WriteString(kvQuote(val))
+	}
+	return b.String()
+}
+
+// kvQuote quotes s with strconv.Quote if it contains anything that would
+// make it ambiguous to split back out of a "key=value key=value" suffix
+// (whitespace, '=', or a quote of its own), and leaves it bare otherwise so
+// the common case stays easy to read and grep.
+func kvQuote(s string) string {
+	if s == "" || strings.ContainsAny(s, " \t\n=\"") {
+		return strconv.Quote(s)
+	}
+	return s
+}
+
+// ParseKV extracts the key=value suffix formatKV (and so Debugw/Warningw)
+// appends to a log line, returning them as a key-to-value map. It's
+// meant for the log-send parser and similar tooling that need to pull
+// structured fields back out of a text log; lines with no such suffix
+// return an empty, non-nil map.
+func ParseKV(line string) map[string]string {
+	res := make(map[string]string)
+	fields := splitKVFields(line)
+	for _, f := range fields {
+		eq := strings.IndexByte(f, '=')
+		if eq < 0 {
+			continue
+		}
+		key, err := kvUnquote(f[:eq])
+		if err != nil {
+			continue
+		}
+		val, err := kvUnquote(f[eq+1:])
+		if err != nil {
+			continue
+		}
+		res[key] = val
+	}
+	return res
+}
+
+// splitKVFields splits line on unquoted whitespace, keeping a double-quoted
+// key or value (which may itself contain spaces) intact as one field.
+func splitKVFields(line string) (fields []string) {
+	var cur strings.Builder
+	inQuotes := false
+	for i := 0; i < len(line); i++ {
+		c := line[i]
+		switch {
+		case c == '"' && (i == 0 || line[i-1] != '\\'):
+			inQuotes = !inQuotes
+			cur.WriteByte(c)
+		case c == ' ' && !inQuotes:
+			if cur.Len() > 0 {
+				fields = append(fields, cur.String())
+				cur.Reset()
+			}
+		default:
+			cur.WriteByte(c)
+		}
+	}
+	if cur.Len() > 0 {
+		fields = append(fields, cur.String())
+	}
+	return fields
+}
+
+func kvUnquote(s string) (string, error) {
+	if len(s) > 0 && s[0] == '"' {
+		return strconv.Unquote(s)
+	}
+	return s, nil
+}
@@ -0,0 +1,49 @@
+// Copyright 2020 Keybase, Inc. All rights reserved. Use of
+// this source code is governed by the included BSD license.
+
+package libkb
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFormatKVAndParseKVRoundTrip(t *testing.T) {
+	cases := []struct {
+		msg           string
+		keysAndValues []interface{}
+		wantFields    map[string]string
+	}{
+		{
+			msg:           "no fields",
+			keysAndValues: nil,
+			wantFields:    map[string]string{},
+		},
+		{
+			msg:           "got state",
+			keysAndValues: []interface{}{"bid", "deadbeef", "readyToSend", true},
+			wantFields:    map[string]string{"bid": "deadbeef", "readyToSend": "true"},
+		},
+		{
+			msg:           "value needs quoting",
+			keysAndValues: []interface{}{"provider", "touch id", "reason", `has "quotes"`},
+			wantFields:    map[string]string{"provider": "touch id", "reason": `has "quotes"`},
+		},
+		{
+			msg:           "odd number of args",
+			keysAndValues: []interface{}{"key"},
+			wantFields:    map[string]string{"key": "MISSING"},
+		},
+	}
+	for _, c := range cases {
+		line := formatKV(c.msg, c.keysAndValues)
+		require.True(t, strings.HasPrefix(line, c.msg), "line %q should start with msg %q", line, c.msg)
+		require.Equal(t, c.wantFields, ParseKV(line), "line: %q", line)
+	}
+}
+
+func TestParseKVNoFields(t *testing.T) {
+	require.Equal(t, map[string]string{}, ParseKV("a plain log line with no fields"))
+}
@@ -0,0 +1,126 @@
+// Copyright 2020 Keybase, Inc. All rights reserved. Use of
+// this source code is governed by the included BSD license.
+
+// Code in this file lets callers stash an arbitrary-length secret (e.g. a
+// PGP unlock passphrase) in the OS secret store under a caller-chosen
+// label, the same trick passphrase_stream_store.go uses to store passphrase
+// stream parts: the secret store only speaks LKSecFullSecret-shaped
+// (32-byte) values, so the secret is split across as many such entries as
+// it takes.
+
+package libkb
+
+import (
+	"encoding/binary"
+	"fmt"
+	"strings"
+)
+
+// labeledSecretChunkSize is how many payload bytes fit in a single
+// LKSecFullSecret-shaped entry: 4 bytes record this chunk's payload length,
+// leaving the rest for data.
+const labeledSecretChunkSize = LKSecLen - 4
+
+// labeledSecretMarker is embedded in every identifier this file writes, so
+// isPPSSecretStore can tell them apart from real usernames (same trick
+// passphrase_stream_store.go's suffixes use).
+const labeledSecretMarker = "labeled_secret."
+
+func formatLabeledSecretIdentifier(username NormalizedUsername, label string, chunk int) NormalizedUsername {
+	return NormalizedUsername(fmt.Sprintf("%s.%s%s_%d", username, labeledSecretMarker, label, chunk))
+}
+
+func isLabeledSecretStoreIdentifier(identifier string) bool {
+	return strings.Contains(identifier, "."+labeledSecretMarker)
+}
+
+// storeLabeledSecret stashes secret in the OS secret store for username
+// under label, overwriting any value already stored there.
+func storeLabeledSecret(m MetaContext, username NormalizedUsername, label string, secret string) error {
+	ss := m.G().SecretStore()
+	if ss == nil {
+		return SecretStoreError{Msg: "no secret store available"}
+	}
+
+	data := []byte(secret)
+	for chunk, offset := 0, 0; ; chunk++ {
+		end := offset + labeledSecretChunkSize
+		if end > len(data) {
+			end = len(data)
+		}
+		part := data[offset:end]
+
+		var buf [LKSecLen]byte
+		binary.BigEndian.PutUint32(buf[:4], uint32(len(part)))
+		copy(buf[4:], part)
+
+		if err := ss.StoreSecret(m, formatLabeledSecretIdentifier(username, label, chunk), LKSecFullSecret{f: &buf}); err != nil {
+			return err
+		}
+
+		offset = end
+		if len(part) < labeledSecretChunkSize {
+			break
+		}
+	}
+	return nil
+}
+
+// retrieveLabeledSecret fetches a secret previously stored with
+// storeLabeledSecret. It returns a SecretStoreError on a miss, and a
+// corruption error if a stored chunk isn't shaped the way this file writes
+// them (e.g. it was written by something else, or partially overwritten).
+func retrieveLabeledSecret(m MetaContext, username NormalizedUsername, label string) (string, error) {
+	ss := m.G().SecretStore()
+	if ss == nil {
+		return "", SecretStoreError{Msg: "no secret store available"}
+	}
+
+	var data []byte
+	for chunk := 0; ; chunk++ {
+		full, err := ss.RetrieveSecret(m, formatLabeledSecretIdentifier(username, label, chunk))
+		if err != nil {
+			return "", err
+		}
+		raw := full.Bytes()
+		if len(raw) != LKSecLen {
+			return "", SecretStoreError{Msg: fmt.Sprintf("corrupt labeled secret %q: chunk %d has the wrong length", label, chunk)}
+		}
+		n := binary.BigEndian.Uint32(raw[:4])
+		if n > labeledSecretChunkSize {
+			return "", SecretStoreError{Msg: fmt.Sprintf("corrupt labeled secret %q: chunk %d has an invalid length prefix", label, chunk)}
+		}
+		data = append(data, raw[4:4+n]...)
+		if n < labeledSecretChunkSize {
+			break
+		}
+	}
+	return string(data), nil
+}
+
+// pgpPassphraseStoreLabel is the label under which a PGP key's unlock
+// passphrase is stashed in the secret store, keyed by the key's
+// fingerprint so multiple PGP keys for the same user don't collide.
+func pgpPassphraseStoreLabel(fp PGPFingerprint) string {
+	return "pgp_passphrase_" + fp.String()
+}
+
+// clearLabeledSecret removes a secret previously stored with
+// storeLabeledSecret, if any. It's not an error for there to be nothing to
+// clear.
+func clearLabeledSecret(m MetaContext, username NormalizedUsername, label string) error {
+	ss := m.G().SecretStore()
+	if ss == nil {
+		return nil
+	}
+	for chunk := 0; ; chunk++ {
+		id := formatLabeledSecretIdentifier(username, label, chunk)
+		if _, err := ss.RetrieveSecret(m, id); err != nil {
+			break
+		}
+		if err := ss.ClearSecret(m, id); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,89 @@
+// Copyright 2020 Keybase, Inc. All rights reserved. Use of
+// this source code is governed by the included BSD license.
+
+package libkb
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLabeledSecretStoreHitAndMiss(t *testing.T) {
+	tc := SetupTest(t, "labeled secret store", 1)
+	defer tc.Cleanup()
+
+	m := NewMetaContextForTest(tc)
+	nu := NewNormalizedUsername("tusername")
+
+	_, err := retrieveLabeledSecret(m, nu, "pgp_passphrase_deadbeef")
+	require.Error(t, err, "expected a miss before anything is stored")
+
+	err = storeLabeledSecret(m, nu, "pgp_passphrase_deadbeef", "hunter2")
+	require.NoError(t, err)
+
+	got, err := retrieveLabeledSecret(m, nu, "pgp_passphrase_deadbeef")
+	require.NoError(t, err)
+	require.Equal(t, "hunter2", got)
+
+	// A different label for the same user is independent.
+	_, err = retrieveLabeledSecret(m, nu, "pgp_passphrase_cafebabe")
+	require.Error(t, err)
+
+	err = clearLabeledSecret(m, nu, "pgp_passphrase_deadbeef")
+	require.NoError(t, err)
+
+	_, err = retrieveLabeledSecret(m, nu, "pgp_passphrase_deadbeef")
+	require.Error(t, err, "expected a miss after clearing")
+}
+
+func TestLabeledSecretStoreMultiChunk(t *testing.T) {
+	tc := SetupTest(t, "labeled secret store", 1)
+	defer tc.Cleanup()
+
+	m := NewMetaContextForTest(tc)
+	nu := NewNormalizedUsername("tusername")
+
+	long := ""
+	for len(long) < labeledSecretChunkSize*3 {
+		long += "0123456789abcdef"
+	}
+
+	err := storeLabeledSecret(m, nu, "long", long)
+	require.NoError(t, err)
+
+	got, err := retrieveLabeledSecret(m, nu, "long")
+	require.NoError(t, err)
+	require.Equal(t, long, got)
+}
+
+func TestLabeledSecretStoreCorrupt(t *testing.T) {
+	tc := SetupTest(t, "labeled secret store", 1)
+	defer tc.Cleanup()
+
+	m := NewMetaContextForTest(tc)
+	nu := NewNormalizedUsername("tusername")
+
+	// A chunk written by something else entirely (its first 4 bytes aren't a
+	// valid length prefix).
+	badSecret, err := newLKSecFullSecretFromBytes([]byte("not a valid labeled secret chunk"))
+	require.NoError(t, err)
+	err = m.G().SecretStore().StoreSecret(m, formatLabeledSecretIdentifier(nu, "corrupt", 0), badSecret)
+	require.NoError(t, err)
+
+	_, err = retrieveLabeledSecret(m, nu, "corrupt")
+	require.Error(t, err)
+	require.IsType(t, SecretStoreError{}, err)
+
+	// A chunk whose length prefix lies about how much data follows.
+	var buf [LKSecLen]byte
+	buf[3] = byte(labeledSecretChunkSize + 1)
+	lenLieSecret, err := newLKSecFullSecretFromBytes(buf[:])
+	require.NoError(t, err)
+	err = m.G().SecretStore().StoreSecret(m, formatLabeledSecretIdentifier(nu, "lenlie", 0), lenLieSecret)
+	require.NoError(t, err)
+
+	_, err = retrieveLabeledSecret(m, nu, "lenlie")
+	require.Error(t, err)
+	require.IsType(t, SecretStoreError{}, err)
+}
@@ -65,6 +65,34 @@ func levelDbPut(ops levelDBOps, cleaner *levelDbCleaner, id DbKey, aliases []DbK
 	return nil
 }
 
+// levelDbPutMany writes every item (and its aliases) in a single batch, so
+// the whole set commits atomically and pays transaction overhead once
+// instead of once per row.
+func levelDbPutMany(ops levelDBOps, cleaner *levelDbCleaner, items []DbPutManyItem) (err error) {
+	defer convertNoSpaceError(&err)
+
+	batch := new(leveldb.Batch)
+	var touched [][]byte
+	for _, item := range items {
+		idb := item.Key.ToBytes()
+		batch.Put(idb, item.Value)
+		touched = append(touched, idb)
+		for _, alias := range item.Aliases {
+			aliasKey := alias.ToBytesLookup()
+			batch.Put(aliasKey, idb)
+			touched = append(touched, aliasKey)
+		}
+	}
+
+	if err := ops.Write(batch, nil); err != nil {
+		return err
+	}
+	for _, key := range touched {
+		cleaner.markRecentlyUsed(context.Background(), key)
+	}
+	return nil
+}
+
 func levelDbGetWhich(ops levelDBOps, cleaner *levelDbCleaner, key []byte) (val []byte, found bool, err error) {
 	val, err = ops.Get(key, nil)
 	found = false
@@ -367,6 +395,29 @@ func (l *LevelDb) Delete(id DbKey) error {
 	})
 }
 
+func (l *LevelDb) GetMany(ids []DbKey) (values [][]byte, found []bool, err error) {
+	err = l.doWhileOpenAndNukeIfCorrupted(func() error {
+		values = make([][]byte, len(ids))
+		found = make([]bool, len(ids))
+		for i, id := range ids {
+			val, ok, err := levelDbGet(l.db, l.cleaner, id)
+			if err != nil {
+				return err
+			}
+			values[i] = val
+			found[i] = ok
+		}
+		return nil
+	})
+	return values, found, err
+}
+
+func (l *LevelDb) PutMany(items []DbPutManyItem) error {
+	return l.doWhileOpenAndNukeIfCorrupted(func() error {
+		return levelDbPutMany(l.db, l.cleaner, items)
+	})
+}
+
 func (l *LevelDb) OpenTransaction() (LocalDbTransaction, error) {
 	var (
 		ltr LevelDbTransaction
@@ -408,6 +459,46 @@ func (l *LevelDb) KeysWithPrefixes(prefixes ...[]byte) (DBKeySet, error) {
 	return m, nil
 }
 
+// ScanPrefix implements LocalDb for LevelDb. It reads every matching row
+// into memory before invoking fn, so fn may safely Delete the row it was
+// just handed without disturbing the underlying iterator.
+func (l *LevelDb) ScanPrefix(typ ObjType, prefix string, fn func(key DbKey, value []byte) error) error {
+	type scanRow struct {
+		key   DbKey
+		value []byte
+	}
+	var rows []scanRow
+	err := l.doWhileOpenAndNukeIfCorrupted(func() error {
+		byteScan := []byte(fmt.Sprintf("%s:%s", PrefixString(typ), prefix))
+		opts := &opt.ReadOptions{DontFillCache: true}
+		iter := l.db.NewIterator(util.BytesPrefix(byteScan), opts)
+		defer iter.Release()
+		for iter.Next() {
+			_, dbKey, err := DbKeyParse(string(iter.Key()))
+			if err != nil {
+				return err
+			}
+			value := make([]byte, len(iter.Value()))
+			copy(value, iter.Value())
+			rows = append(rows, scanRow{key: dbKey, value: value})
+		}
+		return iter.Error()
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, row := range rows {
+		if err := fn(row.key, row.value); err != nil {
+			if err == ErrStopScan {
+				return nil
+			}
+			return err
+		}
+	}
+	return nil
+}
+
 type LevelDbTransaction struct {
 	tr      *leveldb.Transaction
 	cleaner *levelDbCleaner
@@ -429,6 +520,24 @@ func (l LevelDbTransaction) Delete(id DbKey) error {
 	return levelDbDelete(l.tr, l.cleaner, id)
 }
 
+func (l LevelDbTransaction) GetMany(ids []DbKey) (values [][]byte, found []bool, err error) {
+	values = make([][]byte, len(ids))
+	found = make([]bool, len(ids))
+	for i, id := range ids {
+		val, ok, err := levelDbGet(l.tr, l.cleaner, id)
+		if err != nil {
+			return nil, nil, err
+		}
+		values[i] = val
+		found[i] = ok
+	}
+	return values, found, nil
+}
+
+func (l LevelDbTransaction) PutMany(items []DbPutManyItem) error {
+	return levelDbPutMany(l.tr, l.cleaner, items)
+}
+
 func (l LevelDbTransaction) Commit() (err error) {
 	defer convertNoSpaceError(&err)
 	return l.tr.Commit()
@@ -385,7 +385,7 @@ func (s *LKSec) tryAllDevicesForBug3964Recovery(m MetaContext, devices DeviceKey
 		}
 	}
 
-	err = PassphraseError{"failed to open secretbox"}
+	err = PassphraseError{Msg: "failed to open secretbox"}
 	return nil, LKSecServerHalf{}, err
 }
 
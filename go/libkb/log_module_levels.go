@@ -0,0 +1,92 @@
+// Copyright 2016 Keybase, Inc. All rights reserved. Use of
+// this source code is governed by the included BSD license.
+
+package libkb
+
+import (
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	keybase1 "github.com/keybase/client/go/protocol/keybase1"
+)
+
+// ModuleLogLevels tracks a per-module override of the global log level, so
+// e.g. "stellar" or "kbfs-notify" can be turned up to LogLevel_DEBUG at
+// runtime without flooding the log with every other module's debug
+// chatter. Reads are a single atomic load, so MetaContext's debug helpers
+// can check it on every log call without worrying about overhead.
+//
+// Unknown module names are accepted and default to the global level --
+// there's no registry to declare a module ahead of time, a caller just
+// needs to use the same string when logging and when configuring.
+type ModuleLogLevels struct {
+	defaultLevel int32 // keybase1.LogLevel, accessed atomically
+
+	mu     sync.Mutex // protects inserts into levels
+	levels sync.Map   // module string -> *int32 (keybase1.LogLevel, accessed atomically)
+}
+
+func NewModuleLogLevels() *ModuleLogLevels {
+	m := &ModuleLogLevels{}
+	atomic.StoreInt32(&m.defaultLevel, int32(keybase1.LogLevel_INFO))
+	return m
+}
+
+// SetDefault sets the log level used for any module that hasn't been given
+// its own override.
+func (m *ModuleLogLevels) SetDefault(level keybase1.LogLevel) {
+	atomic.StoreInt32(&m.defaultLevel, int32(level))
+}
+
+// Set overrides the log level for a specific module.
+func (m *ModuleLogLevels) Set(module string, level keybase1.LogLevel) {
+	if lev, ok := m.levels.Load(module); ok {
+		atomic.StoreInt32(lev.(*int32), int32(level))
+		return
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if lev, ok := m.levels.Load(module); ok {
+		atomic.StoreInt32(lev.(*int32), int32(level))
+		return
+	}
+	lev := int32(level)
+	m.levels.Store(module, &lev)
+}
+
+// GetEffectiveLevel returns the configured level for module, or the
+// default level if module has no override.
+func (m *ModuleLogLevels) GetEffectiveLevel(module string) keybase1.LogLevel {
+	if lev, ok := m.levels.Load(module); ok {
+		return keybase1.LogLevel(atomic.LoadInt32(lev.(*int32)))
+	}
+	return keybase1.LogLevel(atomic.LoadInt32(&m.defaultLevel))
+}
+
+// IsEnabled reports whether a message at the given level should be logged
+// for module.
+func (m *ModuleLogLevels) IsEnabled(module string, level keybase1.LogLevel) bool {
+	return level >= m.GetEffectiveLevel(module)
+}
+
+// ConfigureFromString parses a "module=level,module=level" startup config
+// string (as found in the config file or on the command line) and applies
+// it. Malformed entries are ignored.
+func (m *ModuleLogLevels) ConfigureFromString(s string) {
+	if len(s) == 0 {
+		return
+	}
+	for _, part := range strings.Split(s, ",") {
+		kv := strings.SplitN(part, "=", 2)
+		if len(kv) != 2 {
+			continue
+		}
+		level, ok := keybase1.LogLevelMap[strings.ToUpper(strings.TrimSpace(kv[1]))]
+		if !ok {
+			continue
+		}
+		m.Set(strings.TrimSpace(kv[0]), level)
+	}
+}
@@ -0,0 +1,40 @@
+// Copyright 2016 Keybase, Inc. All rights reserved. Use of
+// this source code is governed by the included BSD license.
+
+package libkb
+
+import (
+	"testing"
+
+	keybase1 "github.com/keybase/client/go/protocol/keybase1"
+	"github.com/stretchr/testify/require"
+)
+
+func TestModuleLogLevelsUnknownModuleDefaultsToGlobal(t *testing.T) {
+	m := NewModuleLogLevels()
+	require.False(t, m.IsEnabled("stellar", keybase1.LogLevel_DEBUG))
+
+	m.SetDefault(keybase1.LogLevel_DEBUG)
+	require.True(t, m.IsEnabled("stellar", keybase1.LogLevel_DEBUG))
+}
+
+func TestModuleLogLevelsRuntimeFlip(t *testing.T) {
+	m := NewModuleLogLevels()
+	require.False(t, m.IsEnabled("stellar", keybase1.LogLevel_DEBUG))
+
+	m.Set("stellar", keybase1.LogLevel_DEBUG)
+	require.True(t, m.IsEnabled("stellar", keybase1.LogLevel_DEBUG))
+	require.False(t, m.IsEnabled("pgp", keybase1.LogLevel_DEBUG))
+
+	m.Set("stellar", keybase1.LogLevel_INFO)
+	require.False(t, m.IsEnabled("stellar", keybase1.LogLevel_DEBUG))
+}
+
+func TestModuleLogLevelsConfigureFromString(t *testing.T) {
+	m := NewModuleLogLevels()
+	m.ConfigureFromString("stellar=debug, kbfs-notify=warn,garbage")
+
+	require.True(t, m.IsEnabled("stellar", keybase1.LogLevel_DEBUG))
+	require.Equal(t, keybase1.LogLevel_WARN, m.GetEffectiveLevel("kbfs-notify"))
+	require.Equal(t, keybase1.LogLevel_INFO, m.GetEffectiveLevel("pgp"))
+}
@@ -3,6 +3,8 @@ package libkb
 import (
 	"errors"
 	"fmt"
+	"sort"
+	"strings"
 	"sync"
 
 	lru "github.com/hashicorp/golang-lru"
@@ -74,6 +76,64 @@ func (m *MemDb) Lookup(alias DbKey) ([]byte, bool, error) {
 	return m.Get(alias)
 }
 
+func (m *MemDb) GetMany(ids []DbKey) ([][]byte, []bool, error) {
+	values := make([][]byte, len(ids))
+	found := make([]bool, len(ids))
+	for i, id := range ids {
+		val, ok, err := m.Get(id)
+		if err != nil {
+			return nil, nil, err
+		}
+		values[i] = val
+		found[i] = ok
+	}
+	return values, found, nil
+}
+
+func (m *MemDb) PutMany(items []DbPutManyItem) error {
+	for _, item := range items {
+		if err := m.Put(item.Key, item.Aliases, item.Value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func (m *MemDb) KeysWithPrefixes(prefixes ...[]byte) (DBKeySet, error) {
 	return nil, fmt.Errorf("unimplemented on memdb")
 }
+
+// ScanPrefix implements LocalDb for MemDb. It snapshots the matching rows
+// under the lock, in key order, before invoking fn, so fn may safely
+// Delete the row it was just handed.
+func (m *MemDb) ScanPrefix(typ ObjType, prefix string, fn func(key DbKey, value []byte) error) error {
+	type scanRow struct {
+		key   DbKey
+		value []byte
+	}
+	var rows []scanRow
+	m.Lock()
+	for _, k := range m.lru.Keys() {
+		dbKey, ok := k.(DbKey)
+		if !ok || dbKey.Typ != typ || !strings.HasPrefix(dbKey.Key, prefix) {
+			continue
+		}
+		val, ok := m.lru.Peek(k)
+		if !ok {
+			continue
+		}
+		rows = append(rows, scanRow{key: dbKey, value: val.([]byte)})
+	}
+	m.Unlock()
+	sort.Slice(rows, func(i, j int) bool { return rows[i].key.Key < rows[j].key.Key })
+
+	for _, row := range rows {
+		if err := fn(row.key, row.value); err != nil {
+			if err == ErrStopScan {
+				return nil
+			}
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,48 @@
+package libkb
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemDbScanPrefix(t *testing.T) {
+	db := NewMemDb(100)
+
+	for i := 0; i < 5; i++ {
+		key := DbKey{Typ: 1, Key: fmt.Sprintf("match-%d", i)}
+		require.NoError(t, db.Put(key, nil, []byte{byte(i)}))
+	}
+	require.NoError(t, db.Put(DbKey{Typ: 2, Key: "match-0"}, nil, []byte{0xff}))
+	require.NoError(t, db.Put(DbKey{Typ: 1, Key: "other-0"}, nil, []byte{0xff}))
+
+	var seen []string
+	err := db.ScanPrefix(1, "match-", func(key DbKey, value []byte) error {
+		seen = append(seen, key.Key)
+		return db.Delete(key)
+	})
+	require.NoError(t, err)
+	require.Len(t, seen, 5)
+
+	for i := 0; i < 5; i++ {
+		_, found, err := db.Get(DbKey{Typ: 1, Key: fmt.Sprintf("match-%d", i)})
+		require.NoError(t, err)
+		require.False(t, found)
+	}
+	_, found, err := db.Get(DbKey{Typ: 2, Key: "match-0"})
+	require.NoError(t, err)
+	require.True(t, found)
+
+	count := 0
+	for i := 0; i < 3; i++ {
+		key := DbKey{Typ: 3, Key: fmt.Sprintf("stop-%d", i)}
+		require.NoError(t, db.Put(key, nil, []byte{byte(i)}))
+	}
+	err = db.ScanPrefix(3, "stop-", func(key DbKey, value []byte) error {
+		count++
+		return ErrStopScan
+	})
+	require.NoError(t, err)
+	require.Equal(t, 1, count)
+}
@@ -324,17 +324,20 @@ func (k NaclSigningKeyPair) VerifyStringAndExtract(ctx VerifyContext, sig string
 	var fullSigBody []byte
 	keyInSignature, msg, fullSigBody, err = kbcrypto.NaclVerifyAndExtract(sig)
 	if err != nil {
+		verifyFailure(ctx, VerifyFailureParseError, err.Error())
 		return nil, id, err
 	}
 
+	id = kbcrypto.ComputeSigIDFromSigBody(fullSigBody)
 	kidInSig := keyInSignature.GetKID()
 	kidWanted := k.GetKID()
+	verifyAttempt(ctx, kidWanted.String(), id)
 	if kidWanted.NotEqual(kidInSig) {
 		err = WrongKidError{kidInSig, kidWanted}
+		verifyFailure(ctx, VerifyFailureWrongKey, err.Error())
 		return nil, id, err
 	}
 
-	id = kbcrypto.ComputeSigIDFromSigBody(fullSigBody)
 	return msg, id, nil
 }
 
@@ -343,16 +346,19 @@ func (k NaclSigningKeyPair) VerifyString(ctx VerifyContext, sig string, msg []by
 	var fullSigBody []byte
 	keyInSignature, fullSigBody, err = kbcrypto.NaclVerifyWithPayload(sig, msg)
 	if err != nil {
+		verifyFailure(ctx, VerifyFailureParseError, err.Error())
 		return id, err
 	}
+	id = kbcrypto.ComputeSigIDFromSigBody(fullSigBody)
 	kidInSig := keyInSignature.GetKID()
 	kidWanted := k.GetKID()
+	verifyAttempt(ctx, kidWanted.String(), id)
 	if kidWanted.NotEqual(kidInSig) {
 		err = WrongKidError{kidInSig, kidWanted}
+		verifyFailure(ctx, VerifyFailureWrongKey, err.Error())
 		return id, err
 	}
 
-	id = kbcrypto.ComputeSigIDFromSigBody(fullSigBody)
 	return id, nil
 }
 
@@ -4,11 +4,13 @@
 package libkb
 
 import (
+	"crypto"
 	"encoding/base64"
 	"encoding/hex"
 	"testing"
 
 	"github.com/keybase/client/go/kbcrypto"
+	keybase1 "github.com/keybase/client/go/protocol/keybase1"
 	"github.com/stretchr/testify/require"
 )
 
@@ -99,6 +101,71 @@ func TestVerifyStringReject(t *testing.T) {
 	}
 }
 
+type recordingVerifyContext struct {
+	BaseVerifyContextHooks
+	attempts int
+	failures []VerifyFailureReason
+	warnings []HashSecurityWarnings
+}
+
+func (r *recordingVerifyContext) Debug(format string, args ...interface{}) {}
+
+func (r *recordingVerifyContext) OnVerifyAttempt(keyFingerprint string, sigID keybase1.SigIDBase) {
+	r.attempts++
+}
+
+func (r *recordingVerifyContext) OnVerifyFailure(reason VerifyFailureReason, details string) {
+	r.failures = append(r.failures, reason)
+}
+
+func (r *recordingVerifyContext) OnVerifyWarning(keyFingerprint string, sigID keybase1.SigIDBase, warnings HashSecurityWarnings) {
+	r.warnings = append(r.warnings, warnings)
+}
+
+// Test that the package-level verifyWarning dispatcher only calls
+// OnVerifyWarning when there's actually something to report.
+func TestVerifyWarningHook(t *testing.T) {
+	ctx := &recordingVerifyContext{}
+
+	verifyWarning(ctx, "deadbeef", "", nil)
+	require.Empty(t, ctx.warnings, "no warnings should mean no hook call")
+
+	warnings := HashSecurityWarnings{NewHashSecurityWarning(HashSecurityWarningSignatureHash, crypto.SHA1, nil)}
+	verifyWarning(ctx, "deadbeef", "", warnings)
+	require.Equal(t, []HashSecurityWarnings{warnings}, ctx.warnings)
+}
+
+// Test that VerifyContextHooks are called on both success and failure.
+func TestVerifyStringHooks(t *testing.T) {
+	keyPair, err := GenerateNaclSigningKeyPair()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	msg := []byte("test message")
+	sig, _, err := keyPair.SignToString(msg)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := &recordingVerifyContext{}
+	if _, err := keyPair.VerifyString(ctx, sig, msg); err != nil {
+		t.Fatal(err)
+	}
+	require.Equal(t, 1, ctx.attempts)
+	require.Empty(t, ctx.failures)
+
+	keyPair2, err := GenerateNaclSigningKeyPair()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := keyPair2.VerifyString(ctx, sig, msg); err == nil {
+		t.Error("Signature with different key unexpectedly passes")
+	}
+	require.Equal(t, 2, ctx.attempts)
+	require.Equal(t, []VerifyFailureReason{VerifyFailureWrongKey}, ctx.failures)
+}
+
 // Test that VerifyBytes accepts the output of SignToBytes.
 func TestVerifyBytesAccept(t *testing.T) {
 	keyPair, err := GenerateNaclSigningKeyPair()
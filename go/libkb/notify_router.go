@@ -34,6 +34,14 @@ type NotifyListener interface {
 	FSEditListRequest(arg keybase1.FSEditListRequest)
 	FSOverallSyncStatusChanged(arg keybase1.FolderSyncStatus)
 	FSFavoritesChanged()
+	FSKeyGenerationRotated(arg keybase1.KeyGenerationRotatedArg)
+	FSDirListProgress(arg keybase1.DirListProgressArg)
+	FSFolderPathChanged(arg keybase1.FolderPathChangedArg)
+	FSRemoteFileUpdated(arg keybase1.RemoteFileUpdatedArg)
+	FSSnapshotComplete(arg keybase1.SnapshotCompleteArg)
+	FSOfflineEditsAtRisk(arg keybase1.OfflineEditsAtRiskArg)
+	FSStagedChangesCount(arg keybase1.StagedChangesCountArg)
+	FSForegroundFetchComplete(arg keybase1.ForegroundFetchCompleteArg)
 	FavoritesChanged(uid keybase1.UID)
 	FSSubscriptionNotify(arg keybase1.FSSubscriptionNotifyArg)
 	FSSubscriptionNotifyPath(arg keybase1.FSSubscriptionNotifyPathArg)
@@ -122,22 +130,30 @@ type NoopNotifyListener struct{}
 
 var _ NotifyListener = (*NoopNotifyListener)(nil)
 
-func (n *NoopNotifyListener) Logout()                                                       {}
-func (n *NoopNotifyListener) Login(username string)                                         {}
-func (n *NoopNotifyListener) ClientOutOfDate(to, uri, msg string)                           {}
-func (n *NoopNotifyListener) UserChanged(uid keybase1.UID)                                  {}
-func (n *NoopNotifyListener) TrackingChanged(uid keybase1.UID, username NormalizedUsername) {}
-func (n *NoopNotifyListener) TrackingInfo(uid keybase1.UID, followers, followees []string)  {}
-func (n *NoopNotifyListener) FSOnlineStatusChanged(online bool)                             {}
-func (n *NoopNotifyListener) FSOverallSyncStatusChanged(status keybase1.FolderSyncStatus)   {}
-func (n *NoopNotifyListener) FSFavoritesChanged()                                           {}
-func (n *NoopNotifyListener) FSActivity(activity keybase1.FSNotification)                   {}
-func (n *NoopNotifyListener) FSPathUpdated(path string)                                     {}
-func (n *NoopNotifyListener) FSEditListResponse(arg keybase1.FSEditListArg)                 {}
-func (n *NoopNotifyListener) FSSyncStatusResponse(arg keybase1.FSSyncStatusArg)             {}
-func (n *NoopNotifyListener) FSSyncEvent(arg keybase1.FSPathSyncStatus)                     {}
-func (n *NoopNotifyListener) FSEditListRequest(arg keybase1.FSEditListRequest)              {}
-func (n *NoopNotifyListener) FavoritesChanged(uid keybase1.UID)                             {}
+func (n *NoopNotifyListener) Logout()                                                           {}
+func (n *NoopNotifyListener) Login(username string)                                             {}
+func (n *NoopNotifyListener) ClientOutOfDate(to, uri, msg string)                               {}
+func (n *NoopNotifyListener) UserChanged(uid keybase1.UID)                                      {}
+func (n *NoopNotifyListener) TrackingChanged(uid keybase1.UID, username NormalizedUsername)     {}
+func (n *NoopNotifyListener) TrackingInfo(uid keybase1.UID, followers, followees []string)      {}
+func (n *NoopNotifyListener) FSOnlineStatusChanged(online bool)                                 {}
+func (n *NoopNotifyListener) FSOverallSyncStatusChanged(status keybase1.FolderSyncStatus)       {}
+func (n *NoopNotifyListener) FSFavoritesChanged()                                               {}
+func (n *NoopNotifyListener) FSKeyGenerationRotated(arg keybase1.KeyGenerationRotatedArg)       {}
+func (n *NoopNotifyListener) FSDirListProgress(arg keybase1.DirListProgressArg)                 {}
+func (n *NoopNotifyListener) FSFolderPathChanged(arg keybase1.FolderPathChangedArg)             {}
+func (n *NoopNotifyListener) FSRemoteFileUpdated(arg keybase1.RemoteFileUpdatedArg)             {}
+func (n *NoopNotifyListener) FSSnapshotComplete(arg keybase1.SnapshotCompleteArg)               {}
+func (n *NoopNotifyListener) FSOfflineEditsAtRisk(arg keybase1.OfflineEditsAtRiskArg)           {}
+func (n *NoopNotifyListener) FSStagedChangesCount(arg keybase1.StagedChangesCountArg)           {}
+func (n *NoopNotifyListener) FSForegroundFetchComplete(arg keybase1.ForegroundFetchCompleteArg) {}
+func (n *NoopNotifyListener) FSActivity(activity keybase1.FSNotification)                       {}
+func (n *NoopNotifyListener) FSPathUpdated(path string)                                         {}
+func (n *NoopNotifyListener) FSEditListResponse(arg keybase1.FSEditListArg)                     {}
+func (n *NoopNotifyListener) FSSyncStatusResponse(arg keybase1.FSSyncStatusArg)                 {}
+func (n *NoopNotifyListener) FSSyncEvent(arg keybase1.FSPathSyncStatus)                         {}
+func (n *NoopNotifyListener) FSEditListRequest(arg keybase1.FSEditListRequest)                  {}
+func (n *NoopNotifyListener) FavoritesChanged(uid keybase1.UID)                                 {}
 func (n *NoopNotifyListener) FSSubscriptionNotify(arg keybase1.FSSubscriptionNotifyArg) {
 }
 func (n *NoopNotifyListener) FSSubscriptionNotifyPath(arg keybase1.FSSubscriptionNotifyPathArg) {
@@ -689,6 +705,230 @@ func (n *NotifyRouter) HandleFSFavoritesChanged() {
 	})
 }
 
+// HandleFSKeyGenerationRotated is called when KBFS rotates a TLF's
+// encryption key to a new generation. It will broadcast the messages to
+// all curious listeners, so they can note it in a security-audit log.
+func (n *NotifyRouter) HandleFSKeyGenerationRotated(arg keybase1.KeyGenerationRotatedArg) {
+	if n == nil {
+		return
+	}
+	// For all connections we currently have open...
+	n.cm.ApplyAll(func(id ConnectionID, xp rpc.Transporter) bool {
+		// If the connection wants the `kbfs` notification type
+		if n.getNotificationChannels(id).Kbfs {
+			// In the background do...
+			go func() {
+				// A send of a `FSKeyGenerationRotated` RPC with the
+				// notification
+				_ = (keybase1.NotifyFSClient{
+					Cli: rpc.NewClient(xp, NewContextifiedErrorUnwrapper(n.G()), nil),
+				}).FSKeyGenerationRotated(context.Background(), keybase1.FSKeyGenerationRotatedArg(arg))
+			}()
+		}
+		return true
+	})
+	n.runListeners(func(listener NotifyListener) {
+		listener.FSKeyGenerationRotated(arg)
+	})
+}
+
+// HandleFSDirListProgress is called while KBFS is still enumerating a large
+// directory. It will broadcast the messages to all curious listeners, so
+// they can show a progressive listing instead of blocking on the full
+// enumeration.
+func (n *NotifyRouter) HandleFSDirListProgress(arg keybase1.DirListProgressArg) {
+	if n == nil {
+		return
+	}
+	// For all connections we currently have open...
+	n.cm.ApplyAll(func(id ConnectionID, xp rpc.Transporter) bool {
+		// If the connection wants the `kbfs` notification type
+		if n.getNotificationChannels(id).Kbfs {
+			// In the background do...
+			go func() {
+				// A send of a `FSDirListProgress` RPC with the
+				// notification
+				_ = (keybase1.NotifyFSClient{
+					Cli: rpc.NewClient(xp, NewContextifiedErrorUnwrapper(n.G()), nil),
+				}).FSDirListProgress(context.Background(), keybase1.FSDirListProgressArg(arg))
+			}()
+		}
+		return true
+	})
+	n.runListeners(func(listener NotifyListener) {
+		listener.FSDirListProgress(arg)
+	})
+}
+
+// HandleFSFolderPathChanged is called when a folder's canonical path
+// changes, e.g. because a member of an implicit team folder changed
+// usernames. It will broadcast the messages to all curious listeners, so
+// the GUI can update breadcrumbs and open handles to the new path.
+func (n *NotifyRouter) HandleFSFolderPathChanged(arg keybase1.FolderPathChangedArg) {
+	if n == nil {
+		return
+	}
+	// For all connections we currently have open...
+	n.cm.ApplyAll(func(id ConnectionID, xp rpc.Transporter) bool {
+		// If the connection wants the `kbfs` notification type
+		if n.getNotificationChannels(id).Kbfs {
+			// In the background do...
+			go func() {
+				// A send of a `FSFolderPathChanged` RPC with the
+				// notification
+				_ = (keybase1.NotifyFSClient{
+					Cli: rpc.NewClient(xp, NewContextifiedErrorUnwrapper(n.G()), nil),
+				}).FSFolderPathChanged(context.Background(), keybase1.FSFolderPathChangedArg(arg))
+			}()
+		}
+		return true
+	})
+	n.runListeners(func(listener NotifyListener) {
+		listener.FSFolderPathChanged(arg)
+	})
+}
+
+// HandleFSRemoteFileUpdated is called when KBFS notices that the server
+// version of a file open locally has advanced past what the client has
+// seen. It will broadcast the messages to all curious listeners, so an
+// editor can prompt the user to reload instead of silently overwriting the
+// newer version on save.
+func (n *NotifyRouter) HandleFSRemoteFileUpdated(arg keybase1.RemoteFileUpdatedArg) {
+	if n == nil {
+		return
+	}
+	// For all connections we currently have open...
+	n.cm.ApplyAll(func(id ConnectionID, xp rpc.Transporter) bool {
+		// If the connection wants the `kbfs` notification type
+		if n.getNotificationChannels(id).Kbfs {
+			// In the background do...
+			go func() {
+				// A send of a `FSRemoteFileUpdated` RPC with the
+				// notification
+				_ = (keybase1.NotifyFSClient{
+					Cli: rpc.NewClient(xp, NewContextifiedErrorUnwrapper(n.G()), nil),
+				}).FSRemoteFileUpdated(context.Background(), keybase1.FSRemoteFileUpdatedArg(arg))
+			}()
+		}
+		return true
+	})
+	n.runListeners(func(listener NotifyListener) {
+		listener.FSRemoteFileUpdated(arg)
+	})
+}
+
+// HandleFSSnapshotComplete is called when KBFS finishes persisting a
+// background-archive snapshot of a folder. It will broadcast the message to
+// all curious listeners, so the GUI can confirm to the user that the
+// snapshot is ready to restore from.
+func (n *NotifyRouter) HandleFSSnapshotComplete(arg keybase1.SnapshotCompleteArg) {
+	if n == nil {
+		return
+	}
+	// For all connections we currently have open...
+	n.cm.ApplyAll(func(id ConnectionID, xp rpc.Transporter) bool {
+		// If the connection wants the `kbfs` notification type
+		if n.getNotificationChannels(id).Kbfs {
+			// In the background do...
+			go func() {
+				// A send of a `FSSnapshotComplete` RPC with the
+				// notification
+				_ = (keybase1.NotifyFSClient{
+					Cli: rpc.NewClient(xp, NewContextifiedErrorUnwrapper(n.G()), nil),
+				}).FSSnapshotComplete(context.Background(), keybase1.FSSnapshotCompleteArg(arg))
+			}()
+		}
+		return true
+	})
+	n.runListeners(func(listener NotifyListener) {
+		listener.FSSnapshotComplete(arg)
+	})
+}
+
+// HandleFSOfflineEditsAtRisk is called when conflict resolution is about to
+// discard (or has already discarded) edits made while offline. It will
+// broadcast the message to all curious listeners, so the GUI can warn the
+// user to save a copy before the edits are lost.
+func (n *NotifyRouter) HandleFSOfflineEditsAtRisk(arg keybase1.OfflineEditsAtRiskArg) {
+	if n == nil {
+		return
+	}
+	// For all connections we currently have open...
+	n.cm.ApplyAll(func(id ConnectionID, xp rpc.Transporter) bool {
+		// If the connection wants the `kbfs` notification type
+		if n.getNotificationChannels(id).Kbfs {
+			// In the background do...
+			go func() {
+				// A send of a `FSOfflineEditsAtRisk` RPC with the
+				// notification
+				_ = (keybase1.NotifyFSClient{
+					Cli: rpc.NewClient(xp, NewContextifiedErrorUnwrapper(n.G()), nil),
+				}).FSOfflineEditsAtRisk(context.Background(), keybase1.FSOfflineEditsAtRiskArg(arg))
+			}()
+		}
+		return true
+	})
+	n.runListeners(func(listener NotifyListener) {
+		listener.FSOfflineEditsAtRisk(arg)
+	})
+}
+
+// HandleFSStagedChangesCount is called whenever the number of locally
+// staged-but-not-yet-flushed operations for a folder changes. It will
+// broadcast the message to all curious listeners, so the GUI can show an
+// accurate "N unsaved changes" badge.
+func (n *NotifyRouter) HandleFSStagedChangesCount(arg keybase1.StagedChangesCountArg) {
+	if n == nil {
+		return
+	}
+	// For all connections we currently have open...
+	n.cm.ApplyAll(func(id ConnectionID, xp rpc.Transporter) bool {
+		// If the connection wants the `kbfs` notification type
+		if n.getNotificationChannels(id).Kbfs {
+			// In the background do...
+			go func() {
+				// A send of a `FSStagedChangesCount` RPC with the
+				// notification
+				_ = (keybase1.NotifyFSClient{
+					Cli: rpc.NewClient(xp, NewContextifiedErrorUnwrapper(n.G()), nil),
+				}).FSStagedChangesCount(context.Background(), keybase1.FSStagedChangesCountArg(arg))
+			}()
+		}
+		return true
+	})
+	n.runListeners(func(listener NotifyListener) {
+		listener.FSStagedChangesCount(arg)
+	})
+}
+
+// HandleFSForegroundFetchComplete is called when a foreground fetch
+// triggered by a user opening a not-yet-cached file finishes. It will
+// broadcast the message to all curious listeners, so the GUI can
+// transition from a loading spinner to showing the file (or an error).
+func (n *NotifyRouter) HandleFSForegroundFetchComplete(arg keybase1.ForegroundFetchCompleteArg) {
+	if n == nil {
+		return
+	}
+	// For all connections we currently have open...
+	n.cm.ApplyAll(func(id ConnectionID, xp rpc.Transporter) bool {
+		// If the connection wants the `kbfs` notification type
+		if n.getNotificationChannels(id).Kbfs {
+			// In the background do...
+			go func() {
+				// A send of a `FSForegroundFetchComplete` RPC with the
+				// notification
+				_ = (keybase1.NotifyFSClient{
+					Cli: rpc.NewClient(xp, NewContextifiedErrorUnwrapper(n.G()), nil),
+				}).FSForegroundFetchComplete(context.Background(), keybase1.FSForegroundFetchCompleteArg(arg))
+			}()
+		}
+		return true
+	})
+	n.runListeners(func(listener NotifyListener) {
+		listener.FSForegroundFetchComplete(arg)
+	})
+}
+
 // HandleFSActivity is called for any KBFS notification. It will broadcast the messages
 // to all curious listeners.
 func (n *NotifyRouter) HandleFSActivity(activity keybase1.FSNotification) {
@@ -126,6 +126,35 @@ func GetNewKeybasePassphrase(mctx MetaContext, ui SecretUI, arg keybase1.GUIEntr
 	return keybase1.GetPassphraseRes{}, RetryExhaustedError{}
 }
 
+// GetNewPassphraseWithConfirmation prompts for a new passphrase (e.g. one
+// meant to protect an exported key, rather than the user's Keybase
+// passphrase), collecting it twice to catch typos, and returns an estimate
+// of its strength alongside the result. title and confirmPrompt set the
+// window title and prompt used for the second ("reenter to confirm") round.
+func GetNewPassphraseWithConfirmation(m MetaContext, ui SecretUI, title, prompt, confirmPrompt string) (keybase1.GetPassphraseRes, PassphraseStrength, error) {
+	arg := DefaultPassphraseArg(m)
+	arg.WindowTitle = title
+	arg.Type = keybase1.PassphraseType_NEW_PASS_PHRASE
+	arg.Prompt = prompt
+
+	res, err := GetPassphraseUntilCheckWithChecker(m, arg, newUIPrompter(ui), &CheckPassphraseSimple)
+	if err != nil {
+		return keybase1.GetPassphraseRes{}, PassphraseStrengthWeak, err
+	}
+
+	arg.Prompt = confirmPrompt
+	confirm, err := GetPassphraseUntilCheckWithChecker(m, arg, newUIPrompter(ui), &CheckPassphraseSimple)
+	if err != nil {
+		return keybase1.GetPassphraseRes{}, PassphraseStrengthWeak, err
+	}
+	if res.Passphrase != confirm.Passphrase {
+		return keybase1.GetPassphraseRes{}, PassphraseStrengthWeak, errors.New("passphrase mismatch")
+	}
+
+	strength, _ := EstimatePassphraseStrength(res.Passphrase)
+	return res, strength, nil
+}
+
 type PassphrasePrompter interface {
 	Prompt(keybase1.GUIEntryArg) (keybase1.GetPassphraseRes, error)
 }
@@ -157,7 +157,7 @@ func pplPost(m MetaContext, eOu string, lp PDPKALoginPackage) (*loginAPIResult,
 	if res.Status.Code == SCBadLoginPassword {
 		// NOTE: This error message is also hardcoded in the frontend to detect
 		// this class of errors.
-		return nil, PassphraseError{"Invalid password. Server rejected login attempt."}
+		return nil, PassphraseError{Msg: "Invalid password. Server rejected login attempt."}
 	}
 	if res.Status.Code == SCBadLoginUserNotFound {
 		return nil, NotFoundError{}
@@ -38,7 +38,8 @@ func formatPPSSecretStoreIdentifier(username NormalizedUsername, typ pwhStoreIde
 
 func isPPSSecretStore(identifier string) bool {
 	return strings.HasSuffix(identifier, string(ssEddsaSuffix)) ||
-		strings.HasSuffix(identifier, string(ssPwhashSuffix))
+		strings.HasSuffix(identifier, string(ssPwhashSuffix)) ||
+		isLabeledSecretStoreIdentifier(identifier)
 }
 
 func RetrievePwhashEddsaPassphraseStream(mctx MetaContext, username NormalizedUsername, uid keybase1.UID) (ret *PassphraseStream, err error) {
@@ -0,0 +1,86 @@
+package libkb
+
+import "unicode"
+
+// PassphraseStrength is a coarse 0-4 estimate of how hard a passphrase would
+// be to guess, modeled after the scoring scale popularized by zxcvbn (0 =
+// trivially guessable, 4 = very hard to guess). This is a local heuristic,
+// not a port of zxcvbn: it looks at length and the variety of character
+// classes used rather than attempting dictionary or pattern matching.
+type PassphraseStrength int
+
+const (
+	PassphraseStrengthWeak PassphraseStrength = iota
+	PassphraseStrengthFair
+	PassphraseStrengthGood
+	PassphraseStrengthStrong
+	PassphraseStrengthVeryStrong
+)
+
+// MinStrongPassphraseStrength is the minimum score callers should generally
+// require before letting a passphrase protect an exported secret.
+const MinStrongPassphraseStrength = PassphraseStrengthFair
+
+func (s PassphraseStrength) String() string {
+	switch s {
+	case PassphraseStrengthWeak:
+		return "weak"
+	case PassphraseStrengthFair:
+		return "fair"
+	case PassphraseStrengthGood:
+		return "good"
+	case PassphraseStrengthStrong:
+		return "strong"
+	case PassphraseStrengthVeryStrong:
+		return "very strong"
+	default:
+		return "unknown"
+	}
+}
+
+// EstimatePassphraseStrength returns a coarse strength score for s along
+// with a short human-readable hint explaining the score. It rewards length
+// and a mix of character classes, and penalizes short or single-class
+// passphrases regardless of length.
+func EstimatePassphraseStrength(s string) (PassphraseStrength, string) {
+	var hasLower, hasUpper, hasDigit, hasSymbol bool
+	for _, r := range s {
+		switch {
+		case unicode.IsLower(r):
+			hasLower = true
+		case unicode.IsUpper(r):
+			hasUpper = true
+		case unicode.IsDigit(r):
+			hasDigit = true
+		default:
+			hasSymbol = true
+		}
+	}
+
+	classes := 0
+	for _, ok := range []bool{hasLower, hasUpper, hasDigit, hasSymbol} {
+		if ok {
+			classes++
+		}
+	}
+
+	n := len(s)
+	switch {
+	case n < MinPassphraseLength:
+		return PassphraseStrengthWeak, "too short to be secure"
+	case n < 12 && classes <= 1:
+		return PassphraseStrengthWeak, "add more character variety or length"
+	case n < 12:
+		return PassphraseStrengthFair, "a longer passphrase would be harder to guess"
+	case n < 16 && classes <= 2:
+		return PassphraseStrengthFair, "mix in numbers or symbols for a stronger passphrase"
+	case n < 16:
+		return PassphraseStrengthGood, "reasonably strong"
+	case classes <= 2:
+		return PassphraseStrengthGood, "reasonably strong"
+	case n < 24:
+		return PassphraseStrengthStrong, "strong"
+	default:
+		return PassphraseStrengthVeryStrong, "very strong"
+	}
+}
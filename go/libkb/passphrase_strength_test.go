@@ -0,0 +1,31 @@
+package libkb
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestEstimatePassphraseStrength(t *testing.T) {
+	tests := []struct {
+		passphrase string
+		min        PassphraseStrength
+		max        PassphraseStrength
+	}{
+		{"short1", PassphraseStrengthWeak, PassphraseStrengthWeak},
+		{"aaaaaaaaaaaa", PassphraseStrengthWeak, PassphraseStrengthFair},
+		{"correcthorsebatterystaple", PassphraseStrengthGood, PassphraseStrengthVeryStrong},
+		{"Tr0ub4dor&3xtra!", PassphraseStrengthStrong, PassphraseStrengthVeryStrong},
+	}
+	for _, test := range tests {
+		strength, hint := EstimatePassphraseStrength(test.passphrase)
+		require.GreaterOrEqual(t, int(strength), int(test.min), "passphrase %q hint: %s", test.passphrase, hint)
+		require.LessOrEqual(t, int(strength), int(test.max), "passphrase %q hint: %s", test.passphrase, hint)
+		require.NotEmpty(t, hint)
+	}
+}
+
+func TestPassphraseStrengthString(t *testing.T) {
+	require.Equal(t, "weak", PassphraseStrengthWeak.String())
+	require.Equal(t, "very strong", PassphraseStrengthVeryStrong.String())
+}
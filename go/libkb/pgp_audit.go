@@ -0,0 +1,240 @@
+// Copyright 2015 Keybase, Inc. All rights reserved. Use of
+// this source code is governed by the included BSD license.
+
+package libkb
+
+import (
+	"crypto"
+	"crypto/rsa"
+	"fmt"
+	"math/big"
+	"time"
+)
+
+// PGPKeyAuditReport is the result of AuditKey: a snapshot of the
+// crypto-hygiene problems (if any) found in a single PGPKeyBundle. A zero
+// value (every slice nil, every bool false) means the key passed every
+// check.
+type PGPKeyAuditReport struct {
+	KeyID string
+
+	// RSAModulusBits is the primary key's RSA modulus size, or 0 if the
+	// primary key isn't RSA.
+	RSAModulusBits int
+
+	// ROCAVulnerable is true if the primary key is RSA and its modulus has
+	// the structure produced by the Infineon RSA library affected by
+	// CVE-2017-15361 (ROCA).
+	ROCAVulnerable bool
+
+	// WeakSelfSigIdentities lists the identities (user IDs) whose
+	// self-signature uses a hash IsHashSecure considers weak.
+	WeakSelfSigIdentities []string
+
+	// SHA1BindingSubkeys lists the key IDs of subkeys whose binding
+	// signature was hashed with SHA-1.
+	SHA1BindingSubkeys []string
+
+	// ExpiredSubkeys lists the key IDs of subkeys whose declared lifetime
+	// has already elapsed.
+	ExpiredSubkeys []string
+
+	// MissingEncryptionSubkey is true if the key has no subkey (or primary
+	// key) flagged for encryption, so it can't receive encrypted messages.
+	MissingEncryptionSubkey bool
+}
+
+// minimumRSAModulusBits is the smallest RSA modulus size AuditKey doesn't
+// flag as weak. NIST has recommended against anything smaller since 2015.
+const minimumRSAModulusBits = 2048
+
+// AuditKey inspects bundle for known PGP key-hygiene problems: an
+// undersized or ROCA-vulnerable RSA modulus, self-signatures or subkey
+// bindings hashed with a weak digest, expired subkeys, and the absence of
+// any usable encryption subkey. It's read-only and safe to call on a
+// public-only bundle.
+func AuditKey(bundle *PGPKeyBundle) PGPKeyAuditReport {
+	report := PGPKeyAuditReport{KeyID: bundle.PrimaryKey.KeyIdString()}
+
+	if rsaKey, ok := bundle.PrimaryKey.PublicKey.(*rsa.PublicKey); ok {
+		report.RSAModulusBits = rsaKey.N.BitLen()
+		report.ROCAVulnerable = isROCAVulnerable(rsaKey.N)
+	}
+
+	for _, ident := range bundle.Identities {
+		if ident.SelfSignature != nil && !IsHashSecure(ident.SelfSignature.Hash) {
+			report.WeakSelfSigIdentities = append(report.WeakSelfSigIdentities, ident.Name)
+		}
+	}
+
+	now := time.Now()
+	for _, sub := range bundle.Subkeys {
+		if sub.Sig == nil {
+			continue
+		}
+		if sub.Sig.Hash == crypto.SHA1 {
+			report.SHA1BindingSubkeys = append(report.SHA1BindingSubkeys, sub.PublicKey.KeyIdString())
+		}
+		if sub.Sig.KeyLifetimeSecs != nil {
+			expires := sub.PublicKey.CreationTime.Add(time.Duration(*sub.Sig.KeyLifetimeSecs) * time.Second)
+			if expires.Before(now) {
+				report.ExpiredSubkeys = append(report.ExpiredSubkeys, sub.PublicKey.KeyIdString())
+			}
+		}
+	}
+
+	report.MissingEncryptionSubkey = !bundle.CanEncrypt()
+
+	return report
+}
+
+// Problems renders r's findings as a list of human-readable lines, for
+// CLI/log output. An empty result means the key is clean.
+func (r PGPKeyAuditReport) Problems() []string {
+	var problems []string
+
+	if r.RSAModulusBits > 0 && r.RSAModulusBits < minimumRSAModulusBits {
+		problems = append(problems, fmt.Sprintf("RSA modulus is only %d bits (want at least %d)", r.RSAModulusBits, minimumRSAModulusBits))
+	}
+	if r.ROCAVulnerable {
+		problems = append(problems, "RSA modulus has the structure of a ROCA-vulnerable key (CVE-2017-15361); treat its private key as compromised")
+	}
+	for _, ident := range r.WeakSelfSigIdentities {
+		problems = append(problems, fmt.Sprintf("identity %q is self-signed with a weak hash algorithm", ident))
+	}
+	for _, keyID := range r.SHA1BindingSubkeys {
+		problems = append(problems, fmt.Sprintf("subkey %s is bound with a SHA-1 signature", keyID))
+	}
+	for _, keyID := range r.ExpiredSubkeys {
+		problems = append(problems, fmt.Sprintf("subkey %s has expired", keyID))
+	}
+	if r.MissingEncryptionSubkey {
+		problems = append(problems, "key has no subkey flagged for encryption")
+	}
+
+	return problems
+}
+
+// Warnings renders r's findings as HashSecurityWarnings carrying fp, so
+// AuditKey's findings can flow through the same Warnings pipeline
+// SecurityWarnings already feeds into OutputSignatureSuccess and
+// OutputSignatureNonKeybase.
+func (r PGPKeyAuditReport) Warnings(fp *PGPFingerprint) (warnings HashSecurityWarnings) {
+	for _, problem := range r.Problems() {
+		severity := HashSecurityWarningSeverityModerate
+		warnings = append(warnings, NewKeyAuditWarning(problem, severity, fp))
+	}
+	return warnings
+}
+
+// rocaFingerprintPrimes are small odd primes, each with a multiplicative
+// order small enough to brute force, used to screen an RSA modulus for the
+// structure produced by the Infineon RSA library behind CVE-2017-15361
+// (ROCA): such a modulus always equals 65537^x (mod M) for some x, where M
+// is the product of a fixed set of primes like these.
+var rocaFingerprintPrimes = []int64{
+	3, 5, 7, 11, 13, 17, 19, 23, 29, 31,
+	37, 41, 43, 47, 53, 59, 61, 67, 71, 73,
+	79, 83, 89, 97, 101, 103, 107, 109, 113, 127,
+	131, 137, 139, 149, 151, 157, 163, 167, 173, 179,
+	181, 191, 193, 197, 199, 211, 223, 227, 229, 233,
+	239, 241, 251, 257, 263, 269, 271, 277, 281, 283,
+}
+
+// rocaGenerator is the public exponent the vulnerable Infineon library
+// always used, and the base of the discrete-log relation ROCA moduli
+// satisfy.
+const rocaGenerator = 65537
+
+// isROCAVulnerable reports whether n has the algebraic structure of a
+// ROCA-vulnerable RSA modulus: n mod p is a power of rocaGenerator mod p
+// for every p in rocaFingerprintPrimes, with a single consistent exponent
+// across all of them (checked via CRT on the per-prime discrete logs,
+// since rocaGenerator's order modulo the product of these primes is far
+// too large to search directly). Every modulus passes 3, 5 and 7, since
+// rocaGenerator generates every nonzero residue modulo those; from 11 on, a
+// non-vulnerable modulus almost certainly fails within the first few
+// primes, so this is cheap in the common case; a genuinely vulnerable
+// modulus satisfies the relation for every prime, by construction.
+func isROCAVulnerable(n *big.Int) bool {
+	g := big.NewInt(rocaGenerator)
+
+	crtRemainder := big.NewInt(0)
+	crtModulus := big.NewInt(1)
+
+	for _, p := range rocaFingerprintPrimes {
+		prime := big.NewInt(p)
+		residue := new(big.Int).Mod(n, prime)
+		if residue.Sign() == 0 {
+			return false
+		}
+
+		exponent, order, found := discreteLogMod(g, residue, prime)
+		if !found {
+			return false
+		}
+
+		var ok bool
+		crtRemainder, crtModulus, ok = crtCombine(crtRemainder, crtModulus, exponent, order)
+		if !ok {
+			return false
+		}
+	}
+
+	return true
+}
+
+// discreteLogMod brute-forces the smallest non-negative x such that
+// g^x == target (mod p), returning it along with the multiplicative order
+// of g mod p (the modulus x is only meaningful up to). p is assumed small
+// enough (a handful of hundred at most) that this is fast.
+func discreteLogMod(g, target, p *big.Int) (x, order *big.Int, found bool) {
+	cur := big.NewInt(1)
+	one := big.NewInt(1)
+	for i := int64(0); i < p.Int64(); i++ {
+		if !found && cur.Cmp(target) == 0 {
+			x = big.NewInt(i)
+			found = true
+		}
+		cur.Mul(cur, g)
+		cur.Mod(cur, p)
+		if cur.Cmp(one) == 0 {
+			order = big.NewInt(i + 1)
+			break
+		}
+	}
+	if order == nil {
+		// g never returned to 1 within p steps; shouldn't happen for prime
+		// p coprime to g, but guard against an infinite-order read anyway.
+		return nil, nil, false
+	}
+	return x, order, found
+}
+
+// crtCombine merges the two congruences x == a1 (mod n1) and x == a2 (mod
+// n2) into a single x == a (mod n), where n = lcm(n1, n2). It returns
+// ok == false if the two congruences are inconsistent (no such x exists).
+func crtCombine(a1, n1, a2, n2 *big.Int) (a, n *big.Int, ok bool) {
+	g := new(big.Int).GCD(nil, nil, n1, n2)
+
+	diff := new(big.Int).Sub(a2, a1)
+	rem := new(big.Int).Mod(diff, g)
+	if rem.Sign() != 0 {
+		return nil, nil, false
+	}
+
+	n1g := new(big.Int).Div(n1, g)
+	n2g := new(big.Int).Div(n2, g)
+	diffg := new(big.Int).Div(diff, g)
+
+	inv := new(big.Int).ModInverse(n1g, n2g)
+	if inv == nil {
+		return nil, nil, false
+	}
+
+	t := new(big.Int).Mod(new(big.Int).Mul(diffg, inv), n2g)
+	n = new(big.Int).Mul(n1g, n2)
+	x := new(big.Int).Add(a1, new(big.Int).Mul(n1, t))
+	a = new(big.Int).Mod(x, n)
+	return a, n, true
+}
@@ -0,0 +1,113 @@
+// Copyright 2015 Keybase, Inc. All rights reserved. Use of
+// this source code is governed by the included BSD license.
+
+package libkb
+
+import (
+	"crypto"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestAuditKeyClean(t *testing.T) {
+	tc := SetupTest(t, "auditkeyclean", 1)
+	defer tc.Cleanup()
+
+	// MakePGPKey's 1024-bit keys are below minimumRSAModulusBits, so a clean
+	// key needs a 2048-bit one.
+	arg := PGPGenArg{PrimaryBits: 2048, SubkeyBits: 2048, PGPUids: []string{"auditclean@keybase.io"}}
+	require.NoError(t, arg.Init())
+	require.NoError(t, arg.CreatePGPIDs())
+	bundle, err := GeneratePGPKeyBundle(tc.G, arg, tc.G.UI.GetLogUI())
+	require.NoError(t, err)
+
+	report := AuditKey(bundle)
+	require.Empty(t, report.Problems())
+	require.False(t, report.MissingEncryptionSubkey)
+	require.False(t, report.ROCAVulnerable)
+}
+
+func TestAuditKeyWeakSelfSig(t *testing.T) {
+	tc := SetupTest(t, "auditkeyweakselfsig", 1)
+	defer tc.Cleanup()
+
+	bundle, err := tc.MakePGPKey("auditweak@keybase.io")
+	require.NoError(t, err)
+
+	ident := bundle.Identities[bundle.IdentityNames()[0]]
+	ident.SelfSignature.Hash = crypto.SHA1
+
+	report := AuditKey(bundle)
+	require.Contains(t, report.WeakSelfSigIdentities, ident.Name)
+	require.NotEmpty(t, report.Problems())
+}
+
+func TestAuditKeySHA1SubkeyBinding(t *testing.T) {
+	tc := SetupTest(t, "auditkeysha1binding", 1)
+	defer tc.Cleanup()
+
+	bundle, err := tc.MakePGPKey("auditbinding@keybase.io")
+	require.NoError(t, err)
+	require.NotEmpty(t, bundle.Subkeys)
+
+	bundle.Subkeys[0].Sig.Hash = crypto.SHA1
+
+	report := AuditKey(bundle)
+	require.Contains(t, report.SHA1BindingSubkeys, bundle.Subkeys[0].PublicKey.KeyIdString())
+}
+
+func TestAuditKeyExpiredSubkey(t *testing.T) {
+	tc := SetupTest(t, "auditkeyexpiredsubkey", 1)
+	defer tc.Cleanup()
+
+	bundle, err := tc.MakePGPKey("auditexpired@keybase.io")
+	require.NoError(t, err)
+	require.NotEmpty(t, bundle.Subkeys)
+
+	lifetime := uint32(60)
+	bundle.Subkeys[0].Sig.KeyLifetimeSecs = &lifetime
+	bundle.Subkeys[0].PublicKey.CreationTime = time.Now().Add(-time.Hour)
+
+	report := AuditKey(bundle)
+	require.Contains(t, report.ExpiredSubkeys, bundle.Subkeys[0].PublicKey.KeyIdString())
+}
+
+func TestAuditKeyMissingEncryptionSubkey(t *testing.T) {
+	tc := SetupTest(t, "auditkeymissingencrypt", 1)
+	defer tc.Cleanup()
+
+	bundle, err := tc.MakePGPKey("auditnoenc@keybase.io")
+	require.NoError(t, err)
+
+	bundle.Subkeys = nil
+	require.False(t, bundle.CanEncrypt())
+
+	report := AuditKey(bundle)
+	require.True(t, report.MissingEncryptionSubkey)
+}
+
+func TestIsROCAVulnerable(t *testing.T) {
+	M := big.NewInt(1)
+	for _, p := range rocaFingerprintPrimes {
+		M.Mul(M, big.NewInt(p))
+	}
+
+	vulnerable := new(big.Int).Exp(big.NewInt(rocaGenerator), big.NewInt(123456789), M)
+	vulnerable.Add(vulnerable, new(big.Int).Mul(big.NewInt(987654321), M))
+	require.True(t, isROCAVulnerable(vulnerable))
+
+	notVulnerable, ok := new(big.Int).SetString("912384701982374098123740981237409182734091283740918237409182734091283", 10)
+	require.True(t, ok)
+	require.False(t, isROCAVulnerable(notVulnerable))
+}
+
+func TestPGPKeyAuditReportWarnings(t *testing.T) {
+	report := PGPKeyAuditReport{MissingEncryptionSubkey: true}
+	fp := PGPFingerprint{0x01}
+	warnings := report.Warnings(&fp)
+	require.Len(t, warnings, 1)
+	require.Contains(t, warnings[0].String(), "no subkey flagged for encryption")
+}
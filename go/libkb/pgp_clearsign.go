@@ -0,0 +1,85 @@
+// Copyright 2015 Keybase, Inc. All rights reserved. Use of
+// this source code is governed by the included BSD license.
+
+package libkb
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+
+	"github.com/keybase/client/go/kbcrypto"
+	keybase1 "github.com/keybase/client/go/protocol/keybase1"
+	"github.com/keybase/go-crypto/openpgp"
+	"github.com/keybase/go-crypto/openpgp/clearsign"
+)
+
+// ClearSignToString produces an RFC 4880 §7 cleartext signature over msg:
+// msg's text is left readable in the output, framed by "-----BEGIN/END PGP
+// SIGNED MESSAGE-----" headers, with a detached signature appended below
+// it. Line-ending canonicalization and dash-escaping of the message body
+// are handled by the vendored go-crypto clearsign package itself, so this
+// never shells out to gpg.
+func (k *PGPKeyBundle) ClearSignToString(msg []byte) (string, error) {
+	if !k.HasSecretKey() {
+		return "", NoSecretKeyError{}
+	}
+
+	var buf bytes.Buffer
+	w, err := clearsign.Encode(&buf, k.PrivateKey, nil)
+	if err != nil {
+		return "", err
+	}
+	if _, err := w.Write(msg); err != nil {
+		return "", err
+	}
+	if err := w.Close(); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// VerifyClearSignAndExtract checks a cleartext-signed message, such as one
+// produced by ClearSignToString, against k and returns the original
+// message text. A clearsign block's signature is detached from the
+// message it covers rather than wrapping it, so unlike
+// VerifyStringAndExtract and VerifyAndExtract, this doesn't go through
+// PGPOpenSig/ParsedSig.
+func (k PGPKeyBundle) VerifyClearSignAndExtract(ctx VerifyContext, sig []byte) (msg []byte, id keybase1.SigIDBase, warnings HashSecurityWarnings, err error) {
+	block, _ := clearsign.Decode(sig)
+	if block == nil {
+		err = fmt.Errorf("unable to decode clearsigned message")
+		verifyFailure(ctx, VerifyFailureParseError, err.Error())
+		return nil, id, nil, err
+	}
+
+	sigBody, err := ioutil.ReadAll(block.ArmoredSignature.Body)
+	if err != nil {
+		verifyFailure(ctx, VerifyFailureParseError, err.Error())
+		return nil, id, nil, err
+	}
+
+	id = kbcrypto.ComputeSigIDFromSigBody(sigBody)
+	verifyAttempt(ctx, k.GetFingerprint().String(), id)
+
+	if _, err = openpgp.CheckDetachedSignature(k, bytes.NewReader(block.Bytes), bytes.NewReader(sigBody)); err != nil {
+		verifyFailure(ctx, classifyPGPVerifyFailure(err), err.Error())
+		return nil, id, nil, err
+	}
+
+	hashMethod, _, err := ExtractPGPSignatureHashMethod(k, sigBody)
+	if err != nil {
+		return nil, id, nil, err
+	}
+	if !IsHashSecure(hashMethod) {
+		warnings = append(warnings, NewHashSecurityWarning(HashSecurityWarningSignatureHash, hashMethod, nil))
+		if err = hashSecurityPolicyFromContext(ctx).checkVerifyHash(hashMethod); err != nil {
+			verifyFailure(ctx, VerifyFailureWeakDigest, err.Error())
+			return nil, id, warnings, err
+		}
+	}
+
+	msg = block.Bytes
+	verifyWarning(ctx, k.GetFingerprint().String(), id, warnings)
+	return msg, id, warnings, nil
+}
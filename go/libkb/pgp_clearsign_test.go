@@ -0,0 +1,74 @@
+// Copyright 2015 Keybase, Inc. All rights reserved. Use of
+// this source code is governed by the included BSD license.
+
+package libkb
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestClearSignRoundTrip(t *testing.T) {
+	tc := SetupTest(t, "clearsignroundtrip", 1)
+	defer tc.Cleanup()
+
+	bundle, err := tc.MakePGPKey("clearsigner@keybase.io")
+	require.NoError(t, err)
+
+	msg := []byte("line one\nline two\n-dash-prefixed line\n")
+	sig, err := bundle.ClearSignToString(msg)
+	require.NoError(t, err)
+	require.Contains(t, sig, "-----BEGIN PGP SIGNED MESSAGE-----")
+	require.Contains(t, sig, "-----BEGIN PGP SIGNATURE-----")
+	// dash-escaping is handled for us by the clearsign encoder.
+	require.Contains(t, sig, "- -dash-prefixed line")
+
+	extracted, _, warnings, err := bundle.VerifyClearSignAndExtract(tc.MetaContext(), []byte(sig))
+	require.NoError(t, err)
+	require.Empty(t, warnings)
+	require.Equal(t, strings.TrimRight(string(msg), "\n"), strings.TrimRight(string(extracted), "\n"))
+}
+
+func TestClearSignToStringNoSecretKey(t *testing.T) {
+	tc := SetupTest(t, "clearsignnosecretkey", 1)
+	defer tc.Cleanup()
+
+	bundle, err := tc.MakePGPKey("clearsignpublic@keybase.io")
+	require.NoError(t, err)
+
+	public, _, err := bundle.ExportPublicAndPrivate()
+	require.NoError(t, err)
+	publicBundle, _, err := ReadOneKeyFromBytes(public)
+	require.NoError(t, err)
+
+	_, err = publicBundle.ClearSignToString([]byte("hello"))
+	require.Equal(t, NoSecretKeyError{}, err)
+}
+
+func TestVerifyClearSignAndExtractTamperedBody(t *testing.T) {
+	tc := SetupTest(t, "verifyclearsigntampered", 1)
+	defer tc.Cleanup()
+
+	bundle, err := tc.MakePGPKey("clearsigntamper@keybase.io")
+	require.NoError(t, err)
+
+	sig, err := bundle.ClearSignToString([]byte("the original message"))
+	require.NoError(t, err)
+
+	tampered := strings.Replace(sig, "the original message", "a different message!", 1)
+	_, _, _, err = bundle.VerifyClearSignAndExtract(tc.MetaContext(), []byte(tampered))
+	require.Error(t, err)
+}
+
+func TestVerifyClearSignAndExtractGarbage(t *testing.T) {
+	tc := SetupTest(t, "verifyclearsigngarbage", 1)
+	defer tc.Cleanup()
+
+	bundle, err := tc.MakePGPKey("clearsigngarbage@keybase.io")
+	require.NoError(t, err)
+
+	_, _, _, err = bundle.VerifyClearSignAndExtract(tc.MetaContext(), []byte("not a clearsigned message"))
+	require.Error(t, err)
+}
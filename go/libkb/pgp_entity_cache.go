@@ -0,0 +1,93 @@
+// Copyright 2015 Keybase, Inc. All rights reserved. Use of
+// this source code is governed by the included BSD license.
+
+package libkb
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+	"sync/atomic"
+
+	lru "github.com/hashicorp/golang-lru"
+	"github.com/keybase/go-crypto/openpgp"
+)
+
+// PGPEntityCache caches the result of parsing an armored PGP key, keyed by
+// the SHA-256 hash of the cleaned (whitespace-normalized) armor, so that
+// identify and sigchain replay -- which tend to re-parse the same armored
+// public key many times over the life of a process -- pay
+// openpgp.ReadArmoredKeyRing's parse cost once per distinct key rather than
+// once per call.
+//
+// Only public keys are cached. A private key's *packet.PrivateKey is
+// decrypted in place, so sharing a cached private bundle across callers
+// would let one caller's successful decryption silently unlock the key for
+// every other holder of the cached entry. Rather than deep-copying secret
+// key material on every hit to avoid that, it's simplest and safest to
+// just not cache it.
+type PGPEntityCache struct {
+	Contextified
+	cache *lru.Cache
+
+	hits   int64
+	misses int64
+}
+
+func NewPGPEntityCache(g *GlobalContext, maxNumElements int) *PGPEntityCache {
+	c, err := lru.New(maxNumElements)
+	if err != nil {
+		g.Log.Warning("failed to create PGPEntityCache LRU: %s", err)
+		c = nil
+	}
+	return &PGPEntityCache{
+		Contextified: NewContextified(g),
+		cache:        c,
+	}
+}
+
+// pgpEntityCacheEntry holds a parse result, so that a cache hit reproduces
+// exactly what a fresh parse would have returned, error and all.
+type pgpEntityCacheEntry struct {
+	bundle   *PGPKeyBundle
+	warnings *Warnings
+	err      error
+}
+
+// GetOrParse returns the PGPKeyBundle for cleanArmor (the result of
+// cleanPGPInput, and optionally bug8612Prepass), parsing and caching it on
+// a miss. originalArmor is used only to populate a freshly-parsed bundle's
+// ArmoredPublicKey field, matching readOneKeyFromString.
+func (c *PGPEntityCache) GetOrParse(cleanArmor, originalArmor string) (*PGPKeyBundle, *Warnings, error) {
+	if c.cache == nil || strings.Contains(cleanArmor, "PRIVATE KEY BLOCK") {
+		return parsePGPEntity(cleanArmor, originalArmor)
+	}
+
+	sum := sha256.Sum256([]byte(cleanArmor))
+	key := hex.EncodeToString(sum[:])
+
+	if obj, ok := c.cache.Get(key); ok {
+		atomic.AddInt64(&c.hits, 1)
+		entry := obj.(*pgpEntityCacheEntry)
+		return entry.bundle, entry.warnings, entry.err
+	}
+	atomic.AddInt64(&c.misses, 1)
+
+	bundle, warnings, err := parsePGPEntity(cleanArmor, originalArmor)
+	c.cache.Add(key, &pgpEntityCacheEntry{bundle: bundle, warnings: warnings, err: err})
+	return bundle, warnings, err
+}
+
+func parsePGPEntity(cleanArmor, originalArmor string) (*PGPKeyBundle, *Warnings, error) {
+	el, err := openpgp.ReadArmoredKeyRing(strings.NewReader(cleanArmor))
+	return finishReadOne(el, originalArmor, err)
+}
+
+// Hits is the number of GetOrParse calls so far satisfied from the cache.
+func (c *PGPEntityCache) Hits() int64 { return atomic.LoadInt64(&c.hits) }
+
+// Misses is the number of GetOrParse calls so far that had to parse armor
+// not yet in the cache. Calls with a private key (see the PGPEntityCache doc
+// comment), or with the cache disabled, are counted neither as hits nor as
+// misses.
+func (c *PGPEntityCache) Misses() int64 { return atomic.LoadInt64(&c.misses) }
@@ -0,0 +1,79 @@
+// Copyright 2015 Keybase, Inc. All rights reserved. Use of
+// this source code is governed by the included BSD license.
+
+package libkb
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPGPEntityCacheHitsAndMisses(t *testing.T) {
+	tc := SetupTest(t, "pgpentitycache", 1)
+	defer tc.Cleanup()
+
+	bundle, err := tc.MakePGPKey("pgpentitycache@keybase.io")
+	require.NoError(t, err)
+	armored, err := bundle.Encode()
+	require.NoError(t, err)
+
+	cache := NewPGPEntityCache(tc.G, 10)
+	require.EqualValues(t, 0, cache.Hits())
+	require.EqualValues(t, 0, cache.Misses())
+
+	clean := cleanPGPInput(armored)
+
+	first, _, err := cache.GetOrParse(clean, armored)
+	require.NoError(t, err)
+	require.EqualValues(t, 0, cache.Hits())
+	require.EqualValues(t, 1, cache.Misses())
+
+	second, _, err := cache.GetOrParse(clean, armored)
+	require.NoError(t, err)
+	require.EqualValues(t, 1, cache.Hits())
+	require.EqualValues(t, 1, cache.Misses())
+
+	require.True(t, first == second, "a cache hit should return the same bundle pointer as the original parse")
+	require.Equal(t, bundle.GetFingerprint(), second.GetFingerprint())
+}
+
+func TestPGPEntityCacheSkipsPrivateKeys(t *testing.T) {
+	tc := SetupTest(t, "pgpentitycache", 1)
+	defer tc.Cleanup()
+
+	bundle, err := tc.MakePGPKey("pgpentitycacheprivate@keybase.io")
+	require.NoError(t, err)
+	armoredBytes, err := bundle.ToArmoredBytes(true)
+	require.NoError(t, err)
+	armored := string(armoredBytes)
+
+	cache := NewPGPEntityCache(tc.G, 10)
+
+	_, _, err = cache.GetOrParse(cleanPGPInput(armored), armored)
+	require.NoError(t, err)
+	_, _, err = cache.GetOrParse(cleanPGPInput(armored), armored)
+	require.NoError(t, err)
+
+	require.EqualValues(t, 0, cache.Hits(), "private keys should never be served from the cache")
+	require.EqualValues(t, 0, cache.Misses(), "private keys should never be counted as cache misses either")
+}
+
+func TestPGPEntityCacheDisabled(t *testing.T) {
+	tc := SetupTest(t, "pgpentitycache", 1)
+	defer tc.Cleanup()
+
+	bundle, err := tc.MakePGPKey("pgpentitycachedisabled@keybase.io")
+	require.NoError(t, err)
+	armored, err := bundle.Encode()
+	require.NoError(t, err)
+
+	cache := NewPGPEntityCache(tc.G, 10)
+	cache.cache = nil
+
+	parsed, _, err := cache.GetOrParse(cleanPGPInput(armored), armored)
+	require.NoError(t, err)
+	require.Equal(t, bundle.GetFingerprint(), parsed.GetFingerprint())
+	require.EqualValues(t, 0, cache.Hits())
+	require.EqualValues(t, 0, cache.Misses())
+}
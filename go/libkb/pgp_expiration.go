@@ -0,0 +1,50 @@
+// Copyright 2015 Keybase, Inc. All rights reserved. Use of
+// this source code is governed by the included BSD license.
+
+package libkb
+
+import "time"
+
+// ExtendExpiration pushes k's expiration out to duration from now: every
+// identity self-signature and subkey binding signature gets a fresh
+// CreationTime of now and a KeyLifetimeSecs covering duration, and k is
+// marked so those signatures are recomputed -- rather than reused -- the
+// next time it's serialized. k must hold its primary private key, since
+// recomputing a signature requires signing with it.
+//
+// This is the in-process equivalent of editing a key's expiration date in
+// GPG and re-importing it, minus the round trip: it returns the updated
+// bundle's newly armored public key directly, and updates
+// k.ArmoredPublicKey to match.
+func (k *PGPKeyBundle) ExtendExpiration(duration time.Duration) (armored string, err error) {
+	if !k.HasSecretKey() {
+		return "", NoSecretKeyError{}
+	}
+
+	now := time.Now()
+	lifetime := ui32p(int(duration / time.Second))
+
+	for _, identity := range k.Identities {
+		if identity.SelfSignature == nil {
+			continue
+		}
+		identity.SelfSignature.CreationTime = now
+		identity.SelfSignature.KeyLifetimeSecs = lifetime
+	}
+	for i := range k.Subkeys {
+		if k.Subkeys[i].Sig == nil {
+			continue
+		}
+		k.Subkeys[i].Sig.CreationTime = now
+		k.Subkeys[i].Sig.KeyLifetimeSecs = lifetime
+	}
+
+	k.Generated = true
+	k.ArmoredPublicKey = ""
+
+	out, err := k.ToArmoredBytes(false)
+	if err != nil {
+		return "", err
+	}
+	return string(out), nil
+}
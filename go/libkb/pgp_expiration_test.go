@@ -0,0 +1,57 @@
+// Copyright 2015 Keybase, Inc. All rights reserved. Use of
+// this source code is governed by the included BSD license.
+
+package libkb
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestExtendExpiration(t *testing.T) {
+	tc := SetupTest(t, "extendexpiration", 1)
+	defer tc.Cleanup()
+
+	bundle, err := tc.MakePGPKey("extendexpiration@keybase.io")
+	require.NoError(t, err)
+	require.False(t, bundle.IsExpired())
+
+	subkeySig := bundle.Subkeys[0].Sig
+
+	armored, err := bundle.ExtendExpiration(24 * time.Hour)
+	require.NoError(t, err)
+	require.Contains(t, armored, "-----BEGIN PGP PUBLIC KEY BLOCK-----")
+	require.Equal(t, armored, bundle.ArmoredPublicKey)
+	require.False(t, bundle.IsExpired())
+
+	expiration := bundle.expirationTime()
+	require.NotNil(t, expiration)
+	require.WithinDuration(t, time.Now().Add(24*time.Hour), *expiration, time.Minute)
+	require.NotNil(t, subkeySig.KeyLifetimeSecs)
+	require.Equal(t, uint32(24*time.Hour/time.Second), *subkeySig.KeyLifetimeSecs)
+
+	reimported, _, err := ReadOneKeyFromString(armored)
+	require.NoError(t, err)
+	expiration = reimported.expirationTime()
+	require.NotNil(t, expiration)
+	require.WithinDuration(t, time.Now().Add(24*time.Hour), *expiration, time.Minute)
+}
+
+func TestExtendExpirationRequiresSecretKey(t *testing.T) {
+	tc := SetupTest(t, "extendexpirationpublic", 1)
+	defer tc.Cleanup()
+
+	bundle, err := tc.MakePGPKey("extendexpirationpublic@keybase.io")
+	require.NoError(t, err)
+
+	public, _, err := bundle.ExportPublicAndPrivate()
+	require.NoError(t, err)
+	publicBundle, _, err := ReadOneKeyFromBytes(public)
+	require.NoError(t, err)
+
+	_, err = publicBundle.ExtendExpiration(24 * time.Hour)
+	require.Error(t, err)
+	require.IsType(t, NoSecretKeyError{}, err)
+}
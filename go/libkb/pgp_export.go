@@ -0,0 +1,69 @@
+// Copyright 2015 Keybase, Inc. All rights reserved. Use of
+// this source code is governed by the included BSD license.
+
+package libkb
+
+import (
+	"crypto/ed25519"
+	"crypto/rsa"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	gocryptoed25519 "github.com/keybase/go-crypto/ed25519"
+	"github.com/keybase/go-crypto/openpgp/packet"
+	"golang.org/x/crypto/ssh"
+)
+
+// ExportSSHAuthorizedKey renders k's primary key as a single
+// authorized_keys-style line -- algorithm, base64 RFC 4253 public key blob,
+// and a trailing comment -- the same shape `ssh-keygen -i -m PKCS8` would
+// produce for an imported key, so it can be pasted straight into
+// ~/.ssh/authorized_keys. Only RSA and Ed25519 are supported, since those
+// are the only primary key algorithms PGPGenArg can generate; any other
+// OpenPGP public-key algorithm (DSA, ECDSA, ElGamal) returns an error, as
+// OpenSSH's authorized_keys format has no representation for it here.
+func (k *PGPKeyBundle) ExportSSHAuthorizedKey() (string, error) {
+	sshPub, err := sshPublicKeyFromPGP(k.PrimaryKey)
+	if err != nil {
+		return "", err
+	}
+
+	comment := k.GetFingerprint().String()
+	if uids := k.IdentityNames(); len(uids) > 0 {
+		comment = uids[0]
+	}
+
+	line := strings.TrimRight(string(ssh.MarshalAuthorizedKey(sshPub)), "\n")
+	return line + " " + comment, nil
+}
+
+// sshPublicKeyFromPGP converts an OpenPGP public key's underlying key
+// material to the crypto.PublicKey ssh.NewPublicKey wants.
+func sshPublicKeyFromPGP(pub *packet.PublicKey) (ssh.PublicKey, error) {
+	switch key := pub.PublicKey.(type) {
+	case *rsa.PublicKey:
+		return ssh.NewPublicKey(key)
+	case gocryptoed25519.PublicKey:
+		return ssh.NewPublicKey(ed25519.PublicKey(key))
+	default:
+		return nil, fmt.Errorf("ExportSSHAuthorizedKey: unsupported PGP public key algorithm %v", pub.PubKeyAlgo)
+	}
+}
+
+// WriteKeybox is meant to serialize k directly into a GnuPG keybox (.kbx)
+// container -- the length-prefixed, checksummed sequence of header and
+// OpenPGP key blobs GnuPG uses for pubring.kbx -- so a Keybase PGP key could
+// be dropped straight into a GnuPG homedir without going through `gpg
+// --import` at all. It isn't implemented: that's a fiddly binary format
+// (see GnuPG's kbx/keybox-blob.c for the field layout) with no test fixture
+// or real `gpg` binary available here to round-trip against, and a
+// hand-rolled writer that's subtly wrong produces a keybox GnuPG silently
+// refuses to open rather than a loud, debuggable failure. Until it's been
+// verified against a real GnuPG install, `keybase pgp export` followed by
+// `gpg --import` -- which does the keybox conversion GnuPG's own,
+// already-correct way -- remains the supported path.
+func (k *PGPKeyBundle) WriteKeybox(w io.Writer) error {
+	return errors.New("WriteKeybox: GnuPG keybox export is not yet implemented; use `keybase pgp export | gpg --import` instead")
+}
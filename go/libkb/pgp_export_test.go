@@ -0,0 +1,49 @@
+// Copyright 2015 Keybase, Inc. All rights reserved. Use of
+// this source code is governed by the included BSD license.
+
+package libkb
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestExportSSHAuthorizedKeyRSA(t *testing.T) {
+	tc := SetupTest(t, "exportsshrsa", 1)
+	defer tc.Cleanup()
+
+	bundle, err := tc.MakePGPKey("sshexport@keybase.io")
+	require.NoError(t, err)
+
+	line, err := bundle.ExportSSHAuthorizedKey()
+	require.NoError(t, err)
+	require.True(t, strings.HasPrefix(line, "ssh-rsa "))
+	require.Contains(t, line, "sshexport@keybase.io")
+}
+
+func TestExportSSHAuthorizedKeyEdDSA(t *testing.T) {
+	tc := SetupTest(t, "exportsshed25519", 1)
+	defer tc.Cleanup()
+
+	bundle, err := tc.MakeEdDSAPGPKey("sshexported25519@keybase.io")
+	require.NoError(t, err)
+
+	line, err := bundle.ExportSSHAuthorizedKey()
+	require.NoError(t, err)
+	require.True(t, strings.HasPrefix(line, "ssh-ed25519 "))
+	require.Contains(t, line, "sshexported25519@keybase.io")
+}
+
+func TestWriteKeyboxNotImplemented(t *testing.T) {
+	tc := SetupTest(t, "writekeybox", 1)
+	defer tc.Cleanup()
+
+	bundle, err := tc.MakePGPKey("kbxexport@keybase.io")
+	require.NoError(t, err)
+
+	var buf strings.Builder
+	err = bundle.WriteKeybox(&buf)
+	require.Error(t, err)
+}
@@ -7,8 +7,12 @@ import (
 	"crypto"
 	"fmt"
 	"strings"
+	"time"
 
+	"github.com/keybase/go-crypto/curve25519"
+	"github.com/keybase/go-crypto/ed25519"
 	"github.com/keybase/go-crypto/openpgp"
+	"github.com/keybase/go-crypto/openpgp/ecdh"
 	"github.com/keybase/go-crypto/openpgp/errors"
 	"github.com/keybase/go-crypto/openpgp/packet"
 	"github.com/keybase/go-crypto/openpgp/s2k"
@@ -23,6 +27,12 @@ type PGPGenArg struct {
 	PGPUids         []string
 	PrimaryLifetime int
 	SubkeyLifetime  int
+
+	// Eddsa, if set, generates an Ed25519 signing key with a Curve25519
+	// ECDH encryption subkey instead of the RSA/RSA default. PrimaryBits
+	// and SubkeyBits are ignored in this mode, since both curves have a
+	// fixed size.
+	Eddsa bool
 }
 
 func ui32p(i int) *uint32 {
@@ -33,13 +43,45 @@ func ui32p(i int) *uint32 {
 	return nil
 }
 
+// generateEncryptionSubkey generates a fresh, unbound encryption subkey to
+// go alongside a primary key of the given algorithm: a Curve25519 ECDH
+// subkey for an EdDSA primary, or an RSA subkey (sized per bits) for an
+// RSA primary. Callers are responsible for binding the subkey to a
+// primary key with a subkey-binding signature. Used both by
+// GeneratePGPKeyBundle and PGPKeyBundle.RotateSubkey.
+func generateEncryptionSubkey(primaryAlgo packet.PublicKeyAlgorithm, bits int, currentTime time.Time, config *packet.Config) (openpgp.Subkey, error) {
+	if primaryAlgo == packet.PubKeyAlgoEdDSA {
+		priv, err := ecdh.GenerateKey(curve25519.Cv25519(), config.Random())
+		if err != nil {
+			return openpgp.Subkey{}, err
+		}
+		return openpgp.Subkey{
+			PublicKey:  packet.NewECDHPublicKey(currentTime, &priv.PublicKey),
+			PrivateKey: packet.NewECDHPrivateKey(currentTime, priv),
+		}, nil
+	}
+
+	priv, err := rsa.GenerateKey(config.Random(), bits)
+	if err != nil {
+		return openpgp.Subkey{}, err
+	}
+	return openpgp.Subkey{
+		PublicKey:  packet.NewRSAPublicKey(currentTime, &priv.PublicKey),
+		PrivateKey: packet.NewRSAPrivateKey(currentTime, priv),
+	}, nil
+}
+
 // NewEntity returns an Entity that contains a fresh RSA/RSA keypair with a
 // single identity composed of the given full name, comment and email, any of
 // which may be empty but must not contain any of "()<>\x00".
 // If config is nil, sensible defaults will be used.
 //
 // Modification of: https://code.google.com/p/go/source/browse/openpgp/keys.go?repo=crypto&r=8fec09c61d5d66f460d227fd1df3473d7e015bc6#456
-//  From golang.com/x/crypto/openpgp/keys.go
+//
+//	From golang.com/x/crypto/openpgp/keys.go
+//
+// With arg.Eddsa set, the keypair is an Ed25519 primary key with a
+// Curve25519 ECDH subkey instead of RSA/RSA.
 func GeneratePGPKeyBundle(g *GlobalContext, arg PGPGenArg, logUI LogUI) (*PGPKeyBundle, error) {
 	currentTime := arg.Config.Now()
 
@@ -60,27 +102,49 @@ func GeneratePGPKeyBundle(g *GlobalContext, arg PGPGenArg, logUI LogUI) (*PGPKey
 		}
 	}
 
-	if logUI != nil {
-		logUI.Info("Generating primary key (%d bits)", arg.PrimaryBits)
-	}
-	masterPriv, err := rsa.GenerateKey(arg.Config.Random(), arg.PrimaryBits)
-	if err != nil {
-		return nil, err
+	var e *openpgp.Entity
+	var primaryAlgo packet.PublicKeyAlgorithm
+	if arg.Eddsa {
+		if logUI != nil {
+			logUI.Info("Generating Ed25519 primary key")
+		}
+		edPub, edPriv, err := ed25519.GenerateKey(arg.Config.Random())
+		if err != nil {
+			return nil, err
+		}
+
+		e = &openpgp.Entity{
+			PrimaryKey: packet.NewEdDSAPublicKey(currentTime, edPub),
+			PrivateKey: packet.NewEdDSAPrivateKey(currentTime, edPriv),
+			Identities: make(map[string]*openpgp.Identity),
+		}
+		primaryAlgo = packet.PubKeyAlgoEdDSA
+	} else {
+		if logUI != nil {
+			logUI.Info("Generating primary key (%d bits)", arg.PrimaryBits)
+		}
+		masterPriv, err := rsa.GenerateKey(arg.Config.Random(), arg.PrimaryBits)
+		if err != nil {
+			return nil, err
+		}
+
+		e = &openpgp.Entity{
+			PrimaryKey: packet.NewRSAPublicKey(currentTime, &masterPriv.PublicKey),
+			PrivateKey: packet.NewRSAPrivateKey(currentTime, masterPriv),
+			Identities: make(map[string]*openpgp.Identity),
+		}
+		primaryAlgo = packet.PubKeyAlgoRSA
 	}
 
 	if logUI != nil {
-		logUI.Info("Generating encryption subkey (%d bits)", arg.SubkeyBits)
+		logUI.Info("Generating encryption subkey")
 	}
-	encryptingPriv, err := rsa.GenerateKey(arg.Config.Random(), arg.SubkeyBits)
+	subkey, err := generateEncryptionSubkey(primaryAlgo, arg.SubkeyBits, currentTime, arg.Config)
 	if err != nil {
 		return nil, err
 	}
-
-	e := &openpgp.Entity{
-		PrimaryKey: packet.NewRSAPublicKey(currentTime, &masterPriv.PublicKey),
-		PrivateKey: packet.NewRSAPrivateKey(currentTime, masterPriv),
-		Identities: make(map[string]*openpgp.Identity),
-	}
+	e.Subkeys = []openpgp.Subkey{subkey}
+	subkeyAlgo := subkey.PublicKey.PubKeyAlgo
 
 	for i, uid := range uids {
 		isPrimaryID := true
@@ -93,7 +157,7 @@ func GeneratePGPKeyBundle(g *GlobalContext, arg PGPGenArg, logUI LogUI) (*PGPKey
 			SelfSignature: &packet.Signature{
 				CreationTime:         currentTime,
 				SigType:              packet.SigTypePositiveCert,
-				PubKeyAlgo:           packet.PubKeyAlgoRSA,
+				PubKeyAlgo:           primaryAlgo,
 				Hash:                 arg.Config.Hash(),
 				IsPrimaryId:          &isPrimaryID,
 				FlagsValid:           true,
@@ -109,23 +173,18 @@ func GeneratePGPKeyBundle(g *GlobalContext, arg PGPGenArg, logUI LogUI) (*PGPKey
 		e.Identities[uid.Id] = id
 	}
 
-	e.Subkeys = make([]openpgp.Subkey, 1)
-	e.Subkeys[0] = openpgp.Subkey{
-		PublicKey:  packet.NewRSAPublicKey(currentTime, &encryptingPriv.PublicKey),
-		PrivateKey: packet.NewRSAPrivateKey(currentTime, encryptingPriv),
-		Sig: &packet.Signature{
-			CreationTime:              currentTime,
-			SigType:                   packet.SigTypeSubkeyBinding,
-			PubKeyAlgo:                packet.PubKeyAlgoRSA,
-			Hash:                      arg.Config.Hash(),
-			FlagsValid:                true,
-			FlagEncryptStorage:        true,
-			FlagEncryptCommunications: true,
-			IssuerKeyId:               &e.PrimaryKey.KeyId,
-			PreferredSymmetric:        arg.PreferredSymmetric(),
-			PreferredHash:             arg.PreferredHash(),
-			PreferredCompression:      arg.PreferredCompression(),
-		},
+	e.Subkeys[0].Sig = &packet.Signature{
+		CreationTime:              currentTime,
+		SigType:                   packet.SigTypeSubkeyBinding,
+		PubKeyAlgo:                subkeyAlgo,
+		Hash:                      arg.Config.Hash(),
+		FlagsValid:                true,
+		FlagEncryptStorage:        true,
+		FlagEncryptCommunications: true,
+		IssuerKeyId:               &e.PrimaryKey.KeyId,
+		PreferredSymmetric:        arg.PreferredSymmetric(),
+		PreferredHash:             arg.PreferredHash(),
+		PreferredCompression:      arg.PreferredCompression(),
 	}
 	e.Subkeys[0].PublicKey.IsSubkey = true
 	e.Subkeys[0].PrivateKey.IsSubkey = true
@@ -172,6 +231,9 @@ func (a *PGPGenArg) MakeAllIds(g *GlobalContext) error {
 func (a *PGPGenArg) PGPUserIDs() ([]*packet.UserId, error) {
 	uids := make([]*packet.UserId, len(a.Ids))
 	for i, id := range a.Ids {
+		if err := id.Validate(); err != nil {
+			return nil, fmt.Errorf("Id[%d]: %s", i, err)
+		}
 		uids[i] = id.ToPGPUserID()
 		if uids[i] == nil {
 			return nil, fmt.Errorf("Id[%d] failed to convert to PGPUserId (%+v)", i, id)
@@ -197,7 +259,13 @@ func (a *PGPGenArg) Init() (err error) {
 	return
 }
 
-func (a *PGPGenArg) PreferredSymmetric() []uint8 {
+func (a *PGPGenArg) PreferredSymmetric() []uint8 { return defaultPreferredSymmetric() }
+
+func (a *PGPGenArg) PreferredHash() []uint8 { return defaultPreferredHash() }
+
+func (a *PGPGenArg) PreferredCompression() []uint8 { return defaultPreferredCompression() }
+
+func defaultPreferredSymmetric() []uint8 {
 	return []uint8{
 		uint8(packet.CipherAES128),
 		uint8(packet.CipherAES256),
@@ -205,7 +273,7 @@ func (a *PGPGenArg) PreferredSymmetric() []uint8 {
 	}
 }
 
-func (a *PGPGenArg) PreferredHash() []uint8 {
+func defaultPreferredHash() []uint8 {
 	gohash := []crypto.Hash{
 		crypto.SHA256,
 		crypto.SHA512,
@@ -223,7 +291,7 @@ func (a *PGPGenArg) PreferredHash() []uint8 {
 	return res
 }
 
-func (a *PGPGenArg) PreferredCompression() []uint8 {
+func defaultPreferredCompression() []uint8 {
 	return []uint8{
 		uint8(packet.CompressionNone),
 		uint8(packet.CompressionZIP),
@@ -0,0 +1,86 @@
+// Copyright 2015 Keybase, Inc. All rights reserved. Use of
+// this source code is governed by the included BSD license.
+
+package libkb
+
+import (
+	"crypto"
+	"fmt"
+)
+
+// HashSecurityPolicy controls how SimpleSign and ParsedSig.Verify react to
+// a cryptographically weak digest algorithm (today, just SHA-1). The zero
+// value, DefaultHashSecurityPolicy, is this package's long-standing
+// behavior: sign with go-crypto's own default (SHA-256), and treat an
+// existing weak signature -- typically an old self-sig -- as a
+// HashSecurityWarning rather than a hard failure.
+type HashSecurityPolicy struct {
+	// RefuseWeakSigning makes SimpleSign fail with a WeakHashError instead
+	// of producing a signature hashed with an algorithm IsHashSecure
+	// considers weak.
+	RefuseWeakSigning bool
+
+	// RefuseWeakVerify makes Verify fail a signature hashed with a weak
+	// algorithm -- classified as VerifyFailureWeakDigest -- instead of
+	// merely recording a HashSecurityWarning about it.
+	RefuseWeakVerify bool
+}
+
+// DefaultHashSecurityPolicy is used by every SimpleSign/Verify call site
+// that hasn't opted into stricter enforcement.
+var DefaultHashSecurityPolicy = HashSecurityPolicy{}
+
+// GetHashSecurityPolicy derives the policy this installation has configured
+// via config/env (see Env.GetPGPRefuseWeakSigning and
+// Env.GetPGPRefuseWeakVerify), for call sites that have a MetaContext handy
+// rather than a policy of their own.
+func GetHashSecurityPolicy(m MetaContext) HashSecurityPolicy {
+	return HashSecurityPolicy{
+		RefuseWeakSigning: m.G().Env.GetPGPRefuseWeakSigning(),
+		RefuseWeakVerify:  m.G().Env.GetPGPRefuseWeakVerify(),
+	}
+}
+
+// hashSecurityPolicyFromContext derives a HashSecurityPolicy from ctx when
+// it's the MetaContext that virtually every VerifyContext in this codebase
+// actually is, the same way verifyAttempt/verifyFailure/verifyWarning reach
+// for the optional VerifyContextHooks methods -- so VerifyStringAndExtractWithWarnings
+// and VerifyAndExtract pick up config/env-configured enforcement without
+// needing a policy threaded through their own signatures. Anything else
+// (a bare-Debug VerifyContext, as some tests use) gets today's permissive
+// default.
+func hashSecurityPolicyFromContext(ctx VerifyContext) HashSecurityPolicy {
+	if mctx, ok := ctx.(MetaContext); ok {
+		return GetHashSecurityPolicy(mctx)
+	}
+	return DefaultHashSecurityPolicy
+}
+
+// checkSigningHash returns a WeakHashError if p.RefuseWeakSigning and hash
+// is one IsHashSecure considers weak, or nil otherwise.
+func (p HashSecurityPolicy) checkSigningHash(hash crypto.Hash) error {
+	if !p.RefuseWeakSigning || IsHashSecure(hash) {
+		return nil
+	}
+	return WeakHashError{Op: "sign", Hash: hash}
+}
+
+// checkVerifyHash returns a WeakHashError if p.RefuseWeakVerify and hash is
+// one IsHashSecure considers weak, or nil otherwise.
+func (p HashSecurityPolicy) checkVerifyHash(hash crypto.Hash) error {
+	if !p.RefuseWeakVerify || IsHashSecure(hash) {
+		return nil
+	}
+	return WeakHashError{Op: "verify", Hash: hash}
+}
+
+// WeakHashError is returned when a HashSecurityPolicy refuses to sign or
+// accept a cryptographically weak digest algorithm.
+type WeakHashError struct {
+	Op   string
+	Hash crypto.Hash
+}
+
+func (e WeakHashError) Error() string {
+	return fmt.Sprintf("refusing to %s using weak hash algorithm %s", e.Op, e.Hash)
+}
@@ -0,0 +1,37 @@
+package libkb
+
+import (
+	"crypto"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestHashSecurityPolicyDefaultIsPermissive(t *testing.T) {
+	require.NoError(t, DefaultHashSecurityPolicy.checkSigningHash(crypto.SHA1))
+	require.NoError(t, DefaultHashSecurityPolicy.checkVerifyHash(crypto.SHA1))
+}
+
+func TestHashSecurityPolicyRefuseWeakSigning(t *testing.T) {
+	policy := HashSecurityPolicy{RefuseWeakSigning: true}
+	require.NoError(t, policy.checkSigningHash(crypto.SHA256), "a secure hash is never refused")
+
+	err := policy.checkSigningHash(crypto.SHA1)
+	require.Error(t, err)
+	require.IsType(t, WeakHashError{}, err)
+	require.Contains(t, err.Error(), "sign")
+}
+
+func TestHashSecurityPolicyRefuseWeakVerify(t *testing.T) {
+	policy := HashSecurityPolicy{RefuseWeakVerify: true}
+	require.NoError(t, policy.checkVerifyHash(crypto.SHA256), "a secure hash is never refused")
+
+	err := policy.checkVerifyHash(crypto.SHA1)
+	require.Error(t, err)
+	require.IsType(t, WeakHashError{}, err)
+	require.Contains(t, err.Error(), "verify")
+}
+
+func TestClassifyPGPVerifyFailureWeakDigest(t *testing.T) {
+	require.Equal(t, VerifyFailureWeakDigest, classifyPGPVerifyFailure(WeakHashError{Op: "verify", Hash: crypto.SHA1}))
+}
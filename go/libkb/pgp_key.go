@@ -6,21 +6,40 @@ package libkb
 import (
 	"bufio"
 	"bytes"
+	"crypto"
+	"crypto/dsa"
+	"crypto/ecdsa"
+	"crypto/rsa"
 	"crypto/sha256"
 	"encoding/hex"
 	"errors"
 	"fmt"
+	"hash"
 	"io"
+	"io/ioutil"
+	"math/big"
 	"regexp"
+	"runtime"
+	"sort"
 	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
 
 	"github.com/keybase/client/go/kbcrypto"
 	keybase1 "github.com/keybase/client/go/protocol/keybase1"
+	"github.com/keybase/go-crypto/ed25519"
 	"github.com/keybase/go-crypto/openpgp"
 	"github.com/keybase/go-crypto/openpgp/armor"
+	"github.com/keybase/go-crypto/openpgp/ecdh"
+	"github.com/keybase/go-crypto/openpgp/elgamal"
+	pgperrors "github.com/keybase/go-crypto/openpgp/errors"
 	"github.com/keybase/go-crypto/openpgp/packet"
 	jsonw "github.com/keybase/go-jsonw"
 	_ "golang.org/x/crypto/ripemd160" // imported so that keybase/go-crypto/openpgp supports ripemd160
+	"golang.org/x/net/context"
+	"golang.org/x/net/idna"
+	"golang.org/x/sync/errgroup"
 )
 
 var _ GenericKey = (*PGPKeyBundle)(nil)
@@ -31,10 +50,11 @@ type PGPKeyBundle struct {
 	// GPGFallbackKey to be used as a fallback if given dummy a PrivateKey.
 	GPGFallbackKey GenericKey
 
-	// We make the (fairly dangerous) assumption that the key will never be
-	// modified. This avoids the issue that encoding an openpgp.Entity is
-	// nondeterministic due to Go's randomized iteration order (so different
-	// exports of the same key may hash differently).
+	// Cached armored export of the public key, keyed to the current state of
+	// Entity. Any mutator that changes what Entity would serialize to -- a
+	// new self-signature, a stripped revocation, and so on -- must call
+	// Invalidate() so the next Encode()/ToArmoredBytes() call regenerates it
+	// instead of handing back a stale copy.
 	//
 	// If you're *sure* that you're creating a PGPKeyBundle from an armored
 	// *public* key, you can prefill this field and Export() will use it.
@@ -42,6 +62,27 @@ type PGPKeyBundle struct {
 
 	// True if this key was generated by this program
 	Generated bool
+
+	// provenance records where this bundle was imported from, for
+	// debugging "where did this key come from" issues. It's metadata
+	// about the import, not part of the key, and is never serialized
+	// into the armored output. See ImportArmoredWithProvenance.
+	provenance *PGPKeyProvenance
+}
+
+// PGPKeyProvenance records the circumstances of a PGPKeyBundle import: where
+// the armored text came from, when it was fetched, and whether the liberal
+// (bug8612) parsing path was needed to read it.
+type PGPKeyProvenance struct {
+	Source    string
+	FetchTime time.Time
+	Liberal   bool
+}
+
+// Provenance returns where k was imported from, or nil if k wasn't created
+// with ImportArmoredWithProvenance.
+func (k *PGPKeyBundle) Provenance() *PGPKeyProvenance {
+	return k.provenance
 }
 
 func NewPGPKeyBundle(entity *openpgp.Entity) *PGPKeyBundle {
@@ -49,13 +90,34 @@ func NewPGPKeyBundle(entity *openpgp.Entity) *PGPKeyBundle {
 }
 
 func NewGeneratedPGPKeyBundle(entity *openpgp.Entity) *PGPKeyBundle {
-	return &PGPKeyBundle{Entity: entity, Generated: true}
+	k := &PGPKeyBundle{Entity: entity, Generated: true}
+	// Populate the ArmoredPublicKey cache now, while the entity is exactly
+	// as keygen produced it, so repeated Encode calls are stable -- see the
+	// comment on ArmoredPublicKey. Errors are ignored here; they'll surface
+	// normally the next time something calls Encode.
+	_, _ = k.ToArmoredBytes(false)
+	return k
 }
 
 const (
 	PGPFingerprintLen = 20
+
+	// pgpFingerprintV5Len is the length in bytes of an OpenPGP v5
+	// (SHA-256) fingerprint, as opposed to the v4 (SHA-1) ones
+	// PGPFingerprint holds. It's used only to recognize v5 input well
+	// enough to reject it with a clear error -- see the PGPFingerprint
+	// doc comment for why v5 itself isn't supported yet.
+	pgpFingerprintV5Len = 32
 )
 
+// PGPFingerprint holds a v4 (SHA-1, 20-byte) OpenPGP key fingerprint, the
+// only version the vendored OpenPGP packet library can parse:
+// packet.PublicKey.parse rejects any key packet whose version byte isn't 4.
+// Until that vendored library gains v5 (SHA-256, 32-byte) key support,
+// there's no v5 key material anywhere in this package for a variable-length
+// fingerprint to describe, so PGPFingerprint stays a fixed [20]byte array
+// rather than becoming one -- FromHex/FromSlice below at least give a
+// specific error for v5-sized input instead of a generic length mismatch.
 type PGPFingerprint [PGPFingerprintLen]byte
 
 func ImportPGPFingerprint(f keybase1.PGPFingerprint) PGPFingerprint {
@@ -71,6 +133,9 @@ func PGPFingerprintFromHex(s string) (*PGPFingerprint, error) {
 	case nil:
 		return &fp, nil
 	case HexWrongLengthError:
+		if len(s) == pgpFingerprintV5Len*2 {
+			return nil, fmt.Errorf("Bad fingerprint; OpenPGP v5 (32-byte) fingerprints are not supported")
+		}
 		return nil, fmt.Errorf("Bad fingerprint; wrong length: %d", len(s))
 	default:
 		return nil, err
@@ -79,6 +144,9 @@ func PGPFingerprintFromHex(s string) (*PGPFingerprint, error) {
 
 func PGPFingerprintFromSlice(b []byte) (*PGPFingerprint, error) {
 	if len(b) != PGPFingerprintLen {
+		if len(b) == pgpFingerprintV5Len {
+			return nil, fmt.Errorf("Bad fingerprint; OpenPGP v5 (32-byte) fingerprints are not supported")
+		}
 		return nil, fmt.Errorf("Bad fingerprint; wrong length: %d", PGPFingerprintLen)
 	}
 	var fp PGPFingerprint
@@ -100,20 +168,76 @@ func (p PGPFingerprint) String() string {
 	return hex.EncodeToString(p[:])
 }
 
-func (p PGPFingerprint) ToQuads() string {
-	x := []byte(strings.ToUpper(p.String()))
-	totlen := len(x)*5/4 - 1
-	ret := make([]byte, totlen)
-	j := 0
-	for i, b := range x {
-		ret[j] = b
-		j++
-		if (i%4) == 3 && j < totlen {
-			ret[j] = ' '
-			j++
+// FingerprintFormatOptions controls how FormatFingerprint groups and cases
+// a fingerprint's hex digits.
+type FingerprintFormatOptions struct {
+	// Lowercase renders hex digits in lowercase instead of the default
+	// uppercase.
+	Lowercase bool
+
+	// GroupBytes sets how many raw fingerprint bytes (2 hex digits each) go
+	// in a group before a separator is inserted. 0 (the zero value) falls
+	// back to ToQuads' traditional 2-byte groups.
+	GroupBytes int
+
+	// Separator is written between groups. Defaults to a single space.
+	Separator string
+}
+
+// TerminalFingerprintFormat is FormatFingerprint's zero-value grouping
+// (uppercase, 2-byte groups, space-separated) under a name that documents
+// the guarantee that matters for displaying it in identify output: every
+// character is a plain ASCII hex digit or space, so there's nothing a dumb
+// terminal could misrender and nothing (like a zero-width joiner) that
+// could be hidden inside it.
+var TerminalFingerprintFormat = FingerprintFormatOptions{}
+
+// GnuPGFingerprintFormat matches `gpg --with-colons`' fingerprint
+// rendering: lowercase hex digits, grouped by single byte, colon-separated.
+var GnuPGFingerprintFormat = FingerprintFormatOptions{
+	Lowercase:  true,
+	GroupBytes: 1,
+	Separator:  ":",
+}
+
+// FormatFingerprint renders p's fingerprint grouped and cased according to
+// opts. The zero value of FingerprintFormatOptions reproduces ToQuads:
+// uppercase hex digits in 2-byte groups, space-separated.
+func (p PGPFingerprint) FormatFingerprint(opts FingerprintFormatOptions) string {
+	hexDigits := p.String()
+	if !opts.Lowercase {
+		hexDigits = strings.ToUpper(hexDigits)
+	}
+
+	groupBytes := opts.GroupBytes
+	if groupBytes <= 0 {
+		groupBytes = 2
+	}
+	sep := opts.Separator
+	if sep == "" {
+		sep = " "
+	}
+
+	groupLen := groupBytes * 2
+	var b strings.Builder
+	for i := 0; i < len(hexDigits); i += groupLen {
+		if i > 0 {
+			b.WriteString(sep)
+		}
+		end := i + groupLen
+		if end > len(hexDigits) {
+			end = len(hexDigits)
 		}
+		b.WriteString(hexDigits[i:end])
 	}
-	return string(ret)
+	return b.String()
+}
+
+// ToQuads renders p the way GPG's "quad" fingerprint display does:
+// uppercase hex digits in 2-byte groups, space-separated. It's a thin,
+// pre-existing-API wrapper around FormatFingerprint's zero value.
+func (p PGPFingerprint) ToQuads() string {
+	return p.FormatFingerprint(TerminalFingerprintFormat)
 }
 
 func (p PGPFingerprint) ToKeyID() string {
@@ -137,23 +261,159 @@ func (p *PGPFingerprint) Match(q string, exact bool) bool {
 	return strings.HasSuffix(strings.ToLower(p.String()), strings.ToLower(q))
 }
 
-func (k *PGPKeyBundle) InitGPGKey() {
-	k.GPGFallbackKey = &GPGKey{
-		fp:  k.GetFingerprintP(),
-		kid: k.GetKID(),
+// InitGPGKey wires up a GPGKey as this bundle's signing fallback. Before
+// doing so it probes the local gpg's capabilities (cached per process) so
+// that later calls to SignToString can fail fast with a clear reason
+// instead of a raw exec error.
+func (k *PGPKeyBundle) InitGPGKey(mctx MetaContext) error {
+	fallback := &GPGKey{
+		Contextified: NewContextified(mctx.G()),
+		fp:           k.GetFingerprintP(),
+		kid:          k.GetKID(),
 	}
+	k.GPGFallbackKey = fallback
+	return fallback.CheckCapabilities(mctx)
 }
 
 func (k *PGPKeyBundle) FullHash() (string, error) {
-	keyBlob, err := k.Encode()
+	keyBlob, err := k.NormalizeArmorForHashing()
 	if err != nil {
 		return "", err
 	}
 
-	keySum := sha256.Sum256([]byte(strings.TrimSpace(keyBlob)))
+	keySum := sha256.Sum256(keyBlob)
 	return hex.EncodeToString(keySum[:]), nil
 }
 
+// NormalizeArmorForHashing returns a canonical encoding of k's public key
+// suitable for content hashing (see FullHash): CRLF line endings are
+// collapsed to LF, and the armor header/footer and the CRC24 checksum line
+// are stripped, so differing "Version"/"Comment" headers don't affect the
+// hash. Like Encode/ToArmoredBytes, it serializes identities in a fixed,
+// sorted order rather than Go's randomized map-iteration order (see
+// serializeDeterministic); unlike them, the result is never cached on
+// ArmoredPublicKey, since it's deliberately not valid armor for re-import
+// (no checksum, possibly no headers).
+func (k *PGPKeyBundle) NormalizeArmorForHashing() ([]byte, error) {
+	var raw bytes.Buffer
+	if err := k.serializeDeterministic(&raw); err != nil {
+		return nil, err
+	}
+
+	var armored bytes.Buffer
+	writer, err := armor.Encode(&armored, "PGP PUBLIC KEY BLOCK", nil)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := writer.Write(raw.Bytes()); err != nil {
+		return nil, err
+	}
+	if err := writer.Close(); err != nil {
+		return nil, err
+	}
+
+	return stripArmorForHashing(armored.Bytes()), nil
+}
+
+// serializeDeterministic writes k's packets in the same order as
+// openpgp.Entity.Serialize, except Identities -- a Go map, whose range
+// order is randomized -- is walked in a fixed order (sorted by name) so
+// the same logical key always serializes to the same bytes.
+func (k *PGPKeyBundle) serializeDeterministic(w io.Writer) error {
+	if err := k.PrimaryKey.Serialize(w); err != nil {
+		return err
+	}
+
+	names := make([]string, 0, len(k.Identities))
+	for name := range k.Identities {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		ident := k.Identities[name]
+		if err := ident.UserId.Serialize(w); err != nil {
+			return err
+		}
+		if err := ident.SelfSignature.Serialize(w); err != nil {
+			return err
+		}
+		for _, sig := range ident.Signatures {
+			if err := sig.Serialize(w); err != nil {
+				return err
+			}
+		}
+	}
+
+	for _, subkey := range k.Subkeys {
+		if err := subkey.PublicKey.Serialize(w); err != nil {
+			return err
+		}
+		if subkey.Revocation != nil {
+			if err := subkey.Revocation.Serialize(w); err != nil {
+				return err
+			}
+		}
+		if err := subkey.Sig.Serialize(w); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// stripArmorForHashing drops everything from armored but the base64 body:
+// the "-----BEGIN/END-----" lines, any "Header: value" lines between the
+// BEGIN line and the body, and the trailing CRC24 checksum line. It also
+// collapses CRLF line endings to LF. The base64 alphabet never contains a
+// colon or a blank line, so those are unambiguous markers for the header
+// block and the header/body separator.
+func stripArmorForHashing(armored []byte) []byte {
+	lines := bytes.Split(armored, []byte("\n"))
+	var body [][]byte
+	inHeader := false
+	for _, line := range lines {
+		line = bytes.TrimRight(line, "\r")
+		switch {
+		case bytes.HasPrefix(line, []byte("-----BEGIN ")):
+			inHeader = true
+			continue
+		case bytes.HasPrefix(line, []byte("-----END ")):
+			continue
+		case inHeader && len(line) == 0:
+			inHeader = false
+			continue
+		case inHeader:
+			continue
+		case len(line) == 5 && line[0] == '=':
+			// the CRC24 checksum line
+			continue
+		}
+		body = append(body, line)
+	}
+	return bytes.Join(body, []byte("\n"))
+}
+
+// SubkeysSorted returns k's subkeys in a stable order -- by creation
+// time, then by fingerprint to break ties -- instead of whatever order
+// they happened to be parsed or assembled in. Operations that are
+// sensitive to subkey ordering, like StripRevocations and (downstream
+// of it) ExportPublicAndPrivate, should iterate this instead of
+// k.Subkeys directly so they produce the same result regardless of how
+// the key arrived.
+func (k *PGPKeyBundle) SubkeysSorted() []openpgp.Subkey {
+	sorted := make([]openpgp.Subkey, len(k.Subkeys))
+	copy(sorted, k.Subkeys)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		ti, tj := sorted[i].PublicKey.CreationTime, sorted[j].PublicKey.CreationTime
+		if !ti.Equal(tj) {
+			return ti.Before(tj)
+		}
+		return bytes.Compare(sorted[i].PublicKey.Fingerprint[:], sorted[j].PublicKey.Fingerprint[:]) < 0
+	})
+	return sorted
+}
+
 // StripRevocations returns a copy of the key with revocations removed
 func (k *PGPKeyBundle) StripRevocations() (strippedKey *PGPKeyBundle) {
 	strippedKey = nil
@@ -174,7 +434,7 @@ func (k *PGPKeyBundle) StripRevocations() (strippedKey *PGPKeyBundle) {
 
 	strippedKey.Revocations = nil
 
-	oldSubkeys := strippedKey.Subkeys
+	oldSubkeys := strippedKey.SubkeysSorted()
 	strippedKey.Subkeys = nil
 	for _, subkey := range oldSubkeys {
 		// Skip revoked subkeys
@@ -182,29 +442,231 @@ func (k *PGPKeyBundle) StripRevocations() (strippedKey *PGPKeyBundle) {
 			strippedKey.Subkeys = append(strippedKey.Subkeys, subkey)
 		}
 	}
+
+	// If strippedKey came from ReadOneKeyFromString above, it carries over
+	// k's cached armored export -- the revocations we just removed from
+	// Entity are still sitting in that cached string. Drop it so Encode()
+	// regenerates from the now-stripped Entity instead of handing back the
+	// unstripped original.
+	strippedKey.Invalidate()
+
 	return
 }
 
-func (k *PGPKeyBundle) StoreToLocalDb(g *GlobalContext) error {
+// RevocationRecord describes one revocation signature that
+// StripRevocationsWithReport removed, so callers can keep an audit trail of
+// why a subkey (or the primary key itself) was dropped.
+type RevocationRecord struct {
+	TargetFingerprint PGPFingerprint
+	ReasonCode        uint8
+	ReasonText        string
+	RevokedAt         time.Time
+}
+
+// StripRevocationsWithReport is StripRevocations, but also returns a record
+// of every revocation it removed -- reason code, reason string, and the time
+// of revocation -- for callers that need to keep an audit trail of why
+// subkeys were dropped, not just the cleaned-up key.
+func (k *PGPKeyBundle) StripRevocationsWithReport() (strippedKey *PGPKeyBundle, records []RevocationRecord, err error) {
+	if k.ArmoredPublicKey != "" {
+		strippedKey, _, err = ReadOneKeyFromString(k.ArmoredPublicKey)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	if strippedKey == nil {
+		// Either the armored key wasn't saved or ReadOneKeyFromString
+		// failed above. Fall back to the old behavior: we won't have a
+		// proper copy of the key (there are a lot of pointers in the key
+		// structs), but at least we won't bail out completely.
+		entityCopy := *k.Entity
+		strippedKey = &PGPKeyBundle{Entity: &entityCopy}
+	}
+
+	fp := strippedKey.GetFingerprint()
+	for _, rev := range strippedKey.Revocations {
+		records = append(records, revocationRecord(fp, rev))
+	}
+	strippedKey.Revocations = nil
+
+	oldSubkeys := strippedKey.Subkeys
+	strippedKey.Subkeys = nil
+	for _, subkey := range oldSubkeys {
+		if subkey.Revocation != nil {
+			records = append(records, revocationRecord(PGPFingerprint(subkey.PublicKey.Fingerprint), subkey.Revocation))
+		}
+		// Skip revoked subkeys
+		if subkey.Sig.SigType == packet.SigTypeSubkeyBinding && subkey.Revocation == nil {
+			strippedKey.Subkeys = append(strippedKey.Subkeys, subkey)
+		}
+	}
+
+	// See the matching comment in StripRevocations: strippedKey may carry a
+	// cached armored export of the unstripped key, now stale.
+	strippedKey.Invalidate()
+
+	return strippedKey, records, nil
+}
+
+func revocationRecord(targetFingerprint PGPFingerprint, sig *packet.Signature) RevocationRecord {
+	rec := RevocationRecord{
+		TargetFingerprint: targetFingerprint,
+		RevokedAt:         sig.CreationTime,
+		ReasonText:        sig.RevocationReasonText,
+	}
+	if sig.RevocationReason != nil {
+		rec.ReasonCode = *sig.RevocationReason
+	}
+	return rec
+}
+
+// pgpPacketVersion is the OpenPGP packet version (RFC 4880 section 5.2,
+// "version 4") the vendored library reads and writes for keys and
+// signatures. It rejects v3 and v5 packets outright at parse time rather
+// than tolerating them, so this is the only version KeyPacketVersions can
+// ever observe with the current library -- but the field is reported
+// explicitly (rather than assumed by callers) so a future library upgrade
+// that relaxes this restriction can surface real variation.
+const pgpPacketVersion = 4
+
+// PGPPacketVersions reports the OpenPGP packet version of a key's primary
+// key and each of its signatures, for triaging "this key works in gpg but
+// not our client" reports down to a version mismatch.
+type PGPPacketVersions struct {
+	PrimaryKeyVersion int
+	// SignatureVersions is keyed by a short label identifying which
+	// signature it is, e.g. "identity:<uid>:self" or
+	// "subkey:<fingerprint>:binding".
+	SignatureVersions map[string]int
+}
+
+// KeyPacketVersions reports the packet version of k's primary key and of
+// every self-signature, identity signature, subkey binding, and revocation
+// attached to it.
+func (k *PGPKeyBundle) KeyPacketVersions() PGPPacketVersions {
+	versions := PGPPacketVersions{
+		PrimaryKeyVersion: pgpPacketVersion,
+		SignatureVersions: make(map[string]int),
+	}
+
+	for range k.Revocations {
+		versions.SignatureVersions[fmt.Sprintf("key:%s:revocation", k.GetFingerprint())] = pgpPacketVersion
+	}
+
+	for uid, identity := range k.Identities {
+		if identity.SelfSignature != nil {
+			versions.SignatureVersions[fmt.Sprintf("identity:%s:self", uid)] = pgpPacketVersion
+		}
+		for i := range identity.Signatures {
+			versions.SignatureVersions[fmt.Sprintf("identity:%s:signature:%d", uid, i)] = pgpPacketVersion
+		}
+		if identity.Revocation != nil {
+			versions.SignatureVersions[fmt.Sprintf("identity:%s:revocation", uid)] = pgpPacketVersion
+		}
+	}
+
+	for _, subkey := range k.Subkeys {
+		fp := PGPFingerprint(subkey.PublicKey.Fingerprint)
+		if subkey.Sig != nil {
+			versions.SignatureVersions[fmt.Sprintf("subkey:%s:binding", fp)] = pgpPacketVersion
+		}
+		if subkey.Revocation != nil {
+			versions.SignatureVersions[fmt.Sprintf("subkey:%s:revocation", fp)] = pgpPacketVersion
+		}
+	}
+
+	return versions
+}
+
+// SelfSignatureVersion reports the packet version of the primary identity's
+// self-signature (see GetPrimaryUID for how the primary identity is
+// chosen), or 0 if the key has no identities or the primary one has no
+// self-signature.
+func (k *PGPKeyBundle) SelfSignatureVersion() int {
+	var pri *openpgp.Identity
+	var first *openpgp.Identity
+	for _, id := range k.Identities {
+		if first == nil {
+			first = id
+		}
+		if id.SelfSignature != nil && id.SelfSignature.IsPrimaryId != nil && *id.SelfSignature.IsPrimaryId {
+			pri = id
+			break
+		}
+	}
+	if pri == nil {
+		pri = first
+	}
+	if pri == nil || pri.SelfSignature == nil {
+		return 0
+	}
+	return pgpPacketVersion
+}
+
+func (k *PGPKeyBundle) StoreToLocalDb(mctx MetaContext) error {
 	s, err := k.Encode()
 	if err != nil {
 		return err
 	}
-	val := jsonw.NewString(s)
-	g.Log.Debug("| Storing Key (kid=%s) to Local DB", k.GetKID())
-	return g.LocalDb.Put(DbKey{Typ: DBPGPKey, Key: k.GetKID().String()}, []DbKey{}, val)
+	row, err := encodeCurrentPGPKeyDbRow(s)
+	if err != nil {
+		return err
+	}
+	mctx.Debug("| Storing Key (kid=%s) to Local DB", k.GetKID())
+	dbKey := pgpKeyDbKey(mctx, k.GetKID())
+	err = mctx.G().LocalDb.PutRaw(dbKey, row)
+	if IsDbLockOrCorruptionError(err) {
+		// The PGP key cache is strictly optional, so don't fail the caller
+		// over it -- keep the row in memory for this process's lifetime and
+		// let it get dropped on restart if LocalDb never recovers.
+		mctx.G().MarkLocalDbDegraded(mctx, err.Error())
+		mctx.G().DegradedCachePut(dbKey, row)
+		return nil
+	}
+	return err
 }
 
 func (p PGPFingerprint) Eq(p2 PGPFingerprint) bool {
 	return FastByteArrayEq(p[:], p2[:])
 }
 
+// GetPGPFingerprint parses a fingerprint out of w, which the server has been
+// seen to hand back either as a bare hex string or as an object wrapping one,
+// e.g. {"fingerprint": "...", "algo": ...}.
 func GetPGPFingerprint(w *jsonw.Wrapper) (*PGPFingerprint, error) {
-	s, err := w.GetString()
+	switch w.GetDataOrNil().(type) {
+	case map[string]interface{}:
+		s, err := w.AtKey("fingerprint").GetString()
+		if err != nil {
+			return nil, fmt.Errorf("pgp fingerprint object missing \"fingerprint\" field: %v", err)
+		}
+		return PGPFingerprintFromHex(s)
+	default:
+		s, err := w.GetString()
+		if err != nil {
+			return nil, err
+		}
+		return PGPFingerprintFromHex(s)
+	}
+}
+
+// GetPGPFingerprints parses w as an array of fingerprints, each in either of
+// the forms accepted by GetPGPFingerprint.
+func GetPGPFingerprints(w *jsonw.Wrapper) (ret []PGPFingerprint, err error) {
+	n, err := w.Len()
 	if err != nil {
 		return nil, err
 	}
-	return PGPFingerprintFromHex(s)
+	ret = make([]PGPFingerprint, 0, n)
+	for i := 0; i < n; i++ {
+		fp, err := GetPGPFingerprint(w.AtIndex(i))
+		if err != nil {
+			return nil, err
+		}
+		ret = append(ret, *fp)
+	}
+	return ret, nil
 }
 
 func GetPGPFingerprintVoid(w *jsonw.Wrapper, p *PGPFingerprint, e *error) {
@@ -274,17 +736,40 @@ func (k PGPKeyBundle) SamePrimaryAs(k2 PGPKeyBundle) bool {
 	return FastByteArrayEq(k.PrimaryKey.Fingerprint[:], k2.PrimaryKey.Fingerprint[:])
 }
 
+// Invalidate drops k's cached armored public key export, if any, so the
+// next Encode()/ToArmoredBytes() call regenerates it from the current state
+// of Entity instead of returning a copy that predates a mutation. Call this
+// after any change that alters k's serialized form: a new identity, a new
+// self-signature, a stripped revocation, and so on.
+func (k *PGPKeyBundle) Invalidate() {
+	k.ArmoredPublicKey = ""
+}
+
 func (k *PGPKeyBundle) Encode() (ret string, err error) {
-	if k.ArmoredPublicKey != "" {
-		return k.ArmoredPublicKey, nil
+	if _, err = k.ToArmoredBytes(false); err != nil {
+		return "", err
+	}
+	return k.ArmoredPublicKey, nil
+}
+
+// ToArmoredBytes is Encode's sibling for callers (like FullHash) that just
+// want the armored key as bytes -- e.g. for hashing -- without forcing the
+// string allocation Encode's return type requires. The public encoding is
+// cached on k.ArmoredPublicKey the same way Encode caches it; private
+// encodings are never cached, since key.go clears the cache rather than
+// tracking it for the private case.
+func (k *PGPKeyBundle) ToArmoredBytes(private bool) ([]byte, error) {
+	if !private && k.ArmoredPublicKey != "" {
+		return []byte(k.ArmoredPublicKey), nil
 	}
 	buf := bytes.Buffer{}
-	err = k.EncodeToStream(NopWriteCloser{&buf}, false)
-	if err == nil {
-		ret = buf.String()
-		k.ArmoredPublicKey = ret
+	if err := k.EncodeToStream(NopWriteCloser{&buf}, private); err != nil {
+		return nil, err
 	}
-	return
+	if !private {
+		k.ArmoredPublicKey = buf.String()
+	}
+	return buf.Bytes(), nil
 }
 
 func PGPKeyRawToArmored(raw []byte, priv bool) (ret string, err error) {
@@ -331,7 +816,11 @@ func (k *PGPKeyBundle) EncodeToStream(wc io.WriteCloser, private bool) error {
 	if private {
 		err = k.SerializePrivate(writer)
 	} else {
-		err = k.Entity.Serialize(writer)
+		// k.Entity.Serialize walks Identities -- a Go map -- in its
+		// randomized range order, so the same logical key could otherwise
+		// re-encode to different bytes from one call to the next.
+		// serializeDeterministic walks it sorted by name instead.
+		err = k.serializeDeterministic(writer)
 	}
 	if err != nil {
 		return err
@@ -386,6 +875,40 @@ func ReadOneKeyFromStringLiberal(originalArmor string) (*PGPKeyBundle, *Warnings
 	return readOneKeyFromString(originalArmor, true /* liberal */)
 }
 
+// ImportAndVerifyAgainstFingerprint reads a single armored key out of
+// originalArmor (liberally, if liberal is true) and checks that its
+// fingerprint matches expected, returning BadFingerprintError if it
+// doesn't. It saves callers from forgetting the CheckFingerprint step
+// after importing a pasted key.
+func ImportAndVerifyAgainstFingerprint(originalArmor string, expected *PGPFingerprint, liberal bool) (*PGPKeyBundle, *Warnings, error) {
+	bundle, warnings, err := readOneKeyFromString(originalArmor, liberal)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := bundle.CheckFingerprint(expected); err != nil {
+		return nil, nil, err
+	}
+	return bundle, warnings, nil
+}
+
+// ImportArmoredWithProvenance is like ReadOneKeyFromString (or its liberal
+// variant, if liberal is true), but additionally records source and the
+// current time as the bundle's Provenance, so later debugging can tell
+// where a given key came from and whether it needed liberal parsing to
+// read.
+func ImportArmoredWithProvenance(armored string, source string, liberal bool) (*PGPKeyBundle, *Warnings, error) {
+	bundle, warnings, err := readOneKeyFromString(armored, liberal)
+	if err != nil {
+		return nil, nil, err
+	}
+	bundle.provenance = &PGPKeyProvenance{
+		Source:    source,
+		FetchTime: time.Now(),
+		Liberal:   liberal,
+	}
+	return bundle, warnings, nil
+}
+
 func readOneKeyFromString(originalArmor string, liberal bool) (*PGPKeyBundle, *Warnings, error) {
 	cleanArmor := cleanPGPInput(originalArmor)
 	if liberal {
@@ -443,11 +966,17 @@ func ReadPrivateKeyFromString(s string) (*PGPKeyBundle, *Warnings, error) {
 	return ReadOneKeyFromString(priv)
 }
 
-func mergeKeysIfPossible(out *PGPKeyBundle, lst []*openpgp.Entity) error {
+// mergeKeysIfPossible merges every entity in lst into out, as long as each
+// one shares out's primary key, and pushes a MergeReport onto w for every
+// merge that actually changed out -- e.g. a re-imported key that picked up
+// a new subkey, UID, or signature since it was last cached.
+func mergeKeysIfPossible(out *PGPKeyBundle, lst []*openpgp.Entity, w *Warnings) error {
 	for _, e := range lst {
 		tmp := PGPKeyBundle{Entity: e}
 		if out.SamePrimaryAs(tmp) {
-			out.MergeKey(&tmp)
+			if report := out.MergeKey(&tmp); !report.IsEmpty() {
+				w.Push(report)
+			}
 		} else {
 			return TooManyKeysError{len(lst) + 1}
 		}
@@ -470,7 +999,7 @@ func finishReadOne(lst []*openpgp.Entity, armored string, err error) (*PGPKeyBun
 		// Some keys like Sheldon Hern's (https://github.com/keybase/client/issues/2130)
 		// have the same primary key twice in their list of keys. In this case, we should just
 		// perform a merge if possible, since the server-side accepts and merges such key exports.
-		err = mergeKeysIfPossible(first, lst[1:])
+		err = mergeKeysIfPossible(first, lst[1:], w)
 		if err != nil {
 			return nil, w, err
 		}
@@ -480,6 +1009,10 @@ func finishReadOne(lst []*openpgp.Entity, armored string, err error) (*PGPKeyBun
 		w.Push(Warningf("Bad subkey: %s", bs.Err))
 	}
 
+	for _, uidErr := range first.ValidateUIDEmailSyntax() {
+		w.Push(Warningf("Bad UID email: %s", uidErr))
+	}
+
 	if first.Entity.PrivateKey == nil {
 		first.ArmoredPublicKey = armored
 	}
@@ -492,6 +1025,48 @@ func ReadOneKeyFromBytes(b []byte) (*PGPKeyBundle, *Warnings, error) {
 	return finishReadOne(el, "", err)
 }
 
+// ReadAllKeysFromReader reads every PGP key block out of r, returning one
+// PGPKeyBundle and one set of warnings per distinct key found. Unlike
+// ReadOneKeyFromString, it doesn't attempt to merge same-primary entries
+// or fail with TooManyKeysError on different ones -- multi-key armors of
+// that shape (e.g. the output of `gpg --export` against a whole keyring)
+// are exactly what it's for, so importers can offer the user a picker.
+func ReadAllKeysFromReader(r io.Reader) ([]*PGPKeyBundle, []*Warnings, error) {
+	el, err := openpgp.ReadArmoredKeyRing(r)
+	if err != nil {
+		return nil, nil, err
+	}
+	return bundleAllKeys(el)
+}
+
+// ReadAllKeysFromString is ReadAllKeysFromReader over an already-read
+// armored string.
+func ReadAllKeysFromString(originalArmor string) ([]*PGPKeyBundle, []*Warnings, error) {
+	return ReadAllKeysFromReader(strings.NewReader(cleanPGPInput(originalArmor)))
+}
+
+func bundleAllKeys(lst []*openpgp.Entity) ([]*PGPKeyBundle, []*Warnings, error) {
+	if len(lst) == 0 {
+		return nil, nil, NoKeyError{"No keys found in bundle"}
+	}
+
+	bundles := make([]*PGPKeyBundle, 0, len(lst))
+	allWarnings := make([]*Warnings, 0, len(lst))
+	for _, e := range lst {
+		bundle := &PGPKeyBundle{Entity: e}
+		w := &Warnings{}
+		for _, bs := range bundle.Entity.BadSubkeys {
+			w.Push(Warningf("Bad subkey: %s", bs.Err))
+		}
+		for _, uidErr := range bundle.ValidateUIDEmailSyntax() {
+			w.Push(Warningf("Bad UID email: %s", uidErr))
+		}
+		bundles = append(bundles, bundle)
+		allWarnings = append(allWarnings, w)
+	}
+	return bundles, allWarnings, nil
+}
+
 func GetOneKey(jw *jsonw.Wrapper) (*PGPKeyBundle, *Warnings, error) {
 	s, err := jw.GetString()
 	if err != nil {
@@ -500,14 +1075,14 @@ func GetOneKey(jw *jsonw.Wrapper) (*PGPKeyBundle, *Warnings, error) {
 	return ReadOneKeyFromString(s)
 }
 
-// XXX for now this is OK but probably we need a PGP uid parser
-// as in pgp-utils
 func (k *PGPKeyBundle) FindKeybaseUsername(un string) bool {
-
-	rxx := regexp.MustCompile("(?i)< " + un + "@keybase.io>$")
-
+	want := strings.ToLower(un) + "@keybase.io"
 	for _, id := range k.Identities {
-		if rxx.MatchString(id.Name) {
+		ident, err := ParsePGPUserID(id.Name)
+		if err != nil {
+			continue
+		}
+		if strings.ToLower(ident.Email) == want {
 			return true
 		}
 	}
@@ -517,6 +1092,9 @@ func (k *PGPKeyBundle) FindKeybaseUsername(un string) bool {
 func (k PGPKeyBundle) VerboseDescription() string {
 	lines := k.UsersDescription()
 	lines = append(lines, k.KeyDescription())
+	for _, sub := range k.SubkeyInfo() {
+		lines = append(lines, sub.String())
+	}
 	return strings.Join(lines, "\n")
 }
 
@@ -534,62 +1112,258 @@ func (k PGPKeyBundle) UsersDescription() []string {
 	return []string{"user: " + id}
 }
 
-// GetPrimaryUID gets the primary UID in the given key bundle, returned
-// in the 'Max K (foo) <bar@baz.com>' convention.
-func (k PGPKeyBundle) GetPrimaryUID() string {
-
-	var pri *openpgp.Identity
-	var s string
-	if len(k.Identities) == 0 {
-		return ""
-	}
+// primaryIdentity returns the identity whose self-signature is flagged as
+// primary, or else whichever identity is encountered first, or nil if the
+// key has no identities at all.
+func (k PGPKeyBundle) primaryIdentity() *openpgp.Identity {
 	var first *openpgp.Identity
 	for _, id := range k.Identities {
 		if first == nil {
 			first = id
 		}
 		if id.SelfSignature != nil && id.SelfSignature.IsPrimaryId != nil && *id.SelfSignature.IsPrimaryId {
-			pri = id
-			break
+			return id
 		}
 	}
+	return first
+}
+
+// GetPrimaryUID gets the primary UID in the given key bundle, returned
+// in the 'Max K (foo) <bar@baz.com>' convention.
+func (k PGPKeyBundle) GetPrimaryUID() string {
+	pri := k.primaryIdentity()
 	if pri == nil {
-		pri = first
+		return ""
 	}
+	raw := pri.Name
 	if pri.UserId != nil {
-		s = pri.UserId.Id
-	} else {
-		s = pri.Name
+		raw = pri.UserId.Id
+	}
+	if ident, err := ParsePGPUserID(raw); err == nil {
+		return ident.Format()
 	}
-	return s
+	return raw
 }
 
-// HasSecretKey checks if the PGPKeyBundle contains secret key. This
-// function returning true does not indicate that the key is
-// functional - it may also be a key stub.
-func (k *PGPKeyBundle) HasSecretKey() bool {
-	return k.PrivateKey != nil
+// PrimaryKeyFlags returns the usage capabilities declared on the primary
+// identity's self-signature, as a string of letters in the conventional
+// gpg-ish order: "S" (sign), "C" (certify), "E" (encrypt, covering either
+// of the encrypt-communications/encrypt-storage flags). It returns "" if
+// the primary identity has no self-signature or it declares no flags.
+func (k PGPKeyBundle) PrimaryKeyFlags() string {
+	pri := k.primaryIdentity()
+	if pri == nil || pri.SelfSignature == nil {
+		return ""
+	}
+	return keyUsageFlagLetters(pri.SelfSignature)
 }
 
-// FindPGPPrivateKey checks if supposed secret key PGPKeyBundle
-// contains any valid PrivateKey entities. Sometimes primary private
-// key is stoopped out but there are subkeys with secret keys.
-func FindPGPPrivateKey(k *PGPKeyBundle) bool {
-	if k.PrivateKey.PrivateKey != nil {
-		return true
+// subkeyFlags returns the union of usage capability letters (see
+// PrimaryKeyFlags) declared across all of k's subkeys.
+func (k PGPKeyBundle) subkeyFlags() string {
+	var sign, certify, encrypt bool
+	for _, sub := range k.Subkeys {
+		if sub.Sig == nil || !sub.Sig.FlagsValid {
+			continue
+		}
+		sign = sign || sub.Sig.FlagSign
+		certify = certify || sub.Sig.FlagCertify
+		encrypt = encrypt || sub.Sig.FlagEncryptCommunications || sub.Sig.FlagEncryptStorage
 	}
+	return flagLetters(sign, certify, encrypt)
+}
 
-	for _, subKey := range k.Subkeys {
-		if subKey.PrivateKey != nil && subKey.PrivateKey.PrivateKey != nil {
-			return true
-		}
+func keyUsageFlagLetters(sig *packet.Signature) string {
+	if !sig.FlagsValid {
+		return ""
 	}
+	return flagLetters(sig.FlagSign, sig.FlagCertify, sig.FlagEncryptCommunications || sig.FlagEncryptStorage)
+}
 
-	return false
+func flagLetters(sign, certify, encrypt bool) string {
+	var letters string
+	if sign {
+		letters += "S"
+	}
+	if certify {
+		letters += "C"
+	}
+	if encrypt {
+		letters += "E"
+	}
+	return letters
 }
 
-func (k *PGPKeyBundle) CheckSecretKey() (err error) {
-	if k.PrivateKey == nil {
+// IsExpired reports whether the primary identity's self-signature declares
+// a key lifetime that has already elapsed. A key with no declared lifetime
+// never expires.
+func (k PGPKeyBundle) IsExpired() bool {
+	expiration := k.expirationTime()
+	return expiration != nil && expiration.Before(time.Now())
+}
+
+// expirationTime returns when the primary identity's self-signature says
+// this key expires, or nil if it declares no expiration.
+func (k PGPKeyBundle) expirationTime() *time.Time {
+	pri := k.primaryIdentity()
+	if pri == nil || pri.SelfSignature == nil || pri.SelfSignature.KeyLifetimeSecs == nil {
+		return nil
+	}
+	expiration := pri.SelfSignature.CreationTime.Add(time.Duration(*pri.SelfSignature.KeyLifetimeSecs) * time.Second)
+	return &expiration
+}
+
+// KeyUsageSummary renders a compact one-line description of the key
+// suitable for list views, e.g.:
+//
+//	4096-bit RSA key [SC] expires 2026-01-01, 2 subkeys [E]
+func (k PGPKeyBundle) KeyUsageSummary() string {
+	algorithm, _, _ := k.KeyInfo()
+	summary := algorithm
+
+	if letters := k.PrimaryKeyFlags(); letters != "" {
+		summary += fmt.Sprintf(" [%s]", letters)
+	}
+
+	if k.IsExpired() {
+		summary += " expired"
+	} else if expiration := k.expirationTime(); expiration != nil {
+		summary += fmt.Sprintf(" expires %s", expiration.Format("2006-01-02"))
+	}
+
+	if n := len(k.Subkeys); n > 0 {
+		plural := ""
+		if n != 1 {
+			plural = "s"
+		}
+		summary += fmt.Sprintf(", %d subkey%s", n, plural)
+		if letters := k.subkeyFlags(); letters != "" {
+			summary += fmt.Sprintf(" [%s]", letters)
+		}
+	}
+
+	return summary
+}
+
+// HasSecretKey checks if the PGPKeyBundle contains secret key. This
+// function returning true does not indicate that the key is
+// functional - it may also be a key stub.
+func (k *PGPKeyBundle) HasSecretKey() bool {
+	return k.PrivateKey != nil
+}
+
+// IsStubbedSecretKey returns true if k has secret key packets, but every
+// one of them is a GNU Dummy S2K stub rather than real key material --
+// what gpg exports for a key it only holds a reference to, typically
+// because the actual key lives on a smart card (a YubiKey, say) and never
+// existed on this machine. A stubbed key isn't "encrypted" in the normal
+// sense: there's no passphrase that will ever unlock it, so Unlock silently
+// no-ops on it, and the only way to sign with it is a GPGFallbackKey, which
+// shells out to gpg and lets gpg-agent/scdaemon talk to the card.
+func (k *PGPKeyBundle) IsStubbedSecretKey() bool {
+	return k.PrivateKey != nil && !k.PrivateKey.Encrypted && !FindPGPPrivateKey(k)
+}
+
+// FindPGPPrivateKey checks if supposed secret key PGPKeyBundle
+// contains any valid PrivateKey entities. Sometimes primary private
+// key is stoopped out but there are subkeys with secret keys.
+func FindPGPPrivateKey(k *PGPKeyBundle) bool {
+	if k.PrivateKey.PrivateKey != nil {
+		return true
+	}
+
+	for _, subKey := range k.Subkeys {
+		if subKey.PrivateKey != nil && subKey.PrivateKey.PrivateKey != nil {
+			return true
+		}
+	}
+
+	return false
+}
+
+// ScrubSecretsOnError drops this bundle's decrypted private key material if
+// *errp is non-nil. It's meant to be deferred right after unlocking a key,
+// e.g. `defer k.ScrubSecretsOnError(&err)`, so that a caller further up the
+// stack that mishandles the error doesn't end up holding onto a live
+// decrypted key for longer than necessary. It zeros the private scalars it
+// knows how to reach in place, rather than just dropping k's reference to
+// them, but it's still best-effort: Go's GC can have made copies of any of
+// this along the way (e.g. while growing a slice or passing by value), and
+// none of those are reachable here to scrub.
+func (k *PGPKeyBundle) ScrubSecretsOnError(errp *error) {
+	if errp == nil || *errp == nil {
+		return
+	}
+	if k.PrivateKey != nil {
+		scrubPGPPrivateKeyMaterial(k.PrivateKey.PrivateKey)
+		k.PrivateKey.PrivateKey = nil
+		k.PrivateKey.Encrypted = true
+	}
+	for _, subkey := range k.Subkeys {
+		if subkey.PrivateKey != nil {
+			scrubPGPPrivateKeyMaterial(subkey.PrivateKey.PrivateKey)
+			subkey.PrivateKey.PrivateKey = nil
+			subkey.PrivateKey.Encrypted = true
+		}
+	}
+}
+
+// scrubPGPPrivateKeyMaterial zeros the private scalar(s) backing priv, which
+// is the concrete type packet.PrivateKey.PrivateKey holds once decrypted --
+// one of the types below for every algorithm this package generates or
+// imports. Unrecognized types are left alone, since there's nothing
+// type-safe we can do with them.
+func scrubPGPPrivateKeyMaterial(priv interface{}) {
+	switch k := priv.(type) {
+	case *rsa.PrivateKey:
+		zeroBigInt(k.D)
+		for _, p := range k.Primes {
+			zeroBigInt(p)
+		}
+		zeroBigInt(k.Precomputed.Dp)
+		zeroBigInt(k.Precomputed.Dq)
+		zeroBigInt(k.Precomputed.Qinv)
+		for _, crt := range k.Precomputed.CRTValues {
+			zeroBigInt(crt.Exp)
+			zeroBigInt(crt.Coeff)
+			zeroBigInt(crt.R)
+		}
+	case *dsa.PrivateKey:
+		zeroBigInt(k.X)
+	case *elgamal.PrivateKey:
+		zeroBigInt(k.X)
+	case *ecdsa.PrivateKey:
+		zeroBigInt(k.D)
+	case *ecdh.PrivateKey:
+		zeroBigInt(k.X)
+	case *packet.EdDSAPrivateKey:
+		zeroBytes(k.Seed())
+	}
+}
+
+// zeroBigInt clears x's backing words in place via the shared-array access
+// that big.Int.Bits documents, then resets x to 0. A plain x.SetInt64(0)
+// would only repoint x at a fresh zero value, leaving the old digits intact
+// in the backing array until GC reclaims it.
+func zeroBigInt(x *big.Int) {
+	if x == nil {
+		return
+	}
+	words := x.Bits()
+	for i := range words {
+		words[i] = 0
+	}
+	x.SetInt64(0)
+}
+
+func zeroBytes(b []byte) {
+	for i := range b {
+		b[i] = 0
+	}
+}
+
+func (k *PGPKeyBundle) CheckSecretKey() (err error) {
+	if k.PrivateKey == nil {
 		err = NoSecretKeyError{}
 	} else if k.PrivateKey.Encrypted {
 		err = kbcrypto.BadKeyError{Msg: "PGP key material should be unencrypted"}
@@ -604,10 +1378,13 @@ func (k *PGPKeyBundle) CanSign() bool {
 }
 
 func (k *PGPKeyBundle) GetBinaryKID() keybase1.BinaryKID {
+	return binaryKIDFromPublicKey(k.PrimaryKey)
+}
 
+func binaryKIDFromPublicKey(pub *packet.PublicKey) keybase1.BinaryKID {
 	prefix := []byte{
 		byte(kbcrypto.KeybaseKIDV1),
-		byte(k.PrimaryKey.PubKeyAlgo),
+		byte(pub.PubKeyAlgo),
 	}
 
 	// XXX Hack;  Because PublicKey.serializeWithoutHeaders is off-limits
@@ -618,7 +1395,7 @@ func (k *PGPKeyBundle) GetBinaryKID() keybase1.BinaryKID {
 	// have 9 bytes of header material, to encode a 2-byte frame, rather than
 	// a 1-byte frame.
 	buf := bytes.Buffer{}
-	_ = k.PrimaryKey.Serialize(&buf)
+	_ = pub.Serialize(&buf)
 	byts := buf.Bytes()
 	hdrBytes := 8
 	if len(byts) >= 193 {
@@ -636,6 +1413,384 @@ func (k *PGPKeyBundle) GetKID() keybase1.KID {
 	return k.GetBinaryKID().ToKID()
 }
 
+// GetBinaryKIDForSubkey computes the KID for one of this key's subkeys, the
+// same way GetBinaryKID computes it for the primary key. The server uses
+// this to reference signing subkeys independently of the primary key.
+func (k *PGPKeyBundle) GetBinaryKIDForSubkey(sub *openpgp.Subkey) keybase1.BinaryKID {
+	return binaryKIDFromPublicKey(sub.PublicKey)
+}
+
+func (k *PGPKeyBundle) GetKIDForSubkey(sub *openpgp.Subkey) keybase1.KID {
+	return k.GetBinaryKIDForSubkey(sub).ToKID()
+}
+
+// canCertify reports whether any of k's self-signatures grants it the
+// capability to certify (sign) other keys' identities.
+func (k *PGPKeyBundle) canCertify() bool {
+	for _, ident := range k.Identities {
+		if ident.SelfSignature != nil && ident.SelfSignature.FlagCertify {
+			return true
+		}
+	}
+	return false
+}
+
+// isValidCertificationLevel reports whether level is one of the four
+// certification signature types defined by RFC 4880 section 5.2.1.
+func isValidCertificationLevel(level packet.SignatureType) bool {
+	switch level {
+	case packet.SigTypeGenericCert, packet.SigTypePersonaCert,
+		packet.SigTypeCasualCert, packet.SigTypePositiveCert:
+		return true
+	default:
+		return false
+	}
+}
+
+// CertifyUserID has k (the receiver, which must be unlocked and able to
+// certify) sign uid on other's key, attaching a third-party certification at
+// the given level. uid must already be one of other's identities. Unlike
+// openpgp.Entity.SignIdentity, the certification level is caller-chosen
+// rather than fixed to SigTypeGenericCert, since web-of-trust UIs distinguish
+// how carefully the identity was checked.
+func (k *PGPKeyBundle) CertifyUserID(other *PGPKeyBundle, uid string, level packet.SignatureType) (err error) {
+	if !isValidCertificationLevel(level) {
+		return fmt.Errorf("CertifyUserID: invalid certification level %v", level)
+	}
+	if !k.canCertify() {
+		return fmt.Errorf("CertifyUserID: key %s is not allowed to certify other keys", k.GetFingerprint())
+	}
+	if k.PrivateKey == nil || k.PrivateKey.Encrypted {
+		return fmt.Errorf("CertifyUserID: signing key %s is locked", k.GetFingerprint())
+	}
+	ident, found := other.Identities[uid]
+	if !found {
+		return fmt.Errorf("CertifyUserID: uid %q not found on key %s", uid, other.GetFingerprint())
+	}
+
+	sig := &packet.Signature{
+		SigType:      level,
+		PubKeyAlgo:   k.PrivateKey.PubKeyAlgo,
+		Hash:         (*packet.Config)(nil).Hash(),
+		CreationTime: (*packet.Config)(nil).Now(),
+		IssuerKeyId:  &k.PrivateKey.KeyId,
+	}
+	if err := sig.SignUserId(uid, other.PrimaryKey, k.PrivateKey, nil); err != nil {
+		return err
+	}
+	ident.Signatures = append(ident.Signatures, sig)
+
+	// The signature changes other's serialized form, so the cached armored
+	// export (if any) is now stale.
+	other.Invalidate()
+
+	return nil
+}
+
+// IdentitiesSignedBy returns the UIDs among k's identities that carry a
+// certification signature issued by the key with the given key ID.
+func (k *PGPKeyBundle) IdentitiesSignedBy(issuerKeyID uint64) []string {
+	var uids []string
+	for uid, ident := range k.Identities {
+		for _, sig := range ident.Signatures {
+			if sig.IssuerKeyId != nil && *sig.IssuerKeyId == issuerKeyID {
+				uids = append(uids, uid)
+				break
+			}
+		}
+	}
+	return uids
+}
+
+// AddIdentity adds a new UID to k by creating and self-signing a positive
+// certification for it (RFC 4880 section 5.2.1, type 0x13), the same kind
+// of signature GeneratePGPKeyBundle attaches to each UID at key-creation
+// time. It returns the new identity's UID string (the Identities map key)
+// and invalidates the cached armored export. k must be an unlocked private
+// bundle, and ident must not already be present on k.
+func (k *PGPKeyBundle) AddIdentity(ident Identity) (uid string, err error) {
+	if k.PrivateKey == nil || k.PrivateKey.Encrypted {
+		return "", fmt.Errorf("AddIdentity: signing key %s is locked", k.GetFingerprint())
+	}
+	if err := ident.Validate(); err != nil {
+		return "", err
+	}
+	userID := ident.ToPGPUserID()
+	if _, found := k.Identities[userID.Id]; found {
+		return "", fmt.Errorf("AddIdentity: uid %q already exists on key %s", userID.Id, k.GetFingerprint())
+	}
+
+	isPrimaryID := len(k.Identities) == 0
+	config := &packet.Config{}
+	sig := &packet.Signature{
+		CreationTime:         config.Now(),
+		SigType:              packet.SigTypePositiveCert,
+		PubKeyAlgo:           k.PrivateKey.PubKeyAlgo,
+		Hash:                 config.Hash(),
+		IsPrimaryId:          &isPrimaryID,
+		FlagsValid:           true,
+		FlagSign:             true,
+		FlagCertify:          true,
+		IssuerKeyId:          &k.PrivateKey.KeyId,
+		PreferredSymmetric:   defaultPreferredSymmetric(),
+		PreferredHash:        defaultPreferredHash(),
+		PreferredCompression: defaultPreferredCompression(),
+	}
+	if err := sig.SignUserId(userID.Id, k.PrimaryKey, k.PrivateKey, config); err != nil {
+		return "", err
+	}
+
+	k.Identities[userID.Id] = &openpgp.Identity{
+		Name:          userID.Name,
+		UserId:        userID,
+		SelfSignature: sig,
+	}
+	k.Invalidate()
+
+	return userID.Id, nil
+}
+
+// RevokeIdentity marks uid as revoked by adding a self-signed certification
+// revocation signature (RFC 4880 section 5.2.1, type 0x30) to the
+// identity's signature list, and invalidates the cached armored export.
+// Unlike StripRevocations or rewriting the key without the UID, the UID
+// itself remains present so downstream verifiers can see that it was
+// deliberately retired rather than having simply never existed. k must be
+// an unlocked private bundle and must already own uid.
+//
+// reason is accepted for API symmetry with future revocation-reason
+// support, but the vendored OpenPGP packet library doesn't serialize an
+// outgoing revocation-reason subpacket, so it is not currently embedded in
+// the signature.
+func (k *PGPKeyBundle) RevokeIdentity(uid string, reason string) error {
+	if k.PrivateKey == nil || k.PrivateKey.Encrypted {
+		return fmt.Errorf("RevokeIdentity: signing key %s is locked", k.GetFingerprint())
+	}
+	ident, found := k.Identities[uid]
+	if !found {
+		return fmt.Errorf("RevokeIdentity: uid %q not found on key %s", uid, k.GetFingerprint())
+	}
+
+	sig := &packet.Signature{
+		SigType:      packet.SigTypeIdentityRevocation,
+		PubKeyAlgo:   k.PrivateKey.PubKeyAlgo,
+		Hash:         (*packet.Config)(nil).Hash(),
+		CreationTime: (*packet.Config)(nil).Now(),
+		IssuerKeyId:  &k.PrivateKey.KeyId,
+	}
+	if err := sig.SignUserId(uid, k.PrimaryKey, k.PrivateKey, nil); err != nil {
+		return err
+	}
+	ident.Signatures = append(ident.Signatures, sig)
+
+	// The signature changes k's serialized form, so the cached armored
+	// export (if any) is now stale.
+	k.Invalidate()
+
+	return nil
+}
+
+// PGPRevocationReasonCode is the "reason for revocation" code from RFC 4880
+// section 5.2.3.23. The vendored OpenPGP packet library exposes
+// Signature.RevocationReason as a bare *uint8 with no enum of its own.
+type PGPRevocationReasonCode uint8
+
+const (
+	PGPRevocationReasonUnspecified    PGPRevocationReasonCode = 0
+	PGPRevocationReasonKeySuperseded  PGPRevocationReasonCode = 1
+	PGPRevocationReasonKeyCompromised PGPRevocationReasonCode = 2
+	PGPRevocationReasonKeyRetired     PGPRevocationReasonCode = 3
+)
+
+// pgpPrimaryKeyBody returns the same bytes the vendored packet library's
+// unexported PublicKey.serializeWithoutHeaders would: pk's serialized form
+// with the leading packet header stripped off. The header's length is
+// deterministic from the body length alone (RFC 4880 section 4.2.2, the
+// "new format" packet length rules used by serializeHeader), so it can be
+// recovered by inspecting the bytes Serialize wrote rather than needing the
+// unexported method itself.
+func pgpPrimaryKeyBody(pk *packet.PublicKey) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := pk.Serialize(&buf); err != nil {
+		return nil, err
+	}
+	b := buf.Bytes()
+	if len(b) < 2 || b[0]&0xc0 != 0xc0 {
+		return nil, errors.New("pgpPrimaryKeyBody: unexpected packet header")
+	}
+	switch {
+	case b[1] < 192:
+		return b[2:], nil
+	case b[1] < 255:
+		return b[3:], nil
+	default:
+		return b[6:], nil
+	}
+}
+
+// pgpKeyRevocationHash reproduces the vendored packet library's unexported
+// keyRevocationHash: a hash over pk alone (RFC 4880 section 5.2.4), as
+// opposed to keySignatureHash's signer-then-signee pair used for
+// certifications and subkey bindings. PublicKey.VerifyRevocationSignature
+// checks a revocation signature against this same hash, so a signature made
+// over anything else (for example via Signature.SignKey) won't verify as a
+// key revocation.
+func pgpKeyRevocationHash(pk *packet.PublicKey, hashFunc crypto.Hash) (h hash.Hash, err error) {
+	if !hashFunc.Available() {
+		return nil, errors.New("pgpKeyRevocationHash: hash function unavailable")
+	}
+	h = hashFunc.New()
+	pk.SerializeSignaturePrefix(h)
+	body, err := pgpPrimaryKeyBody(pk)
+	if err != nil {
+		return nil, err
+	}
+	h.Write(body)
+	return h, nil
+}
+
+// GenerateRevocationCertificate produces a standalone, armored "hard"
+// revocation signature over k's primary key, without modifying k itself.
+// The idea is to generate and store this somewhere safe apart from the
+// private key right after key creation: if the private key is later lost
+// or compromised, importing this certificate into any keyring holding the
+// public key is enough to revoke it, with no access to the private key
+// required.
+//
+// k must be an unlocked private bundle. description is accepted for API
+// symmetry with RevokeIdentity, but like that function's reason parameter,
+// the vendored OpenPGP packet library doesn't serialize an outgoing
+// revocation-reason subpacket, so neither description nor reason's code
+// byte is embedded in the signature, though both are set on it.
+func (k *PGPKeyBundle) GenerateRevocationCertificate(reason PGPRevocationReasonCode, description string) (armored string, err error) {
+	if k.PrivateKey == nil || k.PrivateKey.Encrypted {
+		return "", fmt.Errorf("GenerateRevocationCertificate: key %s is locked", k.GetFingerprint())
+	}
+
+	reasonByte := uint8(reason)
+	sig := &packet.Signature{
+		SigType:              packet.SigTypeKeyRevocation,
+		PubKeyAlgo:           k.PrivateKey.PubKeyAlgo,
+		Hash:                 (*packet.Config)(nil).Hash(),
+		CreationTime:         (*packet.Config)(nil).Now(),
+		IssuerKeyId:          &k.PrivateKey.KeyId,
+		RevocationReason:     &reasonByte,
+		RevocationReasonText: description,
+	}
+
+	h, err := pgpKeyRevocationHash(k.PrimaryKey, sig.Hash)
+	if err != nil {
+		return "", err
+	}
+	if err := sig.Sign(h, k.PrivateKey, nil); err != nil {
+		return "", err
+	}
+
+	return armorDetachedSignature(sig)
+}
+
+// GenerateSubkeyRevocationCertificate is GenerateRevocationCertificate for
+// one of k's subkeys rather than k's primary key: a standalone, armored
+// subkey revocation signature (RFC 4880 section 5.2.1, type 0x28) that can
+// be imported alongside the public key to revoke just that subkey, without
+// touching the primary key or any other subkey. index is an offset into
+// k.Subkeys.
+//
+// k must be an unlocked private bundle, since revoking a subkey is done
+// with the primary signing key, the same as binding one in the first
+// place.
+func (k *PGPKeyBundle) GenerateSubkeyRevocationCertificate(index int, reason PGPRevocationReasonCode, description string) (armored string, err error) {
+	if k.PrivateKey == nil || k.PrivateKey.Encrypted {
+		return "", fmt.Errorf("GenerateSubkeyRevocationCertificate: key %s is locked", k.GetFingerprint())
+	}
+	if index < 0 || index >= len(k.Subkeys) {
+		return "", fmt.Errorf("GenerateSubkeyRevocationCertificate: subkey index %d out of range", index)
+	}
+	subkey := k.Subkeys[index]
+
+	reasonByte := uint8(reason)
+	sig := &packet.Signature{
+		SigType:              packet.SigTypeSubkeyRevocation,
+		PubKeyAlgo:           k.PrivateKey.PubKeyAlgo,
+		Hash:                 (*packet.Config)(nil).Hash(),
+		CreationTime:         (*packet.Config)(nil).Now(),
+		IssuerKeyId:          &k.PrivateKey.KeyId,
+		RevocationReason:     &reasonByte,
+		RevocationReasonText: description,
+	}
+	if err := sig.SignKey(subkey.PublicKey, k.PrivateKey, nil); err != nil {
+		return "", err
+	}
+
+	return armorDetachedSignature(sig)
+}
+
+// armorDetachedSignature serializes and armors sig on its own, outside of
+// any enclosing key or message -- the form a revocation certificate takes
+// so it can be distributed and imported independently of the key it
+// revokes.
+func armorDetachedSignature(sig *packet.Signature) (string, error) {
+	var buf bytes.Buffer
+	aw, err := armor.Encode(&buf, openpgp.SignatureType, nil)
+	if err != nil {
+		return "", err
+	}
+	if err := sig.Serialize(aw); err != nil {
+		return "", err
+	}
+	if err := aw.Close(); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// armorCRC24Init/Poly/Mask and armorCRC24 mirror the unexported crc24 helper
+// in the vendored armor package: the OpenPGP CRC-24 (RFC 4880 section 6.1)
+// placed on an armor block's checksum line.
+const (
+	armorCRC24Init = 0xb704ce
+	armorCRC24Poly = 0x1864cfb
+	armorCRC24Mask = 0xffffff
+)
+
+func armorCRC24(crc uint32, d []byte) uint32 {
+	for _, b := range d {
+		crc ^= uint32(b) << 16
+		for i := 0; i < 8; i++ {
+			crc <<= 1
+			if crc&0x1000000 != 0 {
+				crc ^= armorCRC24Poly
+			}
+		}
+	}
+	return crc & armorCRC24Mask
+}
+
+// ComputeArmorChecksumCRC24 returns the OpenPGP CRC-24 over this key's raw,
+// unarmored public-key packet bytes -- the same value armor.Encode would
+// place on the checksum line of an exported ArmoredPublicKey. Useful for
+// diagnosing a checksum mismatch a tool reports on one of our exports.
+func (k *PGPKeyBundle) ComputeArmorChecksumCRC24() (uint32, error) {
+	var buf bytes.Buffer
+	if err := k.Entity.Serialize(&buf); err != nil {
+		return 0, err
+	}
+	return armorCRC24(armorCRC24Init, buf.Bytes()), nil
+}
+
+// VerifyArmorChecksum re-parses armored (a full ASCII-armored PGP block,
+// such as an ArmoredPublicKey) and confirms its embedded CRC-24 checksum
+// line matches the one recomputed from the base64-decoded body, returning
+// the mismatch/parse error (typically armor.ArmorCorrupt) if it doesn't.
+func VerifyArmorChecksum(armored string) error {
+	block, err := armor.Decode(strings.NewReader(armored))
+	if err != nil {
+		return err
+	}
+	_, err = io.Copy(ioutil.Discard, block.Body)
+	return err
+}
+
 func (k PGPKeyBundle) GetAlgoType() kbcrypto.AlgoType {
 	return kbcrypto.AlgoType(k.PrimaryKey.PubKeyAlgo)
 }
@@ -647,31 +1802,100 @@ func (k PGPKeyBundle) KeyDescription() string {
 
 func (k PGPKeyBundle) KeyInfo() (algorithm, kid, creation string) {
 	pubkey := k.PrimaryKey
+	algorithm = describeKeyAlgorithm(pubkey)
+	kid = pubkey.KeyIdString()
+	creation = pubkey.CreationTime.Format("2006-01-02")
+	return
+}
 
-	var typ string
-	switch pubkey.PubKeyAlgo {
-	case packet.PubKeyAlgoRSA, packet.PubKeyAlgoRSAEncryptOnly, packet.PubKeyAlgoRSASignOnly:
-		typ = "RSA"
-	case packet.PubKeyAlgoDSA:
-		typ = "DSA"
-	case packet.PubKeyAlgoECDSA:
-		typ = "ECDSA"
-	case packet.PubKeyAlgoEdDSA:
-		typ = "EdDSA"
-	default:
-		typ = "<UNKNOWN TYPE>"
+// describeKeyAlgorithm renders pub's algorithm and bit length (and, for
+// elliptic-curve keys, the curve name) the same way for a primary key
+// (KeyInfo) or a subkey (SubkeyInfo).
+func describeKeyAlgorithm(pub *packet.PublicKey) string {
+	typ := "<UNKNOWN TYPE>"
+	if desc, isPGP := pgpAlgoDescriptorFor(pub.PubKeyAlgo); isPGP {
+		typ = desc.name
 	}
 
-	bl, err := pubkey.BitLength()
+	bl, err := pub.BitLength()
 	if err != nil {
 		bl = 0
 	}
 
-	algorithm = fmt.Sprintf("%d-bit %s key", bl, typ)
-	kid = pubkey.KeyIdString()
-	creation = pubkey.CreationTime.Format("2006-01-02")
+	algorithm := fmt.Sprintf("%d-bit %s key", bl, typ)
+	if curve := curveName(pub); curve != "" {
+		algorithm = fmt.Sprintf("%s (%s)", algorithm, curve)
+	}
+	return algorithm
+}
 
-	return
+// SubkeyInfo describes one of a PGPKeyBundle's subkeys: its algorithm, bit
+// length, declared usage flags, and creation/expiration times. It's the
+// per-subkey counterpart to KeyInfo, letting a caller audit what each
+// subkey (e.g. an encryption subkey) is actually for without re-deriving
+// that from the raw openpgp.Subkey.
+type SubkeyInfo struct {
+	Algorithm string
+	KeyID     string
+	Flags     string
+	Created   time.Time
+	Expires   *time.Time
+}
+
+// SubkeyInfo returns a SubkeyInfo for each of k's subkeys, in the same
+// order as k.Subkeys.
+func (k PGPKeyBundle) SubkeyInfo() []SubkeyInfo {
+	infos := make([]SubkeyInfo, len(k.Subkeys))
+	for i, sub := range k.Subkeys {
+		info := SubkeyInfo{
+			Algorithm: describeKeyAlgorithm(sub.PublicKey),
+			KeyID:     sub.PublicKey.KeyIdString(),
+			Created:   sub.PublicKey.CreationTime,
+		}
+		if sub.Sig != nil {
+			info.Flags = keyUsageFlagLetters(sub.Sig)
+			if sub.Sig.KeyLifetimeSecs != nil {
+				expires := sub.Sig.CreationTime.Add(time.Duration(*sub.Sig.KeyLifetimeSecs) * time.Second)
+				info.Expires = &expires
+			}
+		}
+		infos[i] = info
+	}
+	return infos
+}
+
+// String renders a SubkeyInfo as a single line suitable for
+// VerboseDescription, e.g.:
+//
+//	subkey ID 0123456789ABCDEF, 4096-bit RSA key [E], created 2020-01-01, expires 2022-01-01
+func (s SubkeyInfo) String() string {
+	line := fmt.Sprintf("subkey ID %s, %s", s.KeyID, s.Algorithm)
+	if s.Flags != "" {
+		line += fmt.Sprintf(" [%s]", s.Flags)
+	}
+	line += fmt.Sprintf(", created %s", s.Created.Format("2006-01-02"))
+	if s.Expires != nil {
+		line += fmt.Sprintf(", expires %s", s.Expires.Format("2006-01-02"))
+	}
+	return line
+}
+
+// curveName returns the name of the elliptic curve backing pub, or "" if
+// pub isn't an elliptic-curve key. It exists because KeyInfo's algorithm
+// string otherwise can't tell a Curve25519 ECDH/EdDSA key apart from, say,
+// a NIST P-256 one -- both just read "ECDH" or "EdDSA".
+func curveName(pub *packet.PublicKey) string {
+	switch key := pub.PublicKey.(type) {
+	case *ecdsa.PublicKey:
+		return key.Curve.Params().Name
+	case *ecdh.PublicKey:
+		return key.Curve.Params().Name
+	case ed25519.PublicKey:
+		// This library only supports EdDSA over Curve25519.
+		return "Curve 25519"
+	default:
+		return ""
+	}
 }
 
 // Generates hash security warnings given a CKF
@@ -696,7 +1920,7 @@ func (k PGPKeyBundle) SecurityWarnings(kind HashSecurityWarningType) (warnings H
 	return
 }
 
-func unlockPrivateKey(k *packet.PrivateKey, pw string) error {
+func unlockPrivateKey(k *packet.PrivateKey, component PassphraseErrorComponent, pw string) error {
 	if !k.Encrypted {
 		return nil
 	}
@@ -704,7 +1928,10 @@ func unlockPrivateKey(k *packet.PrivateKey, pw string) error {
 	if err != nil && strings.HasSuffix(err.Error(), "private key checksum failure") {
 		// XXX this is gross, the openpgp library should return a better
 		// error if the PW was incorrectly specified
-		err = PassphraseError{}
+		err = PassphraseError{
+			KID:       binaryKIDFromPublicKey(&k.PublicKey).ToKID(),
+			Component: component,
+		}
 	}
 	return err
 }
@@ -724,31 +1951,97 @@ func (k *PGPKeyBundle) isAnyKeyEncrypted() bool {
 }
 
 func (k *PGPKeyBundle) unlockAllPrivateKeys(pw string) error {
-	if err := unlockPrivateKey(k.PrivateKey, pw); err != nil {
+	if err := unlockPrivateKey(k.PrivateKey, PassphraseErrorComponentPrimary, pw); err != nil {
 		return err
 	}
 	for _, subkey := range k.Subkeys {
-		if err := unlockPrivateKey(subkey.PrivateKey, pw); err != nil {
+		if err := unlockPrivateKey(subkey.PrivateKey, PassphraseErrorComponentSubkey, pw); err != nil {
 			return err
 		}
 	}
 	return nil
 }
 
-func (k *PGPKeyBundle) Unlock(m MetaContext, reason string, secretUI SecretUI) error {
+type pgpUnlockJob struct {
+	key       *packet.PrivateKey
+	component PassphraseErrorComponent
+}
+
+// unlockAllPrivateKeysConcurrent is unlockAllPrivateKeys for a key with
+// enough subkeys (or heavy enough S2K) that unlocking them one at a time is
+// noticeably slow: it unlocks the primary key and every subkey in parallel
+// instead. If secretUI also implements PGPUnlockProgressUI, it's told about
+// each unlock as it finishes and may abort the rest by returning true; m's
+// context is checked the same way, so canceling it has the same effect.
+// Cancellation can only take effect between key unlocks, since a single
+// packet.PrivateKey.Decrypt call isn't itself interruptible.
+func (k *PGPKeyBundle) unlockAllPrivateKeysConcurrent(m MetaContext, pw string, secretUI SecretUI) error {
+	jobs := make([]pgpUnlockJob, 0, len(k.Subkeys)+1)
+	jobs = append(jobs, pgpUnlockJob{k.PrivateKey, PassphraseErrorComponentPrimary})
+	for _, subkey := range k.Subkeys {
+		jobs = append(jobs, pgpUnlockJob{subkey.PrivateKey, PassphraseErrorComponentSubkey})
+	}
+
+	progress, reportsProgress := secretUI.(PGPUnlockProgressUI)
+
+	eg, ctx := errgroup.WithContext(m.Ctx())
+	var unlocked int32
+	for _, j := range jobs {
+		j := j
+		eg.Go(func() error {
+			if err := unlockPrivateKey(j.key, j.component, pw); err != nil {
+				return err
+			}
+			if reportsProgress {
+				n := int(atomic.AddInt32(&unlocked, 1))
+				if progress.PGPKeyUnlockProgress(n, len(jobs)) {
+					return InputCanceledError{}
+				}
+			}
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			default:
+				return nil
+			}
+		})
+	}
+
+	if err := eg.Wait(); err != nil {
+		if err == context.Canceled {
+			return InputCanceledError{}
+		}
+		return err
+	}
+	return nil
+}
+
+// Unlock decrypts k's private key material in place, prompting secretUI for
+// the passphrase as needed. If the user dismisses the prompt, Unlock returns
+// InputCanceledError unwrapped, so callers can treat that case as a quiet
+// no-op rather than logging it as a failure.
+func (k *PGPKeyBundle) Unlock(m MetaContext, reason string, secretUI SecretUI) (err error) {
 	if !k.isAnyKeyEncrypted() {
 		m.Debug("Key is not encrypted, skipping Unlock.")
 		return nil
 	}
+	// unlockAllPrivateKeysConcurrent unlocks subkeys in parallel, so a
+	// failure on one of them can leave others decrypted in k. Scrub them
+	// back to encrypted rather than returning an error while still holding
+	// live key material.
+	defer k.ScrubSecretsOnError(&err)
 
 	unlocker := func(pw string, _ bool) (ret GenericKey, err error) {
-		if err = k.unlockAllPrivateKeys(pw); err != nil {
+		if err = k.unlockAllPrivateKeysConcurrent(m, pw, secretUI); err != nil {
 			return nil, err
 		}
 		return k, nil
 	}
 
-	_, err := NewKeyUnlocker(5, reason, k.VerboseDescription(), PassphraseTypePGP, false, secretUI, unlocker).Run(m)
+	ku := NewKeyUnlocker(5, reason, k.VerboseDescription(), PassphraseTypePGP, false, secretUI, unlocker).
+		WithSecretStore(m.CurrentUsername(), pgpPassphraseStoreLabel(k.GetFingerprint())).
+		WithBiometricUnlock(fmt.Sprintf("%s.%s", m.CurrentUsername(), pgpPassphraseStoreLabel(k.GetFingerprint())))
+	_, err = ku.Run(m)
 	return err
 }
 
@@ -767,23 +2060,124 @@ func (k *PGPKeyBundle) CheckFingerprint(fp *PGPFingerprint) error {
 }
 
 func (k *PGPKeyBundle) SignToString(msg []byte) (sig string, id keybase1.SigIDBase, err error) {
-	if sig, id, err = SimpleSign(msg, *k); err != nil && k.GPGFallbackKey != nil {
+	if sig, id, err = SimpleSign(msg, *k, DefaultHashSecurityPolicy); err != nil && k.GPGFallbackKey != nil {
+		if gpgKey, ok := k.GPGFallbackKey.(*GPGKey); ok && gpgKey.G() != nil {
+			mctx := NewMetaContext(context.TODO(), gpgKey.G())
+			if capErr := gpgKey.CheckCapabilities(mctx); capErr != nil {
+				return "", id, capErr
+			}
+		}
 		return k.GPGFallbackKey.SignToString(msg)
 	}
 	return
 }
 
+// SignatureResult is one message's outcome from SignBatchToString: its
+// signature and SigID on success, or the error signing it hit. A failure on
+// one message doesn't stop the rest of the batch from being signed, so Err
+// is checked per-result rather than aborting the whole call.
+type SignatureResult struct {
+	Sig string
+	ID  keybase1.SigIDBase
+	Err error
+}
+
+// SignBatchToString signs each of msgs with k, the way a run of individual
+// SignToString calls would, but amortizes the per-call setup that's wasted
+// when signing several messages in a row: it decides once whether the
+// in-process key or the GPG fallback will be used, and if it's the fallback,
+// probes gpg's capabilities (and so its pinentry session) only once instead
+// of before every message. This is meant for sigchain multi-link operations
+// and bulk proof generation, which sign several payloads back to back.
+//
+// This isn't part of the GenericKey interface: the win here is specific to
+// PGP's GPGFallbackKey indirection, which NaCl keys don't have.
+func (k *PGPKeyBundle) SignBatchToString(msgs [][]byte) ([]SignatureResult, error) {
+	results := make([]SignatureResult, len(msgs))
+
+	if k.HasSecretKey() {
+		for i, msg := range msgs {
+			sig, id, err := SimpleSign(msg, *k, DefaultHashSecurityPolicy)
+			results[i] = SignatureResult{Sig: sig, ID: id, Err: err}
+		}
+		return results, nil
+	}
+
+	if k.GPGFallbackKey == nil {
+		err := NoSecretKeyError{}
+		for i := range msgs {
+			results[i] = SignatureResult{Err: err}
+		}
+		return results, nil
+	}
+
+	if gpgKey, ok := k.GPGFallbackKey.(*GPGKey); ok && gpgKey.G() != nil {
+		mctx := NewMetaContext(context.TODO(), gpgKey.G())
+		if err := gpgKey.CheckCapabilities(mctx); err != nil {
+			return nil, err
+		}
+	}
+
+	for i, msg := range msgs {
+		sig, id, err := k.GPGFallbackKey.SignToString(msg)
+		results[i] = SignatureResult{Sig: sig, ID: id, Err: err}
+	}
+	return results, nil
+}
+
 func (k PGPKeyBundle) VerifyStringAndExtract(ctx VerifyContext, sig string) (msg []byte, id keybase1.SigIDBase, err error) {
+	msg, id, _, err = k.VerifyStringAndExtractWithWarnings(ctx, sig)
+	return
+}
+
+// VerifyStringAndExtractWithWarnings is VerifyStringAndExtract, but also
+// surfaces any HashSecurityWarnings noticed while checking sig -- in
+// practice, just a HashSecurityWarningSignatureHash if sig was made with a
+// hash algorithm IsHashSecure considers insecure. Unlike
+// VerifyStringAndExtract, this isn't part of the GenericKey interface,
+// since the warning is specific to PGP's choice of digest algorithm and
+// doesn't apply to NaCl keys.
+func (k PGPKeyBundle) VerifyStringAndExtractWithWarnings(ctx VerifyContext, sig string) (msg []byte, id keybase1.SigIDBase, warnings HashSecurityWarnings, err error) {
 	var ps *ParsedSig
 	if ps, err = PGPOpenSig(sig); err != nil {
+		verifyFailure(ctx, VerifyFailureParseError, err.Error())
 		return
-	} else if err = ps.Verify(k); err != nil {
-		ctx.Debug("Failing key----------\n%s", k.ArmoredPublicKey)
-		ctx.Debug("Failing sig----------\n%s", sig)
+	}
+	verifyAttempt(ctx, k.GetFingerprint().String(), ps.ID())
+	if err = ps.Verify(k, hashSecurityPolicyFromContext(ctx)); err != nil {
+		ctx.Debug("Failing key: %s", Redact("armored-public-key", []byte(k.ArmoredPublicKey)))
+		ctx.Debug("Failing sig: %s", RedactString("sig", sig))
+		verifyFailure(ctx, classifyPGPVerifyFailure(err), err.Error())
 		return
 	}
 	msg = ps.LiteralData
 	id = ps.ID()
+	warnings = ps.Warnings
+	verifyWarning(ctx, k.GetFingerprint().String(), id, warnings)
+	return
+}
+
+// VerifyAndExtract is VerifyStringAndExtractWithWarnings's binary-input
+// counterpart, for signatures that arrive as a raw (non-armored) OpenPGP
+// packet stream, such as a detached .sig file produced by
+// `gpg --detach-sign` without --armor.
+func (k PGPKeyBundle) VerifyAndExtract(ctx VerifyContext, sig []byte) (msg []byte, id keybase1.SigIDBase, warnings HashSecurityWarnings, err error) {
+	var ps *ParsedSig
+	if ps, err = PGPOpenBinarySig(sig); err != nil {
+		verifyFailure(ctx, VerifyFailureParseError, err.Error())
+		return
+	}
+	verifyAttempt(ctx, k.GetFingerprint().String(), ps.ID())
+	if err = ps.Verify(k, hashSecurityPolicyFromContext(ctx)); err != nil {
+		ctx.Debug("Failing key: %s", Redact("armored-public-key", []byte(k.ArmoredPublicKey)))
+		ctx.Debug("Failing sig: %s", Redact("binary-sig", sig))
+		verifyFailure(ctx, classifyPGPVerifyFailure(err), err.Error())
+		return
+	}
+	msg = ps.LiteralData
+	id = ps.ID()
+	warnings = ps.Warnings
+	verifyWarning(ctx, k.GetFingerprint().String(), id, warnings)
 	return
 }
 
@@ -800,23 +2194,293 @@ func (k PGPKeyBundle) VerifyString(ctx VerifyContext, sig string, msg []byte) (i
 	return
 }
 
+// SignToWriterDetached is SignToString's streaming counterpart: it produces
+// a detached signature over r -- one that doesn't embed a copy of the
+// signed data the way SignToString's does -- and writes it to out, either
+// ASCII-armored or as a raw OpenPGP packet stream. Because the payload is
+// only ever read once through r and never collected into a []byte, this is
+// the one to use for signing something too large to hold in memory, such as
+// an upload stream. Verify the result with VerifyDetached.
+func (k *PGPKeyBundle) SignToWriterDetached(r io.Reader, out io.Writer, armored bool) (id keybase1.SigIDBase, err error) {
+	if !k.HasSecretKey() {
+		return id, NoSecretKeyError{}
+	}
+
+	var buf bytes.Buffer
+	if armored {
+		err = openpgp.ArmoredDetachSign(&buf, k.Entity, r, nil)
+	} else {
+		err = openpgp.DetachSign(&buf, k.Entity, r, nil)
+	}
+	if err != nil {
+		return id, err
+	}
+
+	if _, err = out.Write(buf.Bytes()); err != nil {
+		return id, err
+	}
+	return kbcrypto.ComputeSigIDFromSigBody(buf.Bytes()), nil
+}
+
+// VerifyDetached checks sig -- a detached signature produced by
+// SignToWriterDetached or by `gpg --detach-sign` (armored or not) -- against
+// signed, streaming the original message through instead of requiring it as
+// a []byte. It returns the same SigID that creating sig derived.
+func (k PGPKeyBundle) VerifyDetached(ctx VerifyContext, signed io.Reader, sig []byte) (id keybase1.SigIDBase, err error) {
+	id = kbcrypto.ComputeSigIDFromSigBody(sig)
+	verifyAttempt(ctx, k.GetFingerprint().String(), id)
+
+	keyring := openpgp.EntityList{k.Entity}
+	sigReader := bytes.NewReader(sig)
+
+	if strings.HasPrefix(strings.TrimSpace(string(sig)), pgpArmorHeaderPrefix) {
+		_, err = openpgp.CheckArmoredDetachedSignature(keyring, signed, sigReader)
+	} else {
+		_, err = openpgp.CheckDetachedSignature(keyring, signed, sigReader)
+	}
+	if err != nil {
+		verifyFailure(ctx, classifyPGPVerifyFailure(err), err.Error())
+		return id, err
+	}
+	return id, nil
+}
+
+// VerificationResult is the result of one verification in a VerifyMany
+// batch: either the message extracted from a verified signature, or the
+// error that made verification fail. Failure of one signature in a batch
+// doesn't abort the rest -- same per-result error handling as
+// SignatureResult for batch signing.
+type VerificationResult struct {
+	Msg []byte
+	ID  keybase1.SigIDBase
+	Err error
+}
+
+// VerifyMany verifies each of sigs against k concurrently, the way a run of
+// individual VerifyStringAndExtract calls would, but overlapping their
+// parse-and-hash work across goroutines instead of paying for it one
+// signature at a time. k's parsed key material (PrimaryKey, Subkeys) is
+// read-only once constructed, so it's safe to share across the batch this
+// way. This is meant for sigchain replay, which otherwise spends most of
+// its time verifying one detached PGP signature after another against the
+// same key.
+//
+// ctx is shared across all goroutines in the batch, so a VerifyContext
+// passed here (and any VerifyContextHooks it implements) must tolerate
+// concurrent calls.
+func (k PGPKeyBundle) VerifyMany(ctx VerifyContext, sigs []string) []VerificationResult {
+	results := make([]VerificationResult, len(sigs))
+
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, runtime.GOMAXPROCS(0))
+	for i, sig := range sigs {
+		wg.Add(1)
+		go func(i int, sig string) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			msg, id, _, err := k.VerifyStringAndExtractWithWarnings(ctx, sig)
+			results[i] = VerificationResult{Msg: msg, ID: id, Err: err}
+		}(i, sig)
+	}
+	wg.Wait()
+
+	return results
+}
+
+// pgpAlgoDescriptor records what we know about a PGP public-key algorithm
+// ID (RFC 4880 section 9.1, plus values registered since): a display name,
+// what it's capable of, and a note on how its "key size" should be read
+// (bit length isn't meaningful the same way for every algorithm).
+type pgpAlgoDescriptor struct {
+	name             string
+	canSign          bool
+	canEncrypt       bool
+	keySizeSemantics string
+}
+
+// pgpAlgoExperimentalRangeStart and pgpAlgoExperimentalRangeEnd bound the
+// RFC 4880 "Private/Experimental Use" public-key algorithm IDs. GPG and
+// other implementations sometimes emit keys in this range for algorithms
+// not yet formally registered; we don't know their capabilities, but we
+// know they're PGP.
+const (
+	pgpAlgoExperimentalRangeStart = 100
+	pgpAlgoExperimentalRangeEnd   = 110
+)
+
+// pgpAlgoTable is the single source of truth for classifying PGP public-key
+// algorithm IDs, used to derive both IsPGPAlgo and KeyInfo's display type.
+// IDs outside this table and outside the experimental range are assumed to
+// not be PGP at all.
+var pgpAlgoTable = map[packet.PublicKeyAlgorithm]pgpAlgoDescriptor{
+	// 0 isn't an RFC 4880 algorithm ID; we use it (kbcrypto.KIDPGPBase) as a
+	// sentinel for "this is a PGP key whose specific algorithm we don't
+	// track," e.g. for GPGKey, where GPG itself handles the algorithm.
+	0:                               {name: "unknown algorithm"},
+	packet.PubKeyAlgoRSA:            {name: "RSA", canSign: true, canEncrypt: true, keySizeSemantics: "modulus bits"},
+	packet.PubKeyAlgoRSAEncryptOnly: {name: "RSA", canSign: false, canEncrypt: true, keySizeSemantics: "modulus bits"},
+	packet.PubKeyAlgoRSASignOnly:    {name: "RSA", canSign: true, canEncrypt: false, keySizeSemantics: "modulus bits"},
+	packet.PubKeyAlgoElGamal:        {name: "ElGamal", canSign: false, canEncrypt: true, keySizeSemantics: "prime modulus bits"},
+	packet.PubKeyAlgoDSA:            {name: "DSA", canSign: true, canEncrypt: false, keySizeSemantics: "prime modulus bits"},
+	packet.PubKeyAlgoECDH:           {name: "ECDH", canSign: false, canEncrypt: true, keySizeSemantics: "curve order bits"},
+	packet.PubKeyAlgoECDSA:          {name: "ECDSA", canSign: true, canEncrypt: false, keySizeSemantics: "curve order bits"},
+	packet.PubKeyAlgoBadElGamal:     {name: "ElGamal", canSign: false, canEncrypt: true, keySizeSemantics: "prime modulus bits"},
+	packet.PubKeyAlgoEdDSA:          {name: "EdDSA", canSign: true, canEncrypt: false, keySizeSemantics: "curve order bits"},
+	// Newer AEAD-era algorithm IDs that GPG has started emitting, not yet
+	// present in the vendored openpgp/packet constants.
+	23: {name: "X25519", canSign: false, canEncrypt: true, keySizeSemantics: "fixed (Curve25519)"},
+	24: {name: "X448", canSign: false, canEncrypt: true, keySizeSemantics: "fixed (Curve448)"},
+	25: {name: "Ed25519", canSign: true, canEncrypt: false, keySizeSemantics: "fixed (Curve25519)"},
+	26: {name: "Ed448", canSign: true, canEncrypt: false, keySizeSemantics: "fixed (Curve448)"},
+}
+
+// pgpAlgoDescriptorFor looks up what we know about algo, returning
+// isPGP=false if algo isn't a PGP public-key algorithm at all (e.g. one of
+// Keybase's own NaCl KID types). Unknown IDs in the RFC 4880
+// private/experimental range are reported as PGP with a descriptor that
+// says so but claims no capabilities, since we can't know them.
+func pgpAlgoDescriptorFor(algo packet.PublicKeyAlgorithm) (desc pgpAlgoDescriptor, isPGP bool) {
+	if desc, ok := pgpAlgoTable[algo]; ok {
+		return desc, true
+	}
+	if algo >= pgpAlgoExperimentalRangeStart && algo <= pgpAlgoExperimentalRangeEnd {
+		return pgpAlgoDescriptor{name: "unknown algorithm"}, true
+	}
+	return pgpAlgoDescriptor{}, false
+}
+
 func IsPGPAlgo(algo kbcrypto.AlgoType) bool {
-	switch algo {
-	case kbcrypto.KIDPGPRsa, kbcrypto.KIDPGPElgamal, kbcrypto.KIDPGPDsa, kbcrypto.KIDPGPEcdh, kbcrypto.KIDPGPEcdsa, kbcrypto.KIDPGPBase, kbcrypto.KIDPGPEddsa:
-		return true
+	_, isPGP := pgpAlgoDescriptorFor(packet.PublicKeyAlgorithm(algo))
+	return isPGP
+}
+
+// splitEmailAddress splits em into a local part and a domain on the last
+// '@', returning ok=false for anything that isn't shaped like an email
+// address (no '@', or an empty local or domain part).
+func splitEmailAddress(em string) (local string, domain string, ok bool) {
+	idx := strings.LastIndex(em, "@")
+	if idx <= 0 || idx == len(em)-1 {
+		return "", "", false
 	}
-	return false
+	return em[:idx], em[idx+1:], true
+}
+
+// normalizeEmailDomain lowercases a domain using Unicode case folding and
+// applies IDNA/punycode normalization, so that "MÜNCHEN.de" and
+// "xn--mnchen-3ya.de" compare equal. If the domain isn't valid IDNA, it
+// falls back to a plain Unicode lowercasing rather than failing the
+// comparison outright.
+func normalizeEmailDomain(domain string) string {
+	ascii, err := idna.ToASCII(strings.ToLower(domain))
+	if err != nil {
+		return strings.ToLower(domain)
+	}
+	return ascii
+}
+
+// EmailsEqual compares two email addresses the way PGP identity matching
+// wants: the domain is Unicode case-folded and IDNA/punycode-normalized, so
+// internationalized domains written in different forms (or cases) compare
+// equal. The local part is compared byte-for-byte unless foldLocal is set,
+// since RFC 5321 technically makes the local part case-sensitive and most
+// providers that ignore case will have already normalized it at signup.
+// Malformed addresses (missing or misplaced '@') compare unequal rather
+// than erroring.
+func EmailsEqual(e1, e2 string, foldLocal bool) bool {
+	local1, domain1, ok1 := splitEmailAddress(e1)
+	local2, domain2, ok2 := splitEmailAddress(e2)
+	if !ok1 || !ok2 {
+		return false
+	}
+	if foldLocal {
+		if !strings.EqualFold(local1, local2) {
+			return false
+		}
+	} else if local1 != local2 {
+		return false
+	}
+	return normalizeEmailDomain(domain1) == normalizeEmailDomain(domain2)
+}
+
+// ValidateUIDEmailSyntax checks every identity's UID for a syntactically
+// valid email address, returning one error per identity that fails. It
+// doesn't reject identities with no email at all (common on older, legacy
+// UIDs) -- only ones that have something after '<' that doesn't look like
+// an email. Callers that want to warn on import rather than fail it can
+// push the results through the Warnings mechanism returned alongside the
+// key (e.g. finishReadOne).
+func (k *PGPKeyBundle) ValidateUIDEmailSyntax() (errs []error) {
+	for uid, ident := range k.Identities {
+		i, err := ParseIdentity(ident.Name)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("UID %q: %s", uid, err))
+			continue
+		}
+		if len(i.Email) == 0 {
+			continue
+		}
+		if _, _, ok := splitEmailAddress(i.Email); !ok {
+			errs = append(errs, fmt.Errorf("UID %q: malformed email %q", uid, i.Email))
+		}
+	}
+	return errs
 }
 
 func (k *PGPKeyBundle) FindEmail(em string) bool {
 	for _, ident := range k.Identities {
-		if i, e := ParseIdentity(ident.Name); e == nil && i.Email == em {
+		if i, e := ParsePGPUserID(ident.Name); e == nil && EmailsEqual(i.Email, em, false) {
 			return true
 		}
 	}
 	return false
 }
 
+// MatchEmailDomain returns the UID strings of identities on this key whose
+// email's domain matches domain, case-insensitively (and IDNA-normalized,
+// per normalizeEmailDomain). If includeSubdomains is true, an identity at
+// "user@mail.company.com" also matches domain "company.com". Identities
+// that don't parse or have no email are skipped. This backs an org-wide
+// "keys claiming @company.com" report.
+func (k *PGPKeyBundle) MatchEmailDomain(domain string, includeSubdomains bool) []string {
+	domain = normalizeEmailDomain(domain)
+	var ret []string
+	for _, ident := range k.Identities {
+		i, err := ParseIdentity(ident.Name)
+		if err != nil || len(i.Email) == 0 {
+			continue
+		}
+		_, identDomain, ok := splitEmailAddress(i.Email)
+		if !ok {
+			continue
+		}
+		identDomain = normalizeEmailDomain(identDomain)
+		if identDomain == domain {
+			ret = append(ret, ident.Name)
+			continue
+		}
+		if includeSubdomains && strings.HasSuffix(identDomain, "."+domain) {
+			ret = append(ret, ident.Name)
+		}
+	}
+	return ret
+}
+
+// IdentitiesByEmail returns the OpenPGP identities on this key whose email
+// matches em, per EmailsEqual. There can be more than one, since a key can
+// carry several UIDs with the same address (e.g. re-added after a
+// revocation).
+func (k *PGPKeyBundle) IdentitiesByEmail(em string, foldLocal bool) []*openpgp.Identity {
+	var ret []*openpgp.Identity
+	for _, ident := range k.Identities {
+		if i, e := ParseIdentity(ident.Name); e == nil && EmailsEqual(i.Email, em, foldLocal) {
+			ret = append(ret, ident)
+		}
+	}
+	return ret
+}
+
 func (k *PGPKeyBundle) IdentityNames() []string {
 	var names []string
 	for _, ident := range k.Identities {
@@ -833,6 +2497,52 @@ func (k *PGPKeyBundle) GetPGPIdentities() []keybase1.PGPIdentity {
 	return ret
 }
 
+// PGPNotation is a single notation-data name/value pair found in a
+// self-signature. See RFC 4880, section 5.2.3.16.
+type PGPNotation struct {
+	Name  string
+	Value string
+}
+
+// SubpacketAuditEntry holds the notation-data and preferred-key-server
+// subpackets carried by one identity's self-signature.
+type SubpacketAuditEntry struct {
+	Identity           string
+	Notations          []PGPNotation
+	PreferredKeyServer string
+}
+
+// SubpacketAudit lists the notation-data and preferred-key-server
+// subpackets embedded in each identity's self-signature, for the "advanced
+// key details" view. Identities with no such subpackets get an entry with
+// empty fields rather than being skipped.
+func (k *PGPKeyBundle) SubpacketAudit() []SubpacketAuditEntry {
+	ret := make([]SubpacketAuditEntry, 0, len(k.Identities))
+	for _, ident := range k.Identities {
+		entry := SubpacketAuditEntry{Identity: ident.Name}
+		if ident.SelfSignature != nil {
+			for _, nd := range ident.SelfSignature.NotationData {
+				entry.Notations = append(entry.Notations, PGPNotation{Name: nd.Name, Value: nd.Value})
+			}
+			entry.PreferredKeyServer = ident.SelfSignature.PreferredKeyServer
+		}
+		ret = append(ret, entry)
+	}
+	return ret
+}
+
+// GetPhotoUIDs returns the raw JFIF bytes of every photo ID (RFC 4880,
+// section 5.12 user attribute packet) carried by the key, in key order.
+// Only attribute packets with a verified self-signature are included,
+// since those are the only ones ReadEntity keeps around.
+func (k *PGPKeyBundle) GetPhotoUIDs() [][]byte {
+	var ret [][]byte
+	for _, attr := range k.UserAttributes {
+		ret = append(ret, attr.Contents.ImageData()...)
+	}
+	return ret
+}
+
 // CheckIdentity finds the foo_user@keybase.io PGP identity and figures out when it
 // was created and when it's slated to expire. We plan to start phasing out use of
 // PGP-specified Expiration times as far as sigchain walking is concerned. But for now,
@@ -840,7 +2550,7 @@ func (k *PGPKeyBundle) GetPGPIdentities() []keybase1.PGPIdentity {
 func (k *PGPKeyBundle) CheckIdentity(kbid Identity) (match bool, ctime int64, etime int64) {
 	ctime, etime = -1, -1
 	for _, pgpIdentity := range k.Identities {
-		if Cicmp(pgpIdentity.UserId.Email, kbid.Email) {
+		if EmailsEqual(pgpIdentity.UserId.Email, kbid.Email, false) {
 			match = true
 			ctime = pgpIdentity.SelfSignature.CreationTime.Unix()
 			// This is a special case in OpenPGP, so we used KeyLifetimeSecs
@@ -857,25 +2567,87 @@ func (k *PGPKeyBundle) CheckIdentity(kbid Identity) (match bool, ctime int64, et
 	return
 }
 
-// EncryptToString fails for this type of key, since we haven't implemented it yet
+// EncryptToString PGP-encrypts plaintext to k -- the underlying openpgp
+// library picks whichever of k's encryption-capable subkeys (or, lacking
+// one, k's primary key) it considers best -- and returns the result as an
+// ASCII-armored PGP message. If sender is given, it must be a
+// *PGPKeyBundle with an unlocked private key, and the message is signed
+// with it along the way.
 func (k *PGPKeyBundle) EncryptToString(plaintext []byte, sender GenericKey) (ciphertext string, err error) {
-	err = KeyCannotEncryptError{}
-	return
+	if !k.CanEncrypt() {
+		return "", KeyCannotEncryptError{}
+	}
+
+	var signer *PGPKeyBundle
+	if sender != nil {
+		var ok bool
+		if signer, ok = sender.(*PGPKeyBundle); !ok {
+			return "", fmt.Errorf("EncryptToString: sender must be a PGP key, got %T", sender)
+		}
+	}
+
+	var buf bytes.Buffer
+	aw, err := armor.Encode(&buf, "PGP MESSAGE", nil)
+	if err != nil {
+		return "", err
+	}
+	if err = PGPEncrypt(bytes.NewReader(plaintext), aw, signer, []*PGPKeyBundle{k}); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
 }
 
-// DecryptFromString fails for this type of key, since we haven't implemented it yet
+// DecryptFromString decrypts an ASCII-armored PGP message produced by
+// EncryptToString, using k's private key. Unlike NaclDHKeyPair's
+// DecryptFromString, the returned sender KID will usually be empty: a PGP
+// signature only carries the signer's 64-bit key ID, not enough to
+// reconstruct a full Keybase KID without an external keyring to resolve
+// it against, and k (the recipient) is the only key this method has to
+// check the signature against.
 func (k *PGPKeyBundle) DecryptFromString(ciphertext string) (msg []byte, sender keybase1.KID, err error) {
-	err = KeyCannotDecryptError{}
-	return
+	if !k.CanDecrypt() {
+		return nil, sender, KeyCannotDecryptError{}
+	}
+
+	block, err := armor.Decode(strings.NewReader(ciphertext))
+	if err != nil {
+		return nil, sender, err
+	}
+
+	md, err := openpgp.ReadMessage(block.Body, openpgp.EntityList{k.Entity}, nil, nil)
+	if err != nil {
+		if err == pgperrors.ErrKeyIncorrect {
+			return nil, sender, NoDecryptionKeyError{Msg: "unable to find a PGP decryption key for this message"}
+		}
+		return nil, sender, err
+	}
+
+	msg, err = ioutil.ReadAll(md.UnverifiedBody)
+	if err != nil {
+		return nil, sender, err
+	}
+
+	if md.IsSigned && md.SignedBy != nil && md.SignedBy.Entity == k.Entity {
+		// The only entity in our keyring is k itself, so the only
+		// signature ReadMessage could have resolved a key for is one
+		// where the sender and recipient are the same key.
+		sender = k.GetKID()
+	}
+
+	return msg, sender, nil
 }
 
-// CanEncrypt returns false for now, since we haven't implemented PGP encryption of packets
-// for metadata operations
-func (k *PGPKeyBundle) CanEncrypt() bool { return false }
+// CanEncrypt reports whether k has a primary key or subkey flagged for
+// encryption, using the same usage flags as PrimaryKeyFlags/subkeyFlags.
+func (k *PGPKeyBundle) CanEncrypt() bool {
+	return strings.Contains(k.PrimaryKeyFlags(), "E") || strings.Contains(k.subkeyFlags(), "E")
+}
 
-// CanDecrypt returns false for now, since we haven't implemented PGP encryption of packets
-// for metadata operations
-func (k *PGPKeyBundle) CanDecrypt() bool { return false }
+// CanDecrypt reports whether k can encrypt and also has the private
+// key material needed to decrypt what it encrypts.
+func (k *PGPKeyBundle) CanDecrypt() bool {
+	return k.CanEncrypt() && k.HasSecretKey()
+}
 
 func (k *PGPKeyBundle) ExportPublicAndPrivate() (public RawPublicKey, private RawPrivateKey, err error) {
 	var publicKey, privateKey bytes.Buffer
@@ -942,12 +2714,53 @@ func (p PGPFingerprint) GetProofType() keybase1.ProofType {
 
 //===================================================
 
+// PGPEncryptOptions controls the S2K (string-to-key) hardening EncryptPGPKey
+// applies to a passphrase-protected secret key export. The zero value,
+// DefaultPGPEncryptOptions, reproduces this package's long-standing
+// behavior: go-crypto's own default iteration count and AES-128.
+//
+// Argon2 isn't an option here: the vendored OpenPGP library only
+// implements RFC 4880's iterated-and-salted S2K, which has no Argon2 mode
+// to select.
+type PGPEncryptOptions struct {
+	// S2KCount is the passphrase-stretching iteration count, per
+	// packet.Config.S2KCount's rules (0 leaves it at go-crypto's default of
+	// 65536; valid range is 1024-65011712).
+	S2KCount int
+
+	// StrongCipher encrypts with AES-256 instead of go-crypto's default,
+	// AES-128.
+	StrongCipher bool
+}
+
+// DefaultPGPEncryptOptions is used by every EncryptPGPKey call site that
+// hasn't opted into stronger S2K hardening.
+var DefaultPGPEncryptOptions = PGPEncryptOptions{}
+
+func (o PGPEncryptOptions) packetConfig() *packet.Config {
+	if o.S2KCount == 0 && !o.StrongCipher {
+		return nil
+	}
+	config := &packet.Config{S2KCount: o.S2KCount}
+	if o.StrongCipher {
+		config.DefaultCipher = packet.CipherAES256
+	}
+	return config
+}
+
 func EncryptPGPKey(bundle *openpgp.Entity, passphrase string) error {
+	return EncryptPGPKeyWithOptions(bundle, passphrase, DefaultPGPEncryptOptions)
+}
+
+// EncryptPGPKeyWithOptions is EncryptPGPKey with S2K hardening opts applied
+// to every private key packet it encrypts.
+func EncryptPGPKeyWithOptions(bundle *openpgp.Entity, passphrase string, opts PGPEncryptOptions) error {
 	passBytes := []byte(passphrase)
+	config := opts.packetConfig()
 
 	if bundle.PrivateKey != nil && bundle.PrivateKey.PrivateKey != nil {
 		// Primary private key exists and is not stubbed.
-		if err := bundle.PrivateKey.Encrypt(passBytes, nil); err != nil {
+		if err := bundle.PrivateKey.Encrypt(passBytes, config); err != nil {
 			return err
 		}
 	}
@@ -958,10 +2771,36 @@ func EncryptPGPKey(bundle *openpgp.Entity, passphrase string) error {
 			continue
 		}
 
-		if err := subkey.PrivateKey.Encrypt(passBytes, nil); err != nil {
+		if err := subkey.PrivateKey.Encrypt(passBytes, config); err != nil {
 			return err
 		}
 	}
 
 	return nil
 }
+
+// ReEncrypt decrypts k's private key material under oldPassphrase and
+// re-encrypts it under newPassphrase with opts, e.g. to rotate a PGP key's
+// export passphrase or to harden an already-exported key's S2K settings.
+// k must hold unstubbed secret key material; on a wrong oldPassphrase it
+// returns a PassphraseError and leaves k unchanged.
+func (k *PGPKeyBundle) ReEncrypt(oldPassphrase, newPassphrase string, opts PGPEncryptOptions) (err error) {
+	if !k.HasSecretKey() {
+		return NoSecretKeyError{}
+	}
+	// unlockAllPrivateKeys unlocks subkeys one at a time, so a failure
+	// partway through (e.g. a subkey using a different passphrase) can
+	// leave the primary key decrypted even though we're returning an
+	// error. Scrub it back rather than breaking the "leaves k unchanged"
+	// contract above.
+	defer k.ScrubSecretsOnError(&err)
+
+	if err := k.unlockAllPrivateKeys(oldPassphrase); err != nil {
+		return err
+	}
+	if err := EncryptPGPKeyWithOptions(k.Entity, newPassphrase, opts); err != nil {
+		return err
+	}
+	k.Invalidate()
+	return nil
+}
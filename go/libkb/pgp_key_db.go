@@ -0,0 +1,257 @@
+// Copyright 2015 Keybase, Inc. All rights reserved. Use of
+// this source code is governed by the included BSD license.
+
+package libkb
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	keybase1 "github.com/keybase/client/go/protocol/keybase1"
+)
+
+// pgpKeyDbRow is the versioned envelope a DBPGPKey row is stored as from v1
+// on. v0 rows predate this envelope entirely: the row is just a bare
+// JSON-encoded armored-key string, with no version marker at all.
+type pgpKeyDbRow struct {
+	Version int    `json:"v"`
+	Key     string `json:"key"`
+}
+
+// pgpKeyDbCurrentVersion is the envelope version this client writes. Bump it
+// (and add a migration below) whenever the stored shape changes -- e.g. the
+// planned TTL envelope and fingerprint index.
+const pgpKeyDbCurrentVersion = 1
+
+// pgpKeyDbMigrations upgrades a row one version at a time, keyed by the
+// version it upgrades *from*. Each entry takes the raw bytes of its version
+// and returns the raw bytes of the next version up.
+var pgpKeyDbMigrations = map[int]func(raw []byte) ([]byte, error){
+	0: migratePGPKeyDbRowV0,
+}
+
+// migratePGPKeyDbRowV0 wraps a v0 bare-string row in the v1 envelope.
+func migratePGPKeyDbRowV0(raw []byte) ([]byte, error) {
+	var armored string
+	if err := json.Unmarshal(raw, &armored); err != nil {
+		return nil, err
+	}
+	return json.Marshal(pgpKeyDbRow{Version: 1, Key: armored})
+}
+
+// pgpKeyDbRowVersion sniffs the version of a raw row without fully decoding
+// it: a v0 row is a bare JSON string, so it has no "v" field to read.
+func pgpKeyDbRowVersion(raw []byte) (int, error) {
+	var probe struct {
+		Version *int `json:"v"`
+	}
+	if err := json.Unmarshal(raw, &probe); err != nil {
+		return 0, err
+	}
+	if probe.Version == nil {
+		return 0, nil
+	}
+	return *probe.Version, nil
+}
+
+// encodeCurrentPGPKeyDbRow wraps armored in the envelope this client writes.
+func encodeCurrentPGPKeyDbRow(armored string) ([]byte, error) {
+	return json.Marshal(pgpKeyDbRow{Version: pgpKeyDbCurrentVersion, Key: armored})
+}
+
+// upgradePGPKeyDbRow lazily walks raw forward through pgpKeyDbMigrations up
+// to pgpKeyDbCurrentVersion. A row from a version newer than this client
+// understands (or one this client has no migration path for) comes back as
+// ok=false -- never an error -- since the caller should treat that as a
+// cache miss and refetch, not fail outright.
+func upgradePGPKeyDbRow(raw []byte) (upgraded []byte, changed bool, ok bool) {
+	version, err := pgpKeyDbRowVersion(raw)
+	if err != nil {
+		return nil, false, false
+	}
+	for version < pgpKeyDbCurrentVersion {
+		migrate, found := pgpKeyDbMigrations[version]
+		if !found {
+			return nil, false, false
+		}
+		raw, err = migrate(raw)
+		if err != nil {
+			return nil, false, false
+		}
+		version++
+		changed = true
+	}
+	if version > pgpKeyDbCurrentVersion {
+		// A future version we don't know how to read.
+		return nil, false, false
+	}
+	return raw, changed, true
+}
+
+// decodePGPKeyDbRow extracts the armored key out of a stored row, lazily
+// upgrading it to the current envelope version first. ok is false on a
+// corrupt row or one from a version this client doesn't understand; callers
+// should treat that the same as a cache miss.
+func decodePGPKeyDbRow(raw []byte) (armored string, upgraded []byte, changed bool, ok bool) {
+	upgraded, changed, ok = upgradePGPKeyDbRow(raw)
+	if !ok {
+		return "", nil, false, false
+	}
+	var row pgpKeyDbRow
+	if err := json.Unmarshal(upgraded, &row); err != nil {
+		return "", nil, false, false
+	}
+	return row.Key, upgraded, changed, true
+}
+
+// MigratePGPKeyDbRows walks every DBPGPKey row and rewrites any that aren't
+// on pgpKeyDbCurrentVersion yet, so the lazy per-row migration in
+// LoadPGPKeyFromLocalDB doesn't have to do it one row at a time forever.
+// It's meant to be run as a one-shot repair, the same way DbClean sweeps
+// stale rows. Corrupt or future-version rows are left alone -- they'll
+// still degrade to a cache miss on read.
+func MigratePGPKeyDbRows(mctx MetaContext) (migrated int, err error) {
+	defer mctx.Trace("MigratePGPKeyDbRows", &err)()
+
+	prefix := []byte(fmt.Sprintf("%s:%02x:", levelDbTableKv, byte(DBPGPKey)))
+	dbKeySet, err := mctx.G().LocalDb.KeysWithPrefixes(prefix)
+	if err != nil {
+		return 0, err
+	}
+	dbKeys := make([]DbKey, 0, len(dbKeySet))
+	for dbKey := range dbKeySet {
+		dbKeys = append(dbKeys, dbKey)
+	}
+
+	values, found, err := mctx.G().LocalDb.GetMany(dbKeys)
+	if err != nil {
+		return 0, err
+	}
+
+	items := make([]DbPutManyItem, 0, len(dbKeys))
+	for i, dbKey := range dbKeys {
+		if !found[i] {
+			continue
+		}
+		_, upgraded, changed, ok := decodePGPKeyDbRow(values[i])
+		if !ok || !changed {
+			continue
+		}
+		items = append(items, DbPutManyItem{Key: dbKey, Value: upgraded})
+	}
+	if len(items) == 0 {
+		return 0, nil
+	}
+
+	if err := mctx.G().LocalDb.PutMany(items); err != nil {
+		return 0, err
+	}
+	return len(items), nil
+}
+
+// pgpKeyDbKey namespaces a DBPGPKey row by the active user's UID. LocalDb is
+// shared by every account that's ever used this service instance, and
+// DBPGPKey rows used to be keyed only by KID -- so a row written while
+// logged in as one user could be read back (and trusted) after switching to
+// another. Namespacing by UID keeps each account's cache isolated.
+func pgpKeyDbKey(mctx MetaContext, kid keybase1.KID) DbKey {
+	return pgpKeyDbKeyForUID(mctx.CurrentUID(), kid)
+}
+
+func pgpKeyDbKeyForUID(uid keybase1.UID, kid keybase1.KID) DbKey {
+	return DbKey{Typ: DBPGPKey, Key: fmt.Sprintf("%s:%s", uid, kid)}
+}
+
+// legacyPGPKeyDbKey is the pre-namespacing row shape. It's only ever read,
+// as a one-time migration, and never written again.
+func legacyPGPKeyDbKey(kid keybase1.KID) DbKey {
+	return DbKey{Typ: DBPGPKey, Key: kid.String()}
+}
+
+// migrateLegacyPGPKeyDbRow moves a pre-namespacing row (if any) under the
+// current user's namespace the first time it's looked up, and drops the
+// legacy row so a different account can't pick it up later.
+func migrateLegacyPGPKeyDbRow(mctx MetaContext, kid keybase1.KID) {
+	legacy := legacyPGPKeyDbKey(kid)
+	raw, found, err := mctx.G().LocalDb.GetRaw(legacy)
+	if err != nil || !found {
+		return
+	}
+	if err := mctx.G().LocalDb.PutRaw(pgpKeyDbKey(mctx, kid), raw); err != nil {
+		mctx.Debug("| migrateLegacyPGPKeyDbRow: failed to namespace row for %s: %s", kid, err)
+		return
+	}
+	if err := mctx.G().LocalDb.Delete(legacy); err != nil {
+		mctx.Debug("| migrateLegacyPGPKeyDbRow: failed to drop legacy row for %s: %s", kid, err)
+	}
+}
+
+// pgpKeyDbUIDPrefix is the LocalDb key prefix covering every DBPGPKey row
+// namespaced under uid, shared by ListStoredPGPKeys and
+// PGPKeyDBLogoutHook.OnLogout.
+func pgpKeyDbUIDPrefix(uid keybase1.UID) []byte {
+	return []byte(fmt.Sprintf("%s:%02x:%s:", levelDbTableKv, byte(DBPGPKey), uid))
+}
+
+// ListStoredPGPKeys returns the KIDs of every PGP key StoreToLocalDb has
+// cached under the current user's namespace.
+func (g *GlobalContext) ListStoredPGPKeys(mctx MetaContext) ([]keybase1.KID, error) {
+	uid := mctx.CurrentUID()
+	if uid.IsNil() {
+		return nil, nil
+	}
+	dbKeys, err := g.LocalDb.KeysWithPrefixes(pgpKeyDbUIDPrefix(uid))
+	if err != nil {
+		return nil, err
+	}
+	kids := make([]keybase1.KID, 0, len(dbKeys))
+	for dbKey := range dbKeys {
+		parts := strings.SplitN(dbKey.Key, ":", 2)
+		if len(parts) != 2 {
+			continue
+		}
+		kids = append(kids, keybase1.KIDFromString(parts[1]))
+	}
+	return kids, nil
+}
+
+// DeleteStoredPGPKey removes kid's cached row, if any, from the current
+// user's namespaced LocalDb cache.
+func (g *GlobalContext) DeleteStoredPGPKey(mctx MetaContext, kid keybase1.KID) error {
+	return g.LocalDb.Delete(pgpKeyDbKey(mctx, kid))
+}
+
+// PGPKeyDBLogoutHook optionally purges a user's namespaced PGP key cache
+// rows on logout. It's off by default, since namespacing alone is enough to
+// stop cross-account bleed; turn it on for contexts (like device
+// deprovisioning) that want the rows gone rather than merely inert.
+type PGPKeyDBLogoutHook struct {
+	PurgeOnLogout bool
+}
+
+var _ LogoutHook = (*PGPKeyDBLogoutHook)(nil)
+
+func NewPGPKeyDBLogoutHook(purgeOnLogout bool) *PGPKeyDBLogoutHook {
+	return &PGPKeyDBLogoutHook{PurgeOnLogout: purgeOnLogout}
+}
+
+func (h *PGPKeyDBLogoutHook) OnLogout(mctx MetaContext) error {
+	if !h.PurgeOnLogout {
+		return nil
+	}
+	uid := mctx.CurrentUID()
+	if uid.IsNil() {
+		return nil
+	}
+	keys, err := mctx.G().LocalDb.KeysWithPrefixes(pgpKeyDbUIDPrefix(uid))
+	if err != nil {
+		return err
+	}
+	for dbKey := range keys {
+		if err := mctx.G().LocalDb.Delete(dbKey); err != nil {
+			mctx.Debug("| PGPKeyDBLogoutHook: failed to delete %+v: %s", dbKey, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,184 @@
+// Copyright 2015 Keybase, Inc. All rights reserved. Use of
+// this source code is governed by the included BSD license.
+
+package libkb
+
+import (
+	"encoding/json"
+	"testing"
+
+	keybase1 "github.com/keybase/client/go/protocol/keybase1"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPGPKeyDbKeyNamespacedByUID(t *testing.T) {
+	kid := keybase1.KID("0101010101010101010101010101010101010101010101010101010101010101010101010101010101010101010101010101010101010101010101010101010101")
+	alice := keybase1.UID("a1a1a1a1a1a1a1a1a1a1a1a1a1a1a100")
+	bob := keybase1.UID("b2b2b2b2b2b2b2b2b2b2b2b2b2b2b200")
+
+	require.NotEqual(t, pgpKeyDbKeyForUID(alice, kid), pgpKeyDbKeyForUID(bob, kid),
+		"two accounts should not share a PGP key cache row")
+}
+
+func TestDecodePGPKeyDbRowV1(t *testing.T) {
+	raw, err := encodeCurrentPGPKeyDbRow("armored-key-material")
+	require.NoError(t, err)
+
+	armored, _, changed, ok := decodePGPKeyDbRow(raw)
+	require.True(t, ok)
+	require.False(t, changed, "a row already on the current version needs no rewrite")
+	require.Equal(t, "armored-key-material", armored)
+}
+
+func TestDecodePGPKeyDbRowV0Upgrade(t *testing.T) {
+	raw, err := json.Marshal("armored-key-material")
+	require.NoError(t, err)
+
+	armored, upgraded, changed, ok := decodePGPKeyDbRow(raw)
+	require.True(t, ok)
+	require.True(t, changed, "a v0 row should be upgraded")
+	require.Equal(t, "armored-key-material", armored)
+
+	// The upgraded bytes should decode cleanly as the current version, with
+	// nothing further to do.
+	armored2, _, changed2, ok2 := decodePGPKeyDbRow(upgraded)
+	require.True(t, ok2)
+	require.False(t, changed2)
+	require.Equal(t, "armored-key-material", armored2)
+}
+
+func TestDecodePGPKeyDbRowFutureVersion(t *testing.T) {
+	raw, err := json.Marshal(pgpKeyDbRow{Version: pgpKeyDbCurrentVersion + 1, Key: "armored-key-material"})
+	require.NoError(t, err)
+
+	_, _, _, ok := decodePGPKeyDbRow(raw)
+	require.False(t, ok, "a row from a future version should look like a miss, not an error")
+}
+
+func TestDecodePGPKeyDbRowCorrupt(t *testing.T) {
+	_, _, _, ok := decodePGPKeyDbRow([]byte("not json at all"))
+	require.False(t, ok)
+}
+
+func TestLoadPGPKeyFromLocalDBUpgradesAndMigrates(t *testing.T) {
+	tc := SetupTest(t, "pgpkeydb", 1)
+	defer tc.Cleanup()
+
+	mctx := NewMetaContextForTest(tc)
+	kid := keybase1.KID("0303030303030303030303030303030303030303030303030303030303030303030303030303030303030303030303030303030303030303030303030303030303")
+
+	legacyRaw, err := json.Marshal(issue454Keys)
+	require.NoError(t, err)
+	require.NoError(t, tc.G.LocalDb.PutRaw(pgpKeyDbKey(mctx, kid), legacyRaw))
+
+	bundle, err := LoadPGPKeyFromLocalDB(mctx, kid)
+	require.NoError(t, err)
+	require.NotNil(t, bundle)
+
+	raw, found, err := tc.G.LocalDb.GetRaw(pgpKeyDbKey(mctx, kid))
+	require.NoError(t, err)
+	require.True(t, found)
+	version, err := pgpKeyDbRowVersion(raw)
+	require.NoError(t, err)
+	require.Equal(t, pgpKeyDbCurrentVersion, version, "reading a v0 row should write back the upgraded version")
+
+	migrated, err := MigratePGPKeyDbRows(mctx)
+	require.NoError(t, err)
+	require.Equal(t, 0, migrated, "the row is already on the current version")
+}
+
+func TestMigratePGPKeyDbRowsBulk(t *testing.T) {
+	tc := SetupTest(t, "pgpkeydb", 1)
+	defer tc.Cleanup()
+
+	mctx := NewMetaContextForTest(tc)
+	oldKID := keybase1.KID("0404040404040404040404040404040404040404040404040404040404040404040404040404040404040404040404040404040404040404040404040404040404")
+	currentKID := keybase1.KID("0505050505050505050505050505050505050505050505050505050505050505050505050505050505050505050505050505050505050505050505050505050505")
+	futureKID := keybase1.KID("0606060606060606060606060606060606060606060606060606060606060606060606060606060606060606060606060606060606060606060606060606060606")
+
+	legacyRaw, err := json.Marshal("old-armored-key")
+	require.NoError(t, err)
+	require.NoError(t, tc.G.LocalDb.PutRaw(pgpKeyDbKey(mctx, oldKID), legacyRaw))
+
+	currentRaw, err := encodeCurrentPGPKeyDbRow("current-armored-key")
+	require.NoError(t, err)
+	require.NoError(t, tc.G.LocalDb.PutRaw(pgpKeyDbKey(mctx, currentKID), currentRaw))
+
+	futureRaw, err := json.Marshal(pgpKeyDbRow{Version: pgpKeyDbCurrentVersion + 1, Key: "future-armored-key"})
+	require.NoError(t, err)
+	require.NoError(t, tc.G.LocalDb.PutRaw(pgpKeyDbKey(mctx, futureKID), futureRaw))
+
+	migrated, err := MigratePGPKeyDbRows(mctx)
+	require.NoError(t, err)
+	require.Equal(t, 1, migrated, "only the v0 row should need rewriting")
+
+	raw, found, err := tc.G.LocalDb.GetRaw(pgpKeyDbKey(mctx, oldKID))
+	require.NoError(t, err)
+	require.True(t, found)
+	version, err := pgpKeyDbRowVersion(raw)
+	require.NoError(t, err)
+	require.Equal(t, pgpKeyDbCurrentVersion, version)
+
+	// The future-version row is left untouched; it'll keep reading back as
+	// a miss until this client is upgraded.
+	futureRawAfter, found, err := tc.G.LocalDb.GetRaw(pgpKeyDbKey(mctx, futureKID))
+	require.NoError(t, err)
+	require.True(t, found)
+	require.Equal(t, futureRaw, futureRawAfter)
+}
+
+func TestListAndDeleteStoredPGPKeys(t *testing.T) {
+	tc := SetupTest(t, "pgpkeydb", 1)
+	defer tc.Cleanup()
+
+	uv := keybase1.UserVersion{Uid: keybase1.UID("c3c3c3c3c3c3c3c3c3c3c3c3c3c3c300"), EldestSeqno: 1}
+	mctx := NewMetaContextForTest(tc).WithNewProvisionalLoginContextForUserVersionAndUsername(uv, NormalizedUsername("carol"))
+
+	kid1 := keybase1.KID("0707070707070707070707070707070707070707070707070707070707070707070707070707070707070707070707070707070707070707070707070707070707")
+	kid2 := keybase1.KID("0808080808080808080808080808080808080808080808080808080808080808080808080808080808080808080808080808080808080808080808080808080808")
+
+	raw, err := encodeCurrentPGPKeyDbRow("armored-key-material")
+	require.NoError(t, err)
+	require.NoError(t, tc.G.LocalDb.PutRaw(pgpKeyDbKey(mctx, kid1), raw))
+	require.NoError(t, tc.G.LocalDb.PutRaw(pgpKeyDbKey(mctx, kid2), raw))
+
+	kids, err := tc.G.ListStoredPGPKeys(mctx)
+	require.NoError(t, err)
+	require.ElementsMatch(t, []keybase1.KID{kid1, kid2}, kids)
+
+	require.NoError(t, tc.G.DeleteStoredPGPKey(mctx, kid1))
+
+	kids, err = tc.G.ListStoredPGPKeys(mctx)
+	require.NoError(t, err)
+	require.Equal(t, []keybase1.KID{kid2}, kids)
+}
+
+func TestListStoredPGPKeysLoggedOut(t *testing.T) {
+	tc := SetupTest(t, "pgpkeydb", 1)
+	defer tc.Cleanup()
+
+	kids, err := tc.G.ListStoredPGPKeys(NewMetaContextForTest(tc))
+	require.NoError(t, err)
+	require.Empty(t, kids)
+}
+
+func TestMigrateLegacyPGPKeyDbRow(t *testing.T) {
+	tc := SetupTest(t, "pgpkeydb", 1)
+	defer tc.Cleanup()
+
+	kid := keybase1.KID("0202020202020202020202020202020202020202020202020202020202020202020202020202020202020202020202020202020202020202020202020202020202")
+	mctx := NewMetaContextForTest(tc)
+
+	require.NoError(t, tc.G.LocalDb.PutRaw(legacyPGPKeyDbKey(kid), []byte("legacy row")))
+
+	migrateLegacyPGPKeyDbRow(mctx, kid)
+
+	raw, found, err := tc.G.LocalDb.GetRaw(pgpKeyDbKey(mctx, kid))
+	require.NoError(t, err)
+	require.True(t, found)
+	require.Equal(t, "legacy row", string(raw))
+
+	_, found, err = tc.G.LocalDb.GetRaw(legacyPGPKeyDbKey(kid))
+	require.NoError(t, err)
+	require.False(t, found, "legacy row should be dropped after migration")
+}
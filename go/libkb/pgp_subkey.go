@@ -0,0 +1,93 @@
+// Copyright 2015 Keybase, Inc. All rights reserved. Use of
+// this source code is governed by the included BSD license.
+
+package libkb
+
+import (
+	"github.com/keybase/go-crypto/openpgp/packet"
+)
+
+// RotateSubkeyArg configures the fresh encryption subkey generated by
+// PGPKeyBundle.RotateSubkey.
+type RotateSubkeyArg struct {
+	// Bits sizes the new subkey when k's primary key is RSA. It's ignored
+	// when the primary is EdDSA, whose subkeys are always Curve25519.
+	Bits int
+
+	// Lifetime is how many seconds from now the new subkey expires,
+	// following SubkeyLifetime's convention in PGPGenArg.
+	Lifetime int
+
+	// Config controls randomness, the clock, and hash/cipher/compression
+	// preferences, same as in PGPGenArg. If nil, sensible defaults are
+	// used.
+	Config *packet.Config
+}
+
+func (a *RotateSubkeyArg) init() {
+	if a.Config == nil {
+		a.Config = &packet.Config{}
+	}
+	if a.Bits == 0 {
+		a.Bits = 4096
+	}
+	if a.Lifetime == 0 {
+		a.Lifetime = SubkeyExpireIn
+	}
+}
+
+// RotateSubkey generates a fresh encryption subkey matching k's primary
+// key algorithm, binds it to k's primary key with a subkey-binding
+// signature, and appends it to k.Subkeys. It doesn't touch any subkeys k
+// already has, so a caller rotating away from a compromised or expiring
+// subkey should drop it from k.Subkeys itself first. k must hold its
+// primary private key, since binding a subkey requires signing with it.
+//
+// Unlike generating a subkey with GPG and reimporting the result, this
+// keeps k's Generated/ArmoredPublicKey bookkeeping intact: the returned
+// armored bundle comes straight out of k's own Encode, not a round trip
+// through an external keyring.
+func (k *PGPKeyBundle) RotateSubkey(arg RotateSubkeyArg) (armored string, err error) {
+	if !k.HasSecretKey() {
+		return "", NoSecretKeyError{}
+	}
+	arg.init()
+
+	currentTime := arg.Config.Now()
+	subkey, err := generateEncryptionSubkey(k.PrimaryKey.PubKeyAlgo, arg.Bits, currentTime, arg.Config)
+	if err != nil {
+		return "", err
+	}
+
+	subkey.Sig = &packet.Signature{
+		CreationTime:              currentTime,
+		SigType:                   packet.SigTypeSubkeyBinding,
+		PubKeyAlgo:                subkey.PublicKey.PubKeyAlgo,
+		Hash:                      arg.Config.Hash(),
+		FlagsValid:                true,
+		FlagEncryptStorage:        true,
+		FlagEncryptCommunications: true,
+		IssuerKeyId:               &k.PrimaryKey.KeyId,
+		PreferredSymmetric:        defaultPreferredSymmetric(),
+		PreferredHash:             defaultPreferredHash(),
+		PreferredCompression:      defaultPreferredCompression(),
+		KeyLifetimeSecs:           ui32p(arg.Lifetime),
+	}
+	subkey.PublicKey.IsSubkey = true
+	subkey.PrivateKey.IsSubkey = true
+
+	k.Subkeys = append(k.Subkeys, subkey)
+
+	// The bundle now differs from whatever produced its cached
+	// ArmoredPublicKey (if any), and its subkey-binding signatures need to
+	// be (re)computed at serialize time rather than reused -- see
+	// SerializePrivate and the Generated field it checks.
+	k.Generated = true
+	k.ArmoredPublicKey = ""
+
+	out, err := k.ToArmoredBytes(false)
+	if err != nil {
+		return "", err
+	}
+	return string(out), nil
+}
@@ -0,0 +1,71 @@
+// Copyright 2015 Keybase, Inc. All rights reserved. Use of
+// this source code is governed by the included BSD license.
+
+package libkb
+
+import (
+	"testing"
+
+	"github.com/keybase/go-crypto/openpgp/packet"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRotateSubkeyRSA(t *testing.T) {
+	tc := SetupTest(t, "rotatesubkey", 1)
+	defer tc.Cleanup()
+
+	bundle, err := tc.MakePGPKey("rotatesubkey@keybase.io")
+	require.NoError(t, err)
+	require.Len(t, bundle.Subkeys, 1)
+
+	original := bundle.Subkeys[0].PublicKey.Fingerprint
+
+	armored, err := bundle.RotateSubkey(RotateSubkeyArg{Bits: 1024})
+	require.NoError(t, err)
+	require.Contains(t, armored, "-----BEGIN PGP PUBLIC KEY BLOCK-----")
+	require.Len(t, bundle.Subkeys, 2, "RotateSubkey should add a subkey, not replace the old one")
+	require.Equal(t, original, bundle.Subkeys[0].PublicKey.Fingerprint, "the original subkey should be untouched")
+
+	newSubkey := bundle.Subkeys[1]
+	require.NotEqual(t, original, newSubkey.PublicKey.Fingerprint)
+	require.Equal(t, packet.PubKeyAlgoRSA, newSubkey.PublicKey.PubKeyAlgo)
+	require.True(t, newSubkey.PublicKey.IsSubkey)
+	require.True(t, newSubkey.PrivateKey.IsSubkey)
+	require.NotNil(t, newSubkey.Sig)
+	require.True(t, newSubkey.Sig.FlagEncryptStorage)
+	require.True(t, newSubkey.Sig.FlagEncryptCommunications)
+
+	reimported, _, err := ReadOneKeyFromString(armored)
+	require.NoError(t, err)
+	require.Len(t, reimported.Subkeys, 2)
+}
+
+func TestRotateSubkeyEdDSA(t *testing.T) {
+	tc := SetupTest(t, "rotatesubkeyeddsa", 1)
+	defer tc.Cleanup()
+
+	bundle, err := tc.MakeEdDSAPGPKey("rotatesubkeyeddsa@keybase.io")
+	require.NoError(t, err)
+
+	_, err = bundle.RotateSubkey(RotateSubkeyArg{})
+	require.NoError(t, err)
+	require.Len(t, bundle.Subkeys, 2)
+	require.Equal(t, packet.PubKeyAlgoECDH, bundle.Subkeys[1].PublicKey.PubKeyAlgo)
+}
+
+func TestRotateSubkeyRequiresSecretKey(t *testing.T) {
+	tc := SetupTest(t, "rotatesubkeypublic", 1)
+	defer tc.Cleanup()
+
+	bundle, err := tc.MakePGPKey("rotatesubkeypublic@keybase.io")
+	require.NoError(t, err)
+
+	public, _, err := bundle.ExportPublicAndPrivate()
+	require.NoError(t, err)
+	publicBundle, _, err := ReadOneKeyFromBytes(public)
+	require.NoError(t, err)
+
+	_, err = publicBundle.RotateSubkey(RotateSubkeyArg{})
+	require.Error(t, err)
+	require.IsType(t, NoSecretKeyError{}, err)
+}
@@ -5,12 +5,15 @@ import (
 	"crypto"
 	"fmt"
 	"io"
+	"sort"
 
 	"github.com/keybase/go-crypto/openpgp/armor"
 
 	"github.com/keybase/go-crypto/openpgp"
 	"github.com/keybase/go-crypto/openpgp/errors"
 	"github.com/keybase/go-crypto/openpgp/packet"
+
+	keybase1 "github.com/keybase/client/go/protocol/keybase1"
 )
 
 func ExtractPGPSignatureHashMethod(keyring openpgp.KeyRing, sig []byte) (crypto.Hash, uint64, error) {
@@ -123,20 +126,98 @@ const (
 	HashSecurityWarningSignersIdentityHash
 	HashSecurityWarningRecipientsIdentityHash
 	HashSecurityWarningOurIdentityHash
+
+	// HashSecurityWarningKeyAudit wraps a PGPKeyAuditReport finding that
+	// isn't itself about a hash algorithm (a weak RSA modulus, a
+	// ROCA-vulnerable key, a missing encryption subkey, ...), so it can
+	// still flow through the same Warnings pipeline. See NewKeyAuditWarning.
+	HashSecurityWarningKeyAudit
+)
+
+// HashSecurityWarningSeverity classifies how urgently a HashSecurityWarning
+// should be surfaced, so the GUI can color-code it (e.g. yellow vs red).
+type HashSecurityWarningSeverity uint8
+
+const (
+	HashSecurityWarningSeverityModerate HashSecurityWarningSeverity = iota
+	HashSecurityWarningSeverityCritical
 )
 
+// severityForHash implements the digest policy: MD5 (and the even weaker
+// MD4) are critical wherever they're found, since they're practically
+// forgeable; anything else insecure (SHA-1, RIPEMD-160) is merely moderate.
+func severityForHash(hash crypto.Hash) HashSecurityWarningSeverity {
+	switch hash {
+	case crypto.MD5, crypto.MD4:
+		return HashSecurityWarningSeverityCritical
+	default:
+		return HashSecurityWarningSeverityModerate
+	}
+}
+
+// remediationForKind gives a concrete "fix it" action for where the weak
+// hash was found, so the GUI doesn't have to guess at wording.
+func remediationForKind(kind HashSecurityWarningType) string {
+	switch kind {
+	case HashSecurityWarningSignatureHash:
+		return "Ask the signer to re-sign the message with a modern hash algorithm (gpg --digest-algo SHA256) and send it again."
+	case HashSecurityWarningSignersIdentityHash, HashSecurityWarningOurIdentityHash:
+		return "Re-sign your key's user IDs with gpg --cert-digest-algo SHA256 and re-import."
+	case HashSecurityWarningRecipientsIdentityHash:
+		return "Ask the recipient to re-sign their key's user IDs with gpg --cert-digest-algo SHA256, re-import, and re-share their key."
+	default:
+		return ""
+	}
+}
+
 type HashSecurityWarning struct {
 	kind        HashSecurityWarningType
 	hash        crypto.Hash
 	fingerprint *PGPFingerprint
+	severity    HashSecurityWarningSeverity
+	remediation string
+	// message holds the rendered text for HashSecurityWarningKeyAudit,
+	// which isn't derived from a hash algorithm the way the other kinds are.
+	message string
 }
 
 func NewHashSecurityWarning(kind HashSecurityWarningType, hash crypto.Hash, fp *PGPFingerprint) HashSecurityWarning {
-	return HashSecurityWarning{kind: kind, hash: hash, fingerprint: fp}
+	return HashSecurityWarning{
+		kind:        kind,
+		hash:        hash,
+		fingerprint: fp,
+		severity:    severityForHash(hash),
+		remediation: remediationForKind(kind),
+	}
+}
+
+// NewKeyAuditWarning wraps a single PGPKeyAuditReport finding (e.g. an
+// undersized RSA modulus or a ROCA-vulnerable key) as a HashSecurityWarning,
+// so AuditKey's findings can flow through the same Warnings pipeline
+// NewHashSecurityWarning's callers already use.
+func NewKeyAuditWarning(message string, severity HashSecurityWarningSeverity, fp *PGPFingerprint) HashSecurityWarning {
+	return HashSecurityWarning{
+		kind:        HashSecurityWarningKeyAudit,
+		fingerprint: fp,
+		severity:    severity,
+		message:     message,
+	}
+}
+
+// Severity reports how urgently this warning should be surfaced.
+func (h HashSecurityWarning) Severity() HashSecurityWarningSeverity {
+	return h.severity
+}
+
+// Remediation gives a concrete action the user can take to fix this warning.
+func (h HashSecurityWarning) Remediation() string {
+	return h.remediation
 }
 
 func (h HashSecurityWarning) String() string {
 	switch h.kind {
+	case HashSecurityWarningKeyAudit:
+		return h.message
 	case HashSecurityWarningSignatureHash:
 		return fmt.Sprintf("Message was signed using an insecure hash scheme (%s)", HashToName[h.hash])
 	case HashSecurityWarningSignersIdentityHash:
@@ -150,6 +231,17 @@ func (h HashSecurityWarning) String() string {
 	}
 }
 
+// Export converts h to its wire form for the GUI, which color-codes by
+// Severity and shows Remediation as a concrete "fix it" action.
+func (h HashSecurityWarning) Export() keybase1.HashSecurityWarning {
+	return keybase1.HashSecurityWarning{
+		Kind:        keybase1.HashSecurityWarningKind(h.kind),
+		Severity:    keybase1.HashSecurityWarningSeverity(h.severity),
+		Description: h.String(),
+		Remediation: h.remediation,
+	}
+}
+
 type HashSecurityWarnings []HashSecurityWarning
 
 func (hs HashSecurityWarnings) Strings() (res []string) {
@@ -158,3 +250,17 @@ func (hs HashSecurityWarnings) Strings() (res []string) {
 	}
 	return
 }
+
+// Export converts hs to its wire form, sorted most-severe-first, so the
+// GUI's aggregated per-user report doesn't need to re-sort.
+func (hs HashSecurityWarnings) Export() (res []keybase1.HashSecurityWarning) {
+	sorted := make(HashSecurityWarnings, len(hs))
+	copy(sorted, hs)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].severity > sorted[j].severity
+	})
+	for _, h := range sorted {
+		res = append(res, h.Export())
+	}
+	return res
+}
@@ -0,0 +1,47 @@
+package libkb
+
+import (
+	"crypto"
+	"testing"
+
+	keybase1 "github.com/keybase/client/go/protocol/keybase1"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHashSecurityWarningSeverity(t *testing.T) {
+	require.Equal(t, HashSecurityWarningSeverityCritical, NewHashSecurityWarning(HashSecurityWarningOurIdentityHash, crypto.MD5, nil).Severity())
+	require.Equal(t, HashSecurityWarningSeverityModerate, NewHashSecurityWarning(HashSecurityWarningSignersIdentityHash, crypto.SHA1, nil).Severity())
+}
+
+func TestHashSecurityWarningRemediation(t *testing.T) {
+	cases := []struct {
+		kind        HashSecurityWarningType
+		remediation string
+	}{
+		{HashSecurityWarningSignatureHash, "Ask the signer to re-sign the message with a modern hash algorithm (gpg --digest-algo SHA256) and send it again."},
+		{HashSecurityWarningSignersIdentityHash, "Re-sign your key's user IDs with gpg --cert-digest-algo SHA256 and re-import."},
+		{HashSecurityWarningOurIdentityHash, "Re-sign your key's user IDs with gpg --cert-digest-algo SHA256 and re-import."},
+		{HashSecurityWarningRecipientsIdentityHash, "Ask the recipient to re-sign their key's user IDs with gpg --cert-digest-algo SHA256, re-import, and re-share their key."},
+	}
+	for _, c := range cases {
+		warning := NewHashSecurityWarning(c.kind, crypto.SHA1, nil)
+		require.Equal(t, c.remediation, warning.Remediation())
+	}
+}
+
+func TestHashSecurityWarningsExportSortsBySeverity(t *testing.T) {
+	fp := &PGPFingerprint{}
+	hs := HashSecurityWarnings{
+		NewHashSecurityWarning(HashSecurityWarningSignersIdentityHash, crypto.SHA1, fp),
+		NewHashSecurityWarning(HashSecurityWarningOurIdentityHash, crypto.MD5, fp),
+		NewHashSecurityWarning(HashSecurityWarningSignatureHash, crypto.SHA1, nil),
+	}
+
+	exported := hs.Export()
+	require.Len(t, exported, 3)
+	require.Equal(t, keybase1.HashSecurityWarningSeverity_CRITICAL, exported[0].Severity)
+	require.Equal(t, keybase1.HashSecurityWarningKind_OUR_IDENTITY_HASH, exported[0].Kind)
+	for _, w := range exported[1:] {
+		require.Equal(t, keybase1.HashSecurityWarningSeverity_MODERATE, w.Severity)
+	}
+}
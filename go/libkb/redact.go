@@ -0,0 +1,38 @@
+// Copyright 2016 Keybase, Inc. All rights reserved. Use of
+// this source code is governed by the included BSD license.
+
+package libkb
+
+import (
+	"crypto/sha256"
+	"fmt"
+)
+
+// Redacted wraps a sensitive value (armored keys, passphrases, secret
+// notes, signed payloads that may embed any of the above, ...) so that it
+// can be passed to MetaContext.Debug/Warning/Error/Info and friends
+// without ever putting the underlying bytes into a log line. Its String()
+// method is the only thing those loggers will ever see, and it is not
+// gated by log level -- there is no "verbose" knob that unredacts it.
+//
+// Use it like: mctx.Debug("failing sig: %s", libkb.Redact("sig", []byte(sig)))
+type Redacted struct {
+	label string
+	size  int
+	sum   [sha256.Size]byte
+}
+
+// Redact wraps data for safe logging. label should describe what data is
+// (e.g. "armored-private-key", "sig"), not repeat its content.
+func Redact(label string, data []byte) Redacted {
+	return Redacted{label: label, size: len(data), sum: sha256.Sum256(data)}
+}
+
+// RedactString is Redact for string-typed secrets.
+func RedactString(label string, data string) Redacted {
+	return Redact(label, []byte(data))
+}
+
+func (r Redacted) String() string {
+	return fmt.Sprintf("<redacted %s: %d bytes, sha256=%x>", r.label, r.size, r.sum[:4])
+}
@@ -0,0 +1,81 @@
+// Copyright 2016 Keybase, Inc. All rights reserved. Use of
+// this source code is governed by the included BSD license.
+
+package libkb
+
+import (
+	"bufio"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRedactedHidesContent(t *testing.T) {
+	r := RedactString("passphrase", "hunter2")
+	require.NotContains(t, r.String(), "hunter2")
+	require.Contains(t, r.String(), "passphrase")
+}
+
+// sensitiveLintFiles are the source files in the pgp and stellar send paths
+// that are most likely to ever need to log a signature, key, or secret
+// note. If a new file in those paths starts logging one of the
+// sensitiveLintNeedles below, add it here.
+var sensitiveLintFiles = []string{
+	"pgp_key.go",
+	"pgp_dec.go",
+	"pgp_enc.go",
+	"gpg_key.go",
+	"../stellar/send.go",
+}
+
+// sensitiveLintNeedles are substrings that, if they appear on the same
+// log-call line as a debug/warning/error/info logger, should be wrapped in
+// Redact/RedactString instead of formatted directly.
+var sensitiveLintNeedles = []string{
+	"ArmoredPrivateKey",
+	"ArmoredPublicKey",
+	"Passphrase",
+	"SecretKey",
+	"StellarSeed",
+	"%s\", sig)",
+}
+
+var logCallMarkers = []string{".Debug(", ".Debugw(", ".Warning(", ".Warningw(", ".Error(", ".Info(", "CDebugf(", "CWarningf(", "CErrorf(", "CInfof("}
+
+// TestNoUnredactedSensitiveLogging is a lint-style regression test: it
+// fails if a log line in the pgp or stellar send paths formats a
+// known-sensitive value without routing it through Redact/RedactString.
+func TestNoUnredactedSensitiveLogging(t *testing.T) {
+	for _, path := range sensitiveLintFiles {
+		f, err := os.Open(path)
+		if os.IsNotExist(err) {
+			continue
+		}
+		require.NoError(t, err)
+		scanner := bufio.NewScanner(f)
+		lineNo := 0
+		for scanner.Scan() {
+			lineNo++
+			line := scanner.Text()
+			isLogCall := false
+			for _, marker := range logCallMarkers {
+				if strings.Contains(line, marker) {
+					isLogCall = true
+					break
+				}
+			}
+			if !isLogCall || strings.Contains(line, "Redact(") || strings.Contains(line, "RedactString(") {
+				continue
+			}
+			for _, needle := range sensitiveLintNeedles {
+				if strings.Contains(line, needle) {
+					t.Errorf("%s:%d: logs %q without redaction: %s", path, lineNo, needle, strings.TrimSpace(line))
+				}
+			}
+		}
+		require.NoError(t, scanner.Err())
+		f.Close()
+	}
+}
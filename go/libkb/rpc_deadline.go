@@ -0,0 +1,118 @@
+// Copyright 2015 Keybase, Inc. All rights reserved. Use of
+// this source code is governed by the included BSD license.
+
+package libkb
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/keybase/go-framed-msgpack-rpc/rpc"
+)
+
+// ServerTimeoutError is returned to an RPC caller in place of a handler's
+// own result when that handler is still running past DeadlineOptions.Hard.
+type ServerTimeoutError struct {
+	Protocol string
+	Method   string
+	Limit    time.Duration
+}
+
+func (e ServerTimeoutError) Error() string {
+	return fmt.Sprintf("%s.%s exceeded its %s server-side deadline", e.Protocol, e.Method, e.Limit)
+}
+
+// DeadlineOptions configures WrapProtocolWithDeadline.
+type DeadlineOptions struct {
+	// Soft is how long a handler may run before a warning is logged about
+	// it, without otherwise affecting the call. Zero disables the warning.
+	Soft time.Duration
+	// Hard is how long a handler may run before its context is canceled
+	// and the caller gets a ServerTimeoutError in place of the handler's
+	// own result. Zero disables enforcement.
+	Hard time.Duration
+	// AllowList names methods that should run with no deadline at all,
+	// for example long-running streaming calls.
+	AllowList map[string]bool
+}
+
+// WrapProtocolWithDeadline returns a copy of proto whose handlers are
+// bounded by opts, so a handler that blocks forever (stuck on a wedged
+// downstream channel, say) can't tie up the connection indefinitely with
+// no visibility. Methods named in opts.AllowList are passed through
+// unwrapped.
+func WrapProtocolWithDeadline(g *GlobalContext, proto rpc.Protocol, opts DeadlineOptions) rpc.Protocol {
+	wrapped := rpc.Protocol{
+		Name:      proto.Name,
+		WrapError: proto.WrapError,
+		Methods:   make(map[string]rpc.ServeHandlerDescription, len(proto.Methods)),
+	}
+	for method, desc := range proto.Methods {
+		if opts.AllowList[method] {
+			wrapped.Methods[method] = desc
+			continue
+		}
+		desc, method := desc, method
+		handler := desc.Handler
+		desc.Handler = func(ctx context.Context, args interface{}) (interface{}, error) {
+			return runHandlerWithDeadline(g, proto.Name, method, ctx, args, handler, opts)
+		}
+		wrapped.Methods[method] = desc
+	}
+	return wrapped
+}
+
+func runHandlerWithDeadline(
+	g *GlobalContext, protoName, method string, ctx context.Context, args interface{},
+	handler func(context.Context, interface{}) (interface{}, error), opts DeadlineOptions) (interface{}, error) {
+	if opts.Soft <= 0 && opts.Hard <= 0 {
+		return handler(ctx, args)
+	}
+
+	hctx := ctx
+	var cancel context.CancelFunc
+	if opts.Hard > 0 {
+		hctx, cancel = context.WithCancel(ctx)
+		defer cancel()
+	}
+
+	type result struct {
+		ret interface{}
+		err error
+	}
+	done := make(chan result, 1)
+	go func() {
+		ret, err := handler(hctx, args)
+		done <- result{ret, err}
+	}()
+
+	var softC, hardC <-chan time.Time
+	if opts.Soft > 0 {
+		t := time.NewTimer(opts.Soft)
+		defer t.Stop()
+		softC = t.C
+	}
+	if opts.Hard > 0 {
+		t := time.NewTimer(opts.Hard)
+		defer t.Stop()
+		hardC = t.C
+	}
+
+	for {
+		select {
+		case r := <-done:
+			return r.ret, r.err
+		case <-softC:
+			NewMetaContext(ctx, g).Warningw("rpc handler exceeded soft deadline",
+				"protocol", protoName, "method", method, "soft", opts.Soft)
+			softC = nil
+		case <-hardC:
+			cancel()
+			// The handler goroutine may still be running; let it drain in
+			// the background instead of leaking, but answer the caller now.
+			go func() { <-done }()
+			return nil, ServerTimeoutError{Protocol: protoName, Method: method, Limit: opts.Hard}
+		}
+	}
+}
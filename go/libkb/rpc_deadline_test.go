@@ -0,0 +1,82 @@
+// Copyright 2015 Keybase, Inc. All rights reserved. Use of
+// this source code is governed by the included BSD license.
+
+package libkb
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/keybase/go-framed-msgpack-rpc/rpc"
+	"github.com/stretchr/testify/require"
+)
+
+func slowEchoProtocol(delay time.Duration) rpc.Protocol {
+	return rpc.Protocol{
+		Name: "test.slow",
+		Methods: map[string]rpc.ServeHandlerDescription{
+			"Slow": {
+				MakeArg: func() interface{} { return new(int) },
+				Handler: func(ctx context.Context, args interface{}) (interface{}, error) {
+					select {
+					case <-time.After(delay):
+						return "done", nil
+					case <-ctx.Done():
+						return nil, ctx.Err()
+					}
+				},
+			},
+			"Fast": {
+				MakeArg: func() interface{} { return new(int) },
+				Handler: func(ctx context.Context, args interface{}) (interface{}, error) {
+					return "done", nil
+				},
+			},
+		},
+	}
+}
+
+func TestWrapProtocolWithDeadlineHardLimit(t *testing.T) {
+	tc := SetupTest(t, "rpcdeadline", 1)
+	defer tc.Cleanup()
+
+	proto := WrapProtocolWithDeadline(tc.G, slowEchoProtocol(time.Hour), DeadlineOptions{
+		Hard: 20 * time.Millisecond,
+	})
+
+	ret, err := proto.Methods["Slow"].Handler(context.Background(), new(int))
+	require.Nil(t, ret)
+	require.Error(t, err)
+	timeoutErr, ok := err.(ServerTimeoutError)
+	require.True(t, ok, "expected ServerTimeoutError, got %T: %v", err, err)
+	require.Equal(t, "Slow", timeoutErr.Method)
+}
+
+func TestWrapProtocolWithDeadlineAllowList(t *testing.T) {
+	tc := SetupTest(t, "rpcdeadline", 1)
+	defer tc.Cleanup()
+
+	proto := WrapProtocolWithDeadline(tc.G, slowEchoProtocol(50*time.Millisecond), DeadlineOptions{
+		Hard:      20 * time.Millisecond,
+		AllowList: map[string]bool{"Slow": true},
+	})
+
+	ret, err := proto.Methods["Slow"].Handler(context.Background(), new(int))
+	require.NoError(t, err)
+	require.Equal(t, "done", ret)
+}
+
+func TestWrapProtocolWithDeadlineFastHandlerUnaffected(t *testing.T) {
+	tc := SetupTest(t, "rpcdeadline", 1)
+	defer tc.Cleanup()
+
+	proto := WrapProtocolWithDeadline(tc.G, slowEchoProtocol(time.Hour), DeadlineOptions{
+		Soft: time.Minute,
+		Hard: time.Hour,
+	})
+
+	ret, err := proto.Methods["Fast"].Handler(context.Background(), new(int))
+	require.NoError(t, err)
+	require.Equal(t, "done", ret)
+}
@@ -245,7 +245,7 @@ func ImportStatusAsError(g *GlobalContext, s *keybase1.Status) error {
 	case SCBadSession:
 		return BadSessionError{s.Desc}
 	case SCBadLoginPassword:
-		return PassphraseError{s.Desc}
+		return PassphraseError{Msg: s.Desc}
 	case SCKeyBadGen:
 		return KeyGenError{s.Desc}
 	case SCAlreadyLoggedIn:
@@ -1084,6 +1084,10 @@ func (id Identity) Export() (ret keybase1.PGPIdentity) {
 	return
 }
 
+// ImportPGPIdentity is the inverse of Identity.Export: it turns a wire-form
+// PGPIdentity back into the fields needed to build a PGP UID packet (see
+// Identity.ToPGPUserID). The caller is responsible for calling
+// Identity.Validate before using the result to generate a key.
 func ImportPGPIdentity(arg keybase1.PGPIdentity) (ret Identity) {
 	ret.Username = arg.Username
 	ret.Email = arg.Email
@@ -0,0 +1,140 @@
+// Copyright 2015 Keybase, Inc. All rights reserved. Use of
+// this source code is governed by the included BSD license.
+
+package libkb
+
+import (
+	"fmt"
+	"io"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/keybase/go-framed-msgpack-rpc/rpc"
+	"golang.org/x/net/context"
+)
+
+// RetryableMethods is an allowlist of RPC method names that are safe to
+// retry, because they're idempotent. Retrying a non-idempotent method (like
+// one that sends a message or creates a resource) on a connection error
+// risks doing it twice, since we can't always tell whether the original call
+// reached the server before the connection dropped.
+type RetryableMethods map[string]bool
+
+// NewRetryableMethods builds a RetryableMethods set from a list of method
+// names.
+func NewRetryableMethods(methods ...string) RetryableMethods {
+	ret := make(RetryableMethods, len(methods))
+	for _, m := range methods {
+		ret[m] = true
+	}
+	return ret
+}
+
+// RPCRetryExhaustedError is returned when a retryable method still hasn't
+// succeeded after every retry attempt.
+type RPCRetryExhaustedError struct {
+	Method   string
+	Attempts int
+	LastErr  error
+}
+
+func (e RPCRetryExhaustedError) Error() string {
+	return fmt.Sprintf("giving up on %q after %d attempt(s), last error: %s", e.Method, e.Attempts, e.LastErr)
+}
+
+// RetryingGenericClient wraps an rpc.GenericClient, retrying calls to
+// allowlisted methods when they fail with a connection-class error (the
+// service briefly restarting, a socket hiccup), using a capped, jittered
+// backoff. Calls are never retried once they've returned a non-connection
+// (i.e. application-level) error, since that means the server did receive
+// and process the call. ctx cancellation is honored between attempts.
+type RetryingGenericClient struct {
+	cli         rpc.GenericClient
+	retryable   RetryableMethods
+	maxAttempts int
+	backoff     BackoffPolicy
+}
+
+var _ rpc.GenericClient = (*RetryingGenericClient)(nil)
+
+// NewRetryingGenericClient wraps cli. maxAttempts is the total number of
+// tries (including the first), and must be at least 1; values less than 1
+// are treated as 1 (no retrying).
+func NewRetryingGenericClient(cli rpc.GenericClient, retryable RetryableMethods, maxAttempts int) *RetryingGenericClient {
+	if maxAttempts < 1 {
+		maxAttempts = 1
+	}
+	return &RetryingGenericClient{
+		cli:         cli,
+		retryable:   retryable,
+		maxAttempts: maxAttempts,
+		backoff:     BackoffDefault,
+	}
+}
+
+func (r *RetryingGenericClient) Call(ctx context.Context, method string, arg interface{}, res interface{}, timeout time.Duration) error {
+	return r.withRetry(ctx, method, func() error {
+		return r.cli.Call(ctx, method, arg, res, timeout)
+	})
+}
+
+func (r *RetryingGenericClient) CallCompressed(ctx context.Context, method string, arg interface{}, res interface{}, ctype rpc.CompressionType, timeout time.Duration) error {
+	return r.withRetry(ctx, method, func() error {
+		return r.cli.CallCompressed(ctx, method, arg, res, ctype, timeout)
+	})
+}
+
+func (r *RetryingGenericClient) Notify(ctx context.Context, method string, arg interface{}, timeout time.Duration) error {
+	return r.withRetry(ctx, method, func() error {
+		return r.cli.Notify(ctx, method, arg, timeout)
+	})
+}
+
+func (r *RetryingGenericClient) withRetry(ctx context.Context, method string, call func() error) error {
+	if !r.retryable[method] {
+		return call()
+	}
+
+	var lastErr error
+	for attempt := 1; attempt <= r.maxAttempts; attempt++ {
+		lastErr = call()
+		if lastErr == nil || !isConnectionClassError(lastErr) {
+			return lastErr
+		}
+		if attempt == r.maxAttempts {
+			break
+		}
+		select {
+		case <-time.After(r.backoff.Duration(attempt - 1)):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	return RPCRetryExhaustedError{Method: method, Attempts: r.maxAttempts, LastErr: lastErr}
+}
+
+// isConnectionClassError reports whether err looks like a transient
+// connection problem -- as opposed to an application-level error returned by
+// a handler that did run -- and is therefore worth retrying.
+func isConnectionClassError(err error) bool {
+	if err == nil {
+		return false
+	}
+	if err == io.EOF || err == io.ErrUnexpectedEOF {
+		return true
+	}
+	if _, ok := err.(net.Error); ok {
+		return true
+	}
+	if IsSocketClosedError(err) {
+		return true
+	}
+	msg := err.Error()
+	for _, s := range []string{"connection refused", "connection reset", "broken pipe"} {
+		if strings.Contains(msg, s) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,88 @@
+// Copyright 2015 Keybase, Inc. All rights reserved. Use of
+// this source code is governed by the included BSD license.
+
+package libkb
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/keybase/go-framed-msgpack-rpc/rpc"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/context"
+)
+
+// flakyGenericClient is a fake rpc.GenericClient that fails the first
+// failures calls to any method with a connection-class error, then
+// succeeds.
+type flakyGenericClient struct {
+	failures int
+	calls    int
+}
+
+var _ rpc.GenericClient = (*flakyGenericClient)(nil)
+
+func (f *flakyGenericClient) tick() error {
+	f.calls++
+	if f.calls <= f.failures {
+		return errors.New("read tcp: connection reset by peer")
+	}
+	return nil
+}
+
+func (f *flakyGenericClient) Call(ctx context.Context, method string, arg interface{}, res interface{}, timeout time.Duration) error {
+	return f.tick()
+}
+
+func (f *flakyGenericClient) CallCompressed(ctx context.Context, method string, arg interface{}, res interface{}, ctype rpc.CompressionType, timeout time.Duration) error {
+	return f.tick()
+}
+
+func (f *flakyGenericClient) Notify(ctx context.Context, method string, arg interface{}, timeout time.Duration) error {
+	return f.tick()
+}
+
+func TestRetryingGenericClientNonRetryableMethod(t *testing.T) {
+	fake := &flakyGenericClient{failures: 1}
+	cli := NewRetryingGenericClient(fake, NewRetryableMethods("test.1.echo.Echo"), 3)
+
+	err := cli.Call(context.Background(), "test.1.other.Other", nil, nil, 0)
+	require.Error(t, err, "a non-allowlisted method should surface the first error, not retry")
+	require.Equal(t, 1, fake.calls)
+}
+
+func TestRetryingGenericClientRetriesUntilSuccess(t *testing.T) {
+	fake := &flakyGenericClient{failures: 2}
+	cli := NewRetryingGenericClient(fake, NewRetryableMethods("test.1.echo.Echo"), 3)
+
+	err := cli.Call(context.Background(), "test.1.echo.Echo", nil, nil, 0)
+	require.NoError(t, err)
+	require.Equal(t, 3, fake.calls)
+}
+
+func TestRetryingGenericClientExhausted(t *testing.T) {
+	fake := &flakyGenericClient{failures: 10}
+	cli := NewRetryingGenericClient(fake, NewRetryableMethods("test.1.echo.Echo"), 3)
+
+	err := cli.Call(context.Background(), "test.1.echo.Echo", nil, nil, 0)
+	require.Error(t, err)
+	exhausted, ok := err.(RPCRetryExhaustedError)
+	require.True(t, ok, "expected an RPCRetryExhaustedError, got %T", err)
+	require.Equal(t, "test.1.echo.Echo", exhausted.Method)
+	require.Equal(t, 3, exhausted.Attempts)
+	require.Equal(t, 3, fake.calls)
+}
+
+func TestRetryingGenericClientCanceledContext(t *testing.T) {
+	fake := &flakyGenericClient{failures: 10}
+	cli := NewRetryingGenericClient(fake, NewRetryableMethods("test.1.echo.Echo"), 5)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	err := cli.Call(ctx, "test.1.echo.Echo", nil, nil, 0)
+	require.Error(t, err)
+	require.Equal(t, context.Canceled, err)
+	require.Equal(t, 1, fake.calls, "should stop retrying once the context is canceled")
+}
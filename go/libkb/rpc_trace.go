@@ -0,0 +1,103 @@
+// Copyright 2015 Keybase, Inc. All rights reserved. Use of
+// this source code is governed by the included BSD license.
+
+package libkb
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/keybase/go-framed-msgpack-rpc/rpc"
+	"golang.org/x/net/context"
+)
+
+// RPCTraceTagKey is the log tag each call through TracingGenericClient is
+// stamped with. Since log tags ride along in the RPC's context metadata,
+// the same ID shows up in the server's logs for the handler that serviced
+// the call, letting the two sides of a call be correlated.
+const RPCTraceTagKey = "RPCTRACE"
+
+// TracingGenericClient wraps an rpc.GenericClient so that every outgoing
+// call gets a trace ID, and its method name, duration, and error class (never
+// arguments, which may be sensitive) are logged at debug level. It's meant
+// to be wrapped around any generated client -- e.g. KbfsClient.Cli -- without
+// any codegen changes, and is only worth turning on when
+// GlobalContext.Env.GetRPCTraceEnabled() is set.
+type TracingGenericClient struct {
+	Contextified
+	cli rpc.GenericClient
+
+	mu     sync.Mutex
+	counts map[string]int64
+}
+
+var _ rpc.GenericClient = (*TracingGenericClient)(nil)
+
+// NewTracingGenericClient wraps cli for tracing.
+func NewTracingGenericClient(g *GlobalContext, cli rpc.GenericClient) *TracingGenericClient {
+	return &TracingGenericClient{
+		Contextified: NewContextified(g),
+		cli:          cli,
+		counts:       make(map[string]int64),
+	}
+}
+
+// startTrace tags ctx with a fresh trace ID, bumps the method's call
+// counter, and returns a func to call with the outgoing error once the call
+// completes.
+func (t *TracingGenericClient) startTrace(ctx context.Context, method string) (context.Context, func(errp *error)) {
+	ctx = WithLogTag(ctx, RPCTraceTagKey)
+	start := t.G().Clock().Now()
+
+	t.mu.Lock()
+	t.counts[method]++
+	t.mu.Unlock()
+
+	return ctx, func(errp *error) {
+		errClass := "<nil>"
+		if errp != nil && *errp != nil {
+			errClass = fmt.Sprintf("%T", *errp)
+		}
+		t.G().Log.CDebugf(ctx, "RPCTrace: %s took %s, err=%s", method, t.G().Clock().Now().Sub(start), errClass)
+	}
+}
+
+func (t *TracingGenericClient) Call(ctx context.Context, method string, arg interface{}, res interface{}, timeout time.Duration) (err error) {
+	ctx, done := t.startTrace(ctx, method)
+	defer done(&err)
+	return t.cli.Call(ctx, method, arg, res, timeout)
+}
+
+func (t *TracingGenericClient) CallCompressed(ctx context.Context, method string, arg interface{}, res interface{}, ctype rpc.CompressionType, timeout time.Duration) (err error) {
+	ctx, done := t.startTrace(ctx, method)
+	defer done(&err)
+	return t.cli.CallCompressed(ctx, method, arg, res, ctype, timeout)
+}
+
+func (t *TracingGenericClient) Notify(ctx context.Context, method string, arg interface{}, timeout time.Duration) (err error) {
+	ctx, done := t.startTrace(ctx, method)
+	defer done(&err)
+	return t.cli.Notify(ctx, method, arg, timeout)
+}
+
+// Counts returns a point-in-time snapshot of the number of calls made so
+// far, keyed by method name.
+func (t *TracingGenericClient) Counts() map[string]int64 {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	ret := make(map[string]int64, len(t.counts))
+	for k, v := range t.counts {
+		ret[k] = v
+	}
+	return ret
+}
+
+// WrapGenericClientForTracing wraps cli in a TracingGenericClient if tracing
+// is enabled in g's config, and otherwise returns cli unchanged.
+func WrapGenericClientForTracing(g *GlobalContext, cli rpc.GenericClient) rpc.GenericClient {
+	if !g.Env.GetRPCTraceEnabled() {
+		return cli
+	}
+	return NewTracingGenericClient(g, cli)
+}
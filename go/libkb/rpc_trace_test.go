@@ -0,0 +1,77 @@
+// Copyright 2015 Keybase, Inc. All rights reserved. Use of
+// this source code is governed by the included BSD license.
+
+package libkb
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	keybase1 "github.com/keybase/client/go/protocol/keybase1"
+	"github.com/keybase/go-framed-msgpack-rpc/rpc"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/context"
+)
+
+// TestTracingGenericClientRoundTrip wires a TracingGenericClient up to a
+// real in-memory RPC server over a net.Pipe, and checks that the trace ID
+// the client generates for the call is the same one the server-side
+// handler sees arrive over the wire.
+func TestTracingGenericClientRoundTrip(t *testing.T) {
+	tc := SetupTest(t, "rpctrace", 1)
+	defer tc.Cleanup()
+
+	clientConn, serverConn := net.Pipe()
+	defer clientConn.Close()
+	defer serverConn.Close()
+
+	serverXp := rpc.NewTransport(serverConn, NewRPCLogFactory(tc.G),
+		NetworkInstrumenterStorageFromSrc(tc.G, keybase1.NetworkSource_LOCAL), MakeWrapError(tc.G), rpc.DefaultMaxFrameLength)
+	clientXp := rpc.NewTransport(clientConn, NewRPCLogFactory(tc.G),
+		NetworkInstrumenterStorageFromSrc(tc.G, keybase1.NetworkSource_LOCAL), MakeWrapError(tc.G), rpc.DefaultMaxFrameLength)
+
+	seenTraceID := make(chan string, 1)
+	server := rpc.NewServer(serverXp, nil)
+	err := server.Register(rpc.Protocol{
+		Name: "test.1.echo",
+		Methods: map[string]rpc.ServeHandlerDescription{
+			"Echo": {
+				MakeArg: func() interface{} { return new(string) },
+				Handler: func(ctx context.Context, arg interface{}) (interface{}, error) {
+					tags, _ := rpc.RpcTagsFromContext(ctx)
+					if v, ok := tags[RPCTraceTagKey]; ok {
+						seenTraceID <- v.(string)
+					} else {
+						seenTraceID <- ""
+					}
+					return "", nil
+				},
+			},
+		},
+	})
+	require.NoError(t, err)
+	server.Run()
+
+	baseClient := rpc.NewClient(clientXp, NewContextifiedErrorUnwrapper(tc.G), LogTagsFromContext)
+	tracingClient := NewTracingGenericClient(tc.G, baseClient)
+
+	ctx, done := tracingClient.startTrace(context.Background(), "test.1.echo.Echo")
+	wantTraceID, ok := ctx.Value(withLogTagKey(RPCTraceTagKey)).(string)
+	require.True(t, ok)
+	require.NotEmpty(t, wantTraceID)
+
+	var res string
+	callErr := tracingClient.cli.Call(ctx, "test.1.echo.Echo", "hi", &res, 0)
+	done(&callErr)
+	require.NoError(t, callErr)
+
+	select {
+	case gotTraceID := <-seenTraceID:
+		require.Equal(t, wantTraceID, gotTraceID, "server should see the same trace ID the client generated")
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for server handler")
+	}
+
+	require.EqualValues(t, 1, tracingClient.Counts()["test.1.echo.Echo"])
+}
@@ -5,6 +5,7 @@ package libkb
 
 import (
 	"bytes"
+	"crypto"
 	"encoding/hex"
 	"fmt"
 	"io/ioutil"
@@ -14,9 +15,16 @@ import (
 	keybase1 "github.com/keybase/client/go/protocol/keybase1"
 	"github.com/keybase/go-crypto/openpgp"
 	"github.com/keybase/go-crypto/openpgp/armor"
+	openpgperrors "github.com/keybase/go-crypto/openpgp/errors"
+	"github.com/keybase/go-crypto/openpgp/packet"
 	jsonw "github.com/keybase/go-jsonw"
 )
 
+// pgpArmorHeaderPrefix is the start of an ASCII-armored PGP block (key,
+// message, or signature). Input that doesn't start with it is assumed to
+// already be a raw, non-armored stream of OpenPGP packets.
+const pgpArmorHeaderPrefix = "-----BEGIN PGP"
+
 func GetSigID(w *jsonw.Wrapper) (keybase1.SigID, error) {
 	s, err := w.GetString()
 	if err != nil {
@@ -38,22 +46,51 @@ type ParsedSig struct {
 	SigBody     []byte
 	MD          *openpgp.MessageDetails
 	LiteralData []byte
+
+	// Warnings is filled in by Verify with a HashSecurityWarningSignatureHash
+	// warning if the signature was made with a hash algorithm IsHashSecure
+	// considers insecure (e.g. a legacy SHA1 signature), so callers can warn
+	// even though the signature itself checked out.
+	Warnings HashSecurityWarnings
 }
 
+// PGPOpenSig opens a PGP signature. armored can either be an
+// ASCII-armored signature block, or, if it doesn't start with an armor
+// header, a raw binary OpenPGP packet stream (as produced by tools like
+// `gpg --detach-sign` or git without --armor) -- PGPOpenSig sniffs the
+// input and dispatches accordingly, returning the same ParsedSig either
+// way. Callers that already have raw bytes in hand (rather than a
+// string that merely isn't armored) should call PGPOpenBinarySig
+// directly.
 func PGPOpenSig(armored string) (ps *ParsedSig, err error) {
+	if !strings.HasPrefix(strings.TrimSpace(armored), pgpArmorHeaderPrefix) {
+		return PGPOpenBinarySig([]byte(armored))
+	}
+
 	pso := ParsedSig{}
 	pso.Block, err = armor.Decode(strings.NewReader(cleanPGPInput(armored)))
 	if err != nil {
-		return
+		return nil, fmt.Errorf("failed to parse as an armored PGP signature: %s", err)
 	}
 	pso.SigBody, err = ioutil.ReadAll(pso.Block.Body)
 	if err != nil {
-		return
+		return nil, fmt.Errorf("failed to read armored PGP signature body: %s", err)
 	}
 	ps = &pso
 	return
 }
 
+// PGPOpenBinarySig opens a raw, non-armored OpenPGP packet stream,
+// producing the same ParsedSig that PGPOpenSig returns for an armored
+// signature. It only sanity-checks that sig begins with a well-formed
+// OpenPGP packet; real validation happens in ParsedSig.Verify.
+func PGPOpenBinarySig(sig []byte) (ps *ParsedSig, err error) {
+	if _, err = packet.Read(bytes.NewReader(sig)); err != nil {
+		return nil, fmt.Errorf("failed to parse as a binary OpenPGP signature: %s", err)
+	}
+	return &ParsedSig{SigBody: sig}, nil
+}
+
 // OpenSig takes an armored PGP or Keybase signature and opens
 // the armor.  It will return the body of the signature, the
 // sigID of the body, or an error if it didn't work out.
@@ -146,7 +183,7 @@ func (ps *ParsedSig) AssertPayload(expected []byte) error {
 	return nil
 }
 
-func (ps *ParsedSig) Verify(k PGPKeyBundle) (err error) {
+func (ps *ParsedSig) Verify(k PGPKeyBundle, policy HashSecurityPolicy) (err error) {
 	ps.MD, err = openpgp.ReadMessage(bytes.NewReader(ps.SigBody), k, nil, nil)
 	if err != nil {
 		return
@@ -181,6 +218,21 @@ func (ps *ParsedSig) Verify(k PGPKeyBundle) (err error) {
 		return
 	}
 
+	var sigHash crypto.Hash
+	switch {
+	case ps.MD.Signature != nil:
+		sigHash = ps.MD.Signature.Hash
+	case ps.MD.SignatureV3 != nil:
+		sigHash = ps.MD.SignatureV3.Hash
+	}
+	if !IsHashSecure(sigHash) {
+		ps.Warnings = append(ps.Warnings,
+			NewHashSecurityWarning(HashSecurityWarningSignatureHash, sigHash, nil))
+		if err = policy.checkVerifyHash(sigHash); err != nil {
+			return err
+		}
+	}
+
 	// Hopefully by here we've covered all of our bases.
 	return nil
 }
@@ -189,6 +241,30 @@ func (ps *ParsedSig) ID() keybase1.SigIDBase {
 	return kbcrypto.ComputeSigIDFromSigBody(ps.SigBody)
 }
 
+// classifyPGPVerifyFailure makes a best-effort guess at why ps.Verify
+// failed, for VerifyContextHooks.OnVerifyFailure. The vendored openpgp
+// package doesn't give us a typed "key expired" error, so this falls back
+// to substring-matching the error text for that case.
+func classifyPGPVerifyFailure(err error) VerifyFailureReason {
+	if err == nil {
+		return VerifyFailureUnknown
+	}
+	if strings.Contains(err.Error(), "wrong SignedBy key") {
+		return VerifyFailureWrongKey
+	}
+	if strings.Contains(err.Error(), "expired") {
+		return VerifyFailureExpiredKey
+	}
+	switch err.(type) {
+	case WeakHashError:
+		return VerifyFailureWeakDigest
+	case openpgperrors.StructuralError, openpgperrors.UnsupportedError, openpgperrors.UnknownPacketTypeError:
+		return VerifyFailureParseError
+	default:
+		return VerifyFailureBadSignature
+	}
+}
+
 func IsPGPSig(s string) bool {
 	return strings.HasPrefix(s, "-----BEGIN PGP MESSAGE-----")
 }
@@ -5,6 +5,7 @@ package libkb
 
 import (
 	"bytes"
+	"crypto"
 	"crypto/sha256"
 	"hash"
 	"io"
@@ -16,8 +17,12 @@ import (
 )
 
 // SimpleSign signs the given data stream, outputs an armored string which is
-// the attached signature of the input data
-func SimpleSign(payload []byte, key PGPKeyBundle) (out string, id keybase1.SigIDBase, err error) {
+// the attached signature of the input data. policy.RefuseWeakSigning has no
+// effect today, since ArmoredAttachedSign's nil *packet.Config already makes
+// go-crypto default to SHA-256 on its own; it's here so a future signing
+// path that does pass a caller-chosen hash can't regress to SHA-1 without
+// SimpleSign refusing it.
+func SimpleSign(payload []byte, key PGPKeyBundle, policy HashSecurityPolicy) (out string, id keybase1.SigIDBase, err error) {
 	var outb bytes.Buffer
 	var in io.WriteCloser
 	var h HashSummer
@@ -25,6 +30,9 @@ func SimpleSign(payload []byte, key PGPKeyBundle) (out string, id keybase1.SigID
 		err = NoSecretKeyError{}
 		return
 	}
+	if err = policy.checkSigningHash(crypto.SHA256); err != nil {
+		return
+	}
 	if in, h, err = ArmoredAttachedSign(NopWriteCloser{&outb}, *key.Entity, nil, nil); err != nil {
 		return
 	}
@@ -93,8 +101,8 @@ func AttachedSignWrapper(out io.WriteCloser, key PGPKeyBundle, armored bool) (
 // to be promoted somewhere more common.
 //
 // From here:
-//     https://code.google.com/p/go/source/browse/openpgp/write.go?repo=crypto&r=1e7a3e301825bf9cb32e0535f3761d62d2d369d1#364
 //
+//	https://code.google.com/p/go/source/browse/openpgp/write.go?repo=crypto&r=1e7a3e301825bf9cb32e0535f3761d62d2d369d1#364
 type NopWriteCloser struct {
 	W io.Writer
 }
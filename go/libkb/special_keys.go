@@ -48,19 +48,41 @@ func (sk *SpecialKeyRing) IsValidKID(kid keybase1.KID) bool {
 	return val && found
 }
 
-func LoadPGPKeyFromLocalDB(k keybase1.KID, g *GlobalContext) (*PGPKeyBundle, error) {
-	dbobj, err := g.LocalDb.Get(DbKey{
-		Typ: DBPGPKey,
-		Key: k.String(),
-	})
-	if err != nil {
+func LoadPGPKeyFromLocalDB(mctx MetaContext, k keybase1.KID) (*PGPKeyBundle, error) {
+	migrateLegacyPGPKeyDbRow(mctx, k)
+
+	dbKey := pgpKeyDbKey(mctx, k)
+	raw, found, err := mctx.G().LocalDb.GetRaw(dbKey)
+	if IsDbLockOrCorruptionError(err) {
+		// Reads must never block crypto paths on LocalDb being unavailable:
+		// fall back to whatever this process has cached in memory, and
+		// treat a miss there the same as a miss on disk.
+		mctx.G().MarkLocalDbDegraded(mctx, err.Error())
+		if cached, ok := mctx.G().DegradedCacheGet(dbKey); ok {
+			raw, found, err = cached, true, nil
+		} else {
+			return nil, nil
+		}
+	}
+	if err != nil || !found {
 		return nil, err
 	}
-	if dbobj == nil {
+
+	armored, upgraded, changed, ok := decodePGPKeyDbRow(raw)
+	if !ok {
+		// Corrupt, or from a version newer than this client understands:
+		// treat it as a miss rather than erroring the caller out.
+		mctx.Debug("| LoadPGPKeyFromLocalDB: unreadable row for %s, treating as a miss", k)
 		return nil, nil
 	}
-	kb, w, err := GetOneKey(dbobj)
-	w.Warn(g)
+	if changed {
+		if err := mctx.G().LocalDb.PutRaw(dbKey, upgraded); err != nil {
+			mctx.Debug("| LoadPGPKeyFromLocalDB: failed to write back upgraded row for %s: %s", k, err)
+		}
+	}
+
+	kb, w, err := mctx.G().PGPEntityCache.GetOrParse(cleanPGPInput(armored), armored)
+	w.Warn(mctx.G())
 	return kb, err
 }
 
@@ -83,7 +105,7 @@ func (sk *SpecialKeyRing) Load(m MetaContext, kid keybase1.KID) (GenericKey, err
 		return key, nil
 	}
 
-	key, err := LoadPGPKeyFromLocalDB(kid, m.G())
+	key, err := LoadPGPKeyFromLocalDB(m, kid)
 
 	if err != nil || key == nil {
 
@@ -103,7 +125,7 @@ func (sk *SpecialKeyRing) Load(m MetaContext, kid keybase1.KID) (GenericKey, err
 		if err == nil {
 			w.Warn(sk.G())
 
-			if e2 := key.StoreToLocalDb(m.G()); e2 != nil {
+			if e2 := key.StoreToLocalDb(m); e2 != nil {
 				m.Warning("Failed to store key: %s", e2)
 			}
 		}
@@ -1,6 +1,7 @@
 // Copyright 2015 Keybase, Inc. All rights reserved. Use of
 // this source code is governed by the included BSD license.
 
+//go:build !production
 // +build !production
 
 package libkb
@@ -194,6 +195,22 @@ func (tc *TestContext) MakePGPKey(id string) (*PGPKeyBundle, error) {
 	return GeneratePGPKeyBundle(tc.G, arg, tc.G.UI.GetLogUI())
 }
 
+func (tc *TestContext) MakeEdDSAPGPKey(id string) (*PGPKeyBundle, error) {
+	arg := PGPGenArg{
+		Eddsa:   true,
+		PGPUids: []string{id},
+	}
+	err := arg.Init()
+	if err != nil {
+		return nil, err
+	}
+	err = arg.CreatePGPIDs()
+	if err != nil {
+		return nil, err
+	}
+	return GeneratePGPKeyBundle(tc.G, arg, tc.G.UI.GetLogUI())
+}
+
 // SimulatServiceRestart simulates a shutdown and restart (for client
 // state). Used by tests that need to clear out cached login state
 // without logging out.
@@ -486,6 +503,21 @@ func (t *TestCountSecretUI) GetPassphrase(p keybase1.GUIEntryArg, terminal *keyb
 	}, nil
 }
 
+// TestProgressSecretUI is a TestSecretUI that also implements
+// PGPUnlockProgressUI, recording every PGPKeyUnlockProgress call it sees.
+// If AbortAfter is nonzero, it requests an abort once that many keys have
+// been unlocked.
+type TestProgressSecretUI struct {
+	TestSecretUI
+	AbortAfter int
+	Progress   []int
+}
+
+func (t *TestProgressSecretUI) PGPKeyUnlockProgress(unlocked, total int) bool {
+	t.Progress = append(t.Progress, unlocked)
+	return t.AbortAfter != 0 && unlocked >= t.AbortAfter
+}
+
 type TestLoginUI struct {
 	Username                 string
 	RevokeBackup             bool
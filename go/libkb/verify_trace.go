@@ -0,0 +1,40 @@
+// Copyright 2015 Keybase, Inc. All rights reserved. Use of
+// this source code is governed by the included BSD license.
+
+package libkb
+
+import (
+	keybase1 "github.com/keybase/client/go/protocol/keybase1"
+)
+
+// MetaContext implements VerifyContextHooks directly (rather than via a
+// separate wrapper type) so that every existing VerifyString/VerifyAndExtract
+// call site -- virtually all of which already pass a MetaContext as their
+// VerifyContext -- picks up structured tracing for free once
+// GlobalContext.Env.GetVerifyTraceEnabled() is turned on, with no plumbing
+// changes required at the call site. Each hook is a no-op unless tracing is
+// enabled, so the normal case costs nothing but the bool check.
+var _ VerifyContextHooks = MetaContext{}
+
+func (m MetaContext) OnVerifyAttempt(keyFingerprint string, sigID keybase1.SigIDBase) {
+	if !m.g.Env.GetVerifyTraceEnabled() {
+		return
+	}
+	m.Debug("VerifyTrace: attempt key=%s sigID=%s", keyFingerprint, sigID)
+}
+
+func (m MetaContext) OnVerifyFailure(reason VerifyFailureReason, details string) {
+	if !m.g.Env.GetVerifyTraceEnabled() {
+		return
+	}
+	m.Debug("VerifyTrace: failure reason=%q details=%s", reason, details)
+}
+
+func (m MetaContext) OnVerifyWarning(keyFingerprint string, sigID keybase1.SigIDBase, warnings HashSecurityWarnings) {
+	if !m.g.Env.GetVerifyTraceEnabled() {
+		return
+	}
+	for _, warning := range warnings.Strings() {
+		m.Debug("VerifyTrace: warning key=%s sigID=%s warning=%q", keyFingerprint, sigID, warning)
+	}
+}
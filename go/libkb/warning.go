@@ -5,6 +5,7 @@ package libkb
 
 import (
 	"fmt"
+	"strings"
 )
 
 type Warning interface {
@@ -33,8 +34,41 @@ func ErrorToWarning(e error) Warning {
 	return StringWarning(e.Error())
 }
 
+// CodedWarning is a Warning that can identify its own category (Code) and
+// the specific value within that category (Field), e.g. code "Bad subkey"
+// and field "<fingerprint>". Warnings implementing this get exact dedup and
+// structured per-code counts; plain Warnings fall back to splitting their
+// Warning() string on the first ": ".
+type CodedWarning interface {
+	Warning
+	WarningCode() string
+	WarningField() string
+}
+
+// warningCodeAndField derives the dedup/reporting key for a Warning, using
+// CodedWarning when available and otherwise treating the text before the
+// first ": " as the code and the remainder as the field.
+func warningCodeAndField(e Warning) (code, field string) {
+	if cw, ok := e.(CodedWarning); ok {
+		return cw.WarningCode(), cw.WarningField()
+	}
+	s := e.Warning()
+	if i := strings.Index(s, ": "); i >= 0 {
+		return s[:i], s[i+2:]
+	}
+	return s, ""
+}
+
+// Warnings collects Warning values pushed during an operation (such as
+// importing a PGP key), deduplicating exact repeats and capping how many
+// unique entries are retained so that a pathological input can't blow up
+// logs or UI reports.
 type Warnings struct {
-	w []Warning
+	w       []Warning
+	counts  []int
+	index   map[string]int
+	cap     int
+	dropped int
 }
 
 func (w Warnings) Warnings() []Warning {
@@ -45,8 +79,68 @@ func (w Warnings) IsEmpty() bool {
 	return w.w == nil || len(w.w) == 0
 }
 
+// SetCap bounds how many unique warnings Push will retain. Once the cap is
+// reached, further never-before-seen warnings are counted in Dropped()
+// instead of being appended. A cap of 0 (the zero value) means unlimited,
+// preserving prior unbounded behavior for existing callers.
+func (w *Warnings) SetCap(n int) {
+	w.cap = n
+}
+
+// Dropped returns the number of distinct warnings that were discarded
+// because the cap set by SetCap had already been reached. It does not count
+// duplicates of warnings already being tracked -- those are merged instead.
+func (w Warnings) Dropped() int {
+	return w.dropped
+}
+
+// Count returns how many times a warning with the same code and field as e
+// was pushed, or 0 if it was never pushed (or was dropped by the cap).
+func (w Warnings) Count(e Warning) int {
+	key := warningKey(warningCodeAndField(e))
+	if idx, ok := w.index[key]; ok {
+		return w.counts[idx]
+	}
+	return 0
+}
+
+// CountsByCode totals how many warnings (including merged duplicates) were
+// retained under each code, for structured reporting.
+func (w Warnings) CountsByCode() map[string]int {
+	res := make(map[string]int)
+	for i, e := range w.w {
+		code, _ := warningCodeAndField(e)
+		res[code] += w.counts[i]
+	}
+	return res
+}
+
+func warningKey(code, field string) string {
+	return code + "\x00" + field
+}
+
+// Push records a warning, merging it into an existing entry with the same
+// code and field if one is already tracked, or dropping it (and
+// incrementing Dropped) if the cap set by SetCap has been reached.
 func (w *Warnings) Push(e Warning) {
+	if e == nil {
+		return
+	}
+	key := warningKey(warningCodeAndField(e))
+	if w.index == nil {
+		w.index = make(map[string]int)
+	}
+	if idx, ok := w.index[key]; ok {
+		w.counts[idx]++
+		return
+	}
+	if w.cap > 0 && len(w.w) >= w.cap {
+		w.dropped++
+		return
+	}
+	w.index[key] = len(w.w)
 	w.w = append(w.w, e)
+	w.counts = append(w.counts, 1)
 }
 
 func (w Warnings) Warn(g *GlobalContext) {
@@ -0,0 +1,55 @@
+// Copyright 2015 Keybase, Inc. All rights reserved. Use of
+// this source code is governed by the included BSD license.
+
+package libkb
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWarningsSmallSetUnchanged(t *testing.T) {
+	var w Warnings
+	require.True(t, w.IsEmpty())
+
+	w.Push(Warningf("Bad subkey: %s", "aaa"))
+	w.Push(Warningf("Bad subkey: %s", "bbb"))
+	w.Push(StringWarning("weak hash"))
+
+	require.False(t, w.IsEmpty())
+	require.Len(t, w.Warnings(), 3)
+	require.Equal(t, 0, w.Dropped())
+}
+
+func TestWarningsDedupOnPush(t *testing.T) {
+	var w Warnings
+
+	w.Push(Warningf("Bad subkey: %s", "aaa"))
+	w.Push(Warningf("Bad subkey: %s", "aaa"))
+	w.Push(Warningf("Bad subkey: %s", "aaa"))
+	w.Push(Warningf("Bad subkey: %s", "bbb"))
+
+	// Exact repeats collapse into one entry each, but distinct fields
+	// (aaa vs bbb) stay separate.
+	require.Len(t, w.Warnings(), 2)
+	require.Equal(t, 3, w.Count(Warningf("Bad subkey: %s", "aaa")))
+	require.Equal(t, 1, w.Count(Warningf("Bad subkey: %s", "bbb")))
+
+	counts := w.CountsByCode()
+	require.Equal(t, 4, counts["Bad subkey"])
+}
+
+func TestWarningsCap(t *testing.T) {
+	var w Warnings
+	w.SetCap(2)
+
+	w.Push(Warningf("Bad subkey: %s", "aaa"))
+	w.Push(Warningf("Bad subkey: %s", "bbb"))
+	w.Push(Warningf("Bad subkey: %s", "ccc"))
+	w.Push(Warningf("Bad subkey: %s", "aaa")) // duplicate of a retained entry
+
+	require.Len(t, w.Warnings(), 2)
+	require.Equal(t, 1, w.Dropped())
+	require.Equal(t, 2, w.Count(Warningf("Bad subkey: %s", "aaa")))
+}
@@ -0,0 +1,117 @@
+// Copyright 2015 Keybase, Inc. All rights reserved. Use of
+// this source code is governed by the included BSD license.
+
+package libkb
+
+import (
+	"crypto/sha1"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+)
+
+// zbase32Alphabet is the z-base-32 alphabet (https://philzimmermann.com/docs/human-oriented-base-32-encoding.txt)
+// used to encode a WKD lookup's local-part hash.
+const zbase32Alphabet = "ybndrfg8ejkmcpqxot1uwisza345h769"
+
+// zbase32Encode encodes b using z-base-32, without padding. WKD only ever
+// encodes a 20-byte SHA-1 hash, so that's all this needs to handle.
+func zbase32Encode(b []byte) string {
+	var out strings.Builder
+	var buf uint32
+	var bits uint
+	for _, c := range b {
+		buf = buf<<8 | uint32(c)
+		bits += 8
+		for bits >= 5 {
+			bits -= 5
+			out.WriteByte(zbase32Alphabet[(buf>>bits)&0x1f])
+		}
+	}
+	if bits > 0 {
+		out.WriteByte(zbase32Alphabet[(buf<<(5-bits))&0x1f])
+	}
+	return out.String()
+}
+
+// WKDClient looks up PGP keys via the Web Key Directory protocol
+// (https://datatracker.ietf.org/doc/html/draft-koch-openpgp-webkey-service),
+// so that "keybase pgp encrypt" and identify can find a recipient's key from
+// their mail domain even when they don't have one hosted on Keybase.
+type WKDClient struct {
+	Contextified
+	cli *http.Client
+}
+
+func NewWKDClient(g *GlobalContext) *WKDClient {
+	return &WKDClient{
+		Contextified: NewContextified(g),
+		cli:          &http.Client{Timeout: HTTPDefaultTimeout},
+	}
+}
+
+// Fetch looks up email's key via WKD, trying the "advanced" method
+// (a dedicated openpgpkey subdomain) first and falling back to the
+// "direct" method (same domain) if that doesn't resolve.
+func (w *WKDClient) Fetch(mctx MetaContext, email string) (*PGPKeyBundle, error) {
+	local, domain, err := splitEmail(email)
+	if err != nil {
+		return nil, err
+	}
+	hash := zbase32Encode(sha1Sum(strings.ToLower(local)))
+
+	advanced := fmt.Sprintf("https://openpgpkey.%s/.well-known/openpgpkey/%s/hu/%s?l=%s",
+		domain, domain, hash, local)
+	direct := fmt.Sprintf("https://%s/.well-known/openpgpkey/hu/%s?l=%s",
+		domain, hash, local)
+
+	body, err := w.get(mctx, advanced)
+	if err != nil {
+		mctx.Debug("WKDClient.Fetch: advanced method failed for %s: %s; trying direct method", email, err)
+		body, err = w.get(mctx, direct)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	bundle, warnings, err := ReadOneKeyFromBytes(body)
+	warnings.Warn(w.G())
+	return bundle, err
+}
+
+func (w *WKDClient) get(mctx MetaContext, uri string) ([]byte, error) {
+	req, err := http.NewRequest("GET", uri, nil)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(mctx.Ctx())
+
+	resp, err := w.cli.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, NoKeyError{fmt.Sprintf("no WKD entry found at %s", uri)}
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("WKD lookup failed: %s returned %s", uri, resp.Status)
+	}
+
+	return ioutil.ReadAll(resp.Body)
+}
+
+func splitEmail(email string) (local, domain string, err error) {
+	idx := strings.LastIndex(email, "@")
+	if idx < 0 || idx == len(email)-1 {
+		return "", "", fmt.Errorf("not a valid email address: %q", email)
+	}
+	return email[:idx], email[idx+1:], nil
+}
+
+func sha1Sum(s string) []byte {
+	sum := sha1.Sum([]byte(s))
+	return sum[:]
+}
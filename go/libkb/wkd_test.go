@@ -0,0 +1,36 @@
+// Copyright 2015 Keybase, Inc. All rights reserved. Use of
+// this source code is governed by the included BSD license.
+
+package libkb
+
+import "testing"
+
+func TestZbase32EncodeWKDExample(t *testing.T) {
+	// From the WKD draft's example: the local-part "Joe.Doe" hashes to this
+	// z-base-32 string. https://datatracker.ietf.org/doc/html/draft-koch-openpgp-webkey-service
+	hash := zbase32Encode(sha1Sum("joe.doe"))
+	expected := "iy9q119eutrkn8s1mk4r39qejnbu3n5q"
+	if hash != expected {
+		t.Errorf("zbase32Encode: got %q, expected %q", hash, expected)
+	}
+}
+
+func TestSplitEmail(t *testing.T) {
+	local, domain, err := splitEmail("Joe.Doe@Example.ORG")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if local != "Joe.Doe" {
+		t.Errorf("local: got %q, expected %q", local, "Joe.Doe")
+	}
+	if domain != "Example.ORG" {
+		t.Errorf("domain: got %q, expected %q", domain, "Example.ORG")
+	}
+
+	if _, _, err := splitEmail("not-an-email"); err == nil {
+		t.Error("expected an error for an address with no '@'")
+	}
+	if _, _, err := splitEmail("joe@"); err == nil {
+		t.Error("expected an error for an address with no domain")
+	}
+}
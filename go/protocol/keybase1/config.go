@@ -167,6 +167,8 @@ type ExtendedStatus struct {
 	LocalSyncCacheDbStats  []string            `codec:"localSyncCacheDbStats" json:"localSyncCacheDbStats"`
 	CacheDirSizeInfo       []DirSizeInfo       `codec:"cacheDirSizeInfo" json:"cacheDirSizeInfo"`
 	UiRouterMapping        map[string]int      `codec:"uiRouterMapping" json:"uiRouterMapping"`
+	LocalDbDegraded        bool                `codec:"localDbDegraded" json:"localDbDegraded"`
+	LocalDbDegradedReason  string              `codec:"localDbDegradedReason" json:"localDbDegradedReason"`
 }
 
 func (o ExtendedStatus) DeepCopy() ExtendedStatus {
@@ -317,6 +319,8 @@ func (o ExtendedStatus) DeepCopy() ExtendedStatus {
 			}
 			return ret
 		})(o.UiRouterMapping),
+		LocalDbDegraded:       o.LocalDbDegraded,
+		LocalDbDegradedReason: o.LocalDbDegradedReason,
 	}
 }
 
@@ -981,6 +985,11 @@ type GetProxyDataArg struct {
 type ToggleRuntimeStatsArg struct {
 }
 
+type SetLogModuleLevelArg struct {
+	Module string   `codec:"module" json:"module"`
+	Level  LogLevel `codec:"level" json:"level"`
+}
+
 type AppendGUILogsArg struct {
 	Content string `codec:"content" json:"content"`
 }
@@ -1030,6 +1039,10 @@ type ConfigInterface interface {
 	SetProxyData(context.Context, ProxyData) error
 	GetProxyData(context.Context) (ProxyData, error)
 	ToggleRuntimeStats(context.Context) error
+	// SetLogModuleLevel overrides the log level for a single module (e.g.
+	// "stellar", "kbfs-notify") at runtime, without affecting the global
+	// log level or any other module's override.
+	SetLogModuleLevel(context.Context, SetLogModuleLevelArg) error
 	AppendGUILogs(context.Context, string) error
 	GenerateWebAuthToken(context.Context) (string, error)
 	UpdateLastLoggedInAndServerConfig(context.Context, string) error
@@ -1464,6 +1477,21 @@ func ConfigProtocol(i ConfigInterface) rpc.Protocol {
 					return
 				},
 			},
+			"setLogModuleLevel": {
+				MakeArg: func() interface{} {
+					var ret [1]SetLogModuleLevelArg
+					return &ret
+				},
+				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
+					typedArgs, ok := args.(*[1]SetLogModuleLevelArg)
+					if !ok {
+						err = rpc.NewTypeError((*[1]SetLogModuleLevelArg)(nil), args)
+						return
+					}
+					err = i.SetLogModuleLevel(ctx, typedArgs[0])
+					return
+				},
+			},
 			"appendGUILogs": {
 				MakeArg: func() interface{} {
 					var ret [1]AppendGUILogsArg
@@ -1685,6 +1713,11 @@ func (c ConfigClient) ToggleRuntimeStats(ctx context.Context) (err error) {
 	return
 }
 
+func (c ConfigClient) SetLogModuleLevel(ctx context.Context, __arg SetLogModuleLevelArg) (err error) {
+	err = c.Cli.Call(ctx, "keybase.1.config.setLogModuleLevel", []interface{}{__arg}, nil, 0*time.Millisecond)
+	return
+}
+
 func (c ConfigClient) AppendGUILogs(ctx context.Context, content string) (err error) {
 	__arg := AppendGUILogsArg{Content: content}
 	err = c.Cli.Call(ctx, "keybase.1.config.appendGUILogs", []interface{}{__arg}, nil, 0*time.Millisecond)
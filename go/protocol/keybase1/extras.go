@@ -68,6 +68,62 @@ const (
 	KidVersion = 0x1
 )
 
+// kidAlgoBytes are the known values of a KID's second byte, the algorithm
+// type. This mirrors kbcrypto.AlgoType, but is duplicated here (rather than
+// imported) to avoid a keybase1 -> kbcrypto import cycle, the same way
+// KidVersion above duplicates kbcrypto.KeybaseKIDV1. Keep in sync with
+// go/kbcrypto/algotype.go.
+var kidAlgoBytes = map[byte]bool{
+	0x00: true, // kbcrypto.KIDPGPBase
+	0x01: true, // kbcrypto.KIDPGPRsa
+	0x10: true, // kbcrypto.KIDPGPElgamal
+	0x11: true, // kbcrypto.KIDPGPDsa
+	0x12: true, // kbcrypto.KIDPGPEcdh
+	0x13: true, // kbcrypto.KIDPGPEcdsa
+	0x16: true, // kbcrypto.KIDPGPEddsa
+	0x20: true, // kbcrypto.KIDNaclEddsa
+	0x21: true, // kbcrypto.KIDNaclDH
+}
+
+// BadKIDError names the specific defect found by ValidateKID or
+// ValidateBinaryKID, so a caller debugging a malformed KID from the server or
+// an old DB row doesn't have to reverse-engineer which check failed.
+type BadKIDError struct {
+	Msg string
+}
+
+func (e BadKIDError) Error() string {
+	return "bad KID: " + e.Msg
+}
+
+// ValidateBinaryKID checks b for the defects that have historically shown up
+// as confusing failures far from the source: wrong length, an unknown
+// version or algorithm byte, or a missing suffix byte. An empty BinaryKID is
+// valid -- it means "no such key."
+func ValidateBinaryKID(b BinaryKID) error {
+	if len(b) == 0 {
+		return nil
+	}
+	if len(b) != KidLen {
+		return BadKIDError{Msg: fmt.Sprintf("wrong length; wanted %d but got %d bytes", KidLen, len(b))}
+	}
+	if b[0] != KidVersion {
+		return BadKIDError{Msg: fmt.Sprintf("bad version byte: got 0x%02x, wanted 0x%02x", b[0], KidVersion)}
+	}
+	if !kidAlgoBytes[b[1]] {
+		return BadKIDError{Msg: fmt.Sprintf("unknown algorithm byte: 0x%02x", b[1])}
+	}
+	if b[len(b)-1] != KidSuffix {
+		return BadKIDError{Msg: fmt.Sprintf("bad suffix byte: got 0x%02x, wanted 0x%02x", b[len(b)-1], KidSuffix)}
+	}
+	return nil
+}
+
+// ValidateKID is ValidateBinaryKID for the hex-string form of a KID.
+func ValidateKID(k KID) error {
+	return ValidateBinaryKID(k.ToBinaryKID())
+}
+
 const redactedReplacer = "[REDACTED]"
 
 func Unquote(data []byte) string {
@@ -123,6 +179,9 @@ func KIDFromStringChecked(s string) (KID, error) {
 		return KID(""), fmt.Errorf("Bad KID version; got 0x%02x but wanted 0x%02x",
 			b[0], KidVersion)
 	}
+	if !kidAlgoBytes[b[1]] {
+		return KID(""), fmt.Errorf("Bad KID algorithm type: 0x%02x", b[1])
+	}
 	return KID(s), nil
 }
 
@@ -218,3 +218,62 @@ func TestRedact(t *testing.T) {
 	arg.Redact()
 	require.Equal(t, strings.Split(cmd2, " "), arg.Argv)
 }
+
+func validTestBinaryKID() BinaryKID {
+	kid, err := KIDFromStringChecked("0120aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa0a")
+	if err != nil {
+		panic(err)
+	}
+	return kid.ToBinaryKID()
+}
+
+func TestValidateBinaryKIDValid(t *testing.T) {
+	require.NoError(t, ValidateBinaryKID(validTestBinaryKID()))
+	require.NoError(t, ValidateBinaryKID(nil), "empty BinaryKID means no key, and is valid")
+}
+
+// TestValidateBinaryKIDTruncated checks that every truncation of a valid
+// BinaryKID is rejected with a BadKIDError, never a panic.
+func TestValidateBinaryKIDTruncated(t *testing.T) {
+	good := validTestBinaryKID()
+	for n := 1; n < len(good); n++ {
+		err := ValidateBinaryKID(good[:n])
+		require.Error(t, err, "truncation to %d bytes should be invalid", n)
+		require.IsType(t, BadKIDError{}, err)
+	}
+}
+
+// TestValidateBinaryKIDBitFlips checks that flipping any single bit of a
+// valid BinaryKID either leaves it valid (a hash byte, or an algorithm byte
+// that lands on another known algorithm) or is caught with a BadKIDError,
+// never a panic.
+func TestValidateBinaryKIDBitFlips(t *testing.T) {
+	good := validTestBinaryKID()
+	for i := range good {
+		for bit := 0; bit < 8; bit++ {
+			flipped := append(BinaryKID{}, good...)
+			flipped[i] ^= 1 << uint(bit)
+			err := ValidateBinaryKID(flipped)
+			switch {
+			case i == 1 && kidAlgoBytes[flipped[1]]:
+				// 0x20 flips to 0x21 or 0x00, both known algorithm bytes.
+				require.NoError(t, err)
+			case i == 0 || i == 1 || i == len(flipped)-1:
+				require.Error(t, err, "flipping bit %d of byte %d should be invalid", bit, i)
+				require.IsType(t, BadKIDError{}, err)
+			default:
+				// Flipping a hash byte doesn't affect any field we check.
+				require.NoError(t, err)
+			}
+		}
+	}
+}
+
+func TestValidateKID(t *testing.T) {
+	good := validTestBinaryKID()
+	require.NoError(t, ValidateKID(good.ToKID()))
+	require.NoError(t, ValidateKID(KID("")))
+
+	truncated := KIDFromSlice(good[:len(good)-2])
+	require.Error(t, ValidateKID(truncated))
+}
@@ -4,6 +4,8 @@
 package keybase1
 
 import (
+	"fmt"
+
 	"github.com/keybase/go-framed-msgpack-rpc/rpc"
 	context "golang.org/x/net/context"
 	"time"
@@ -84,10 +86,55 @@ type EncryptFavoritesArg struct {
 	DataToEncrypt []byte `codec:"dataToEncrypt" json:"dataToEncrypt"`
 }
 
+type KeyGenerationRotatedArg struct {
+	Folder           Folder `codec:"folder" json:"folder"`
+	NewKeyGeneration int    `codec:"newKeyGeneration" json:"newKeyGeneration"`
+}
+
+type DirListProgressArg struct {
+	Path          string `codec:"path" json:"path"`
+	EntriesLoaded int    `codec:"entriesLoaded" json:"entriesLoaded"`
+	Complete      bool   `codec:"complete" json:"complete"`
+}
+
+type OfflineEditsAtRiskArg struct {
+	Folder    Folder `codec:"folder" json:"folder"`
+	NumEdits  int    `codec:"numEdits" json:"numEdits"`
+	Discarded bool   `codec:"discarded" json:"discarded"`
+}
+
+type RemoteFileUpdatedArg struct {
+	Path       string `codec:"path" json:"path"`
+	Revision   int64  `codec:"revision" json:"revision"`
+	ModifiedBy string `codec:"modifiedBy" json:"modifiedBy"`
+}
+
+type FolderPathChangedArg struct {
+	OldPath string `codec:"oldPath" json:"oldPath"`
+	NewPath string `codec:"newPath" json:"newPath"`
+}
+
+type SnapshotCompleteArg struct {
+	Folder    Folder `codec:"folder" json:"folder"`
+	Revision  int64  `codec:"revision" json:"revision"`
+	SizeBytes int64  `codec:"sizeBytes" json:"sizeBytes"`
+}
+
 type DecryptFavoritesArg struct {
 	DataToEncrypt []byte `codec:"dataToEncrypt" json:"dataToEncrypt"`
 }
 
+type StagedChangesCountArg struct {
+	Folder Folder `codec:"folder" json:"folder"`
+	Count  int    `codec:"count" json:"count"`
+}
+
+type ForegroundFetchCompleteArg struct {
+	Path    string `codec:"path" json:"path"`
+	Success bool   `codec:"success" json:"success"`
+	Error   string `codec:"error" json:"error"`
+}
+
 type KbfsInterface interface {
 	// Idea is that kbfs would call the function below whenever these actions are
 	// performed on a file.
@@ -130,6 +177,49 @@ type KbfsInterface interface {
 	EncryptFavorites(context.Context, []byte) ([]byte, error)
 	// Decrypt cached favorites stored on disk.
 	DecryptFavorites(context.Context, []byte) ([]byte, error)
+	// FSKeyGenerationRotated is called by KBFS when a TLF's encryption key is
+	// rotated to a new generation, so that clients can note it for a
+	// security-audit log.
+	FSKeyGenerationRotated(context.Context, KeyGenerationRotatedArg) error
+	// FSDirListProgress is called by KBFS while it's still enumerating a
+	// large directory, so the GUI can show a progressive listing with a
+	// spinner instead of blocking on the full enumeration.
+	FSDirListProgress(context.Context, DirListProgressArg) error
+	// FSRemoteFileUpdated is called by KBFS when the server version of a
+	// file that's open locally advances past what the client has seen, so
+	// an editor can prompt the user to reload instead of silently
+	// overwriting the newer version on save.
+	FSRemoteFileUpdated(context.Context, RemoteFileUpdatedArg) error
+	// FSFolderPathChanged is called by KBFS when a folder's canonical path
+	// changes, e.g. because a member of an implicit team folder changed
+	// usernames, so the GUI can update breadcrumbs and open handles to the
+	// new canonical path.
+	FSFolderPathChanged(context.Context, FolderPathChangedArg) error
+	// FSSnapshotComplete is called by KBFS once a background-archive
+	// snapshot of a folder has been fully persisted, so the GUI can confirm
+	// to the user that the snapshot is ready to restore from.
+	FSSnapshotComplete(context.Context, SnapshotCompleteArg) error
+	// FSOfflineEditsAtRisk is called by KBFS when a conflict resolution is
+	// about to discard (or has already discarded) edits made while offline,
+	// so the GUI can warn the user to save a copy before they're lost.
+	FSOfflineEditsAtRisk(context.Context, OfflineEditsAtRiskArg) error
+	// FSStagedChangesCount is called by KBFS whenever the number of locally
+	// staged-but-not-yet-flushed operations for a folder changes, so the
+	// GUI can show an "N unsaved changes" badge.
+	FSStagedChangesCount(context.Context, StagedChangesCountArg) error
+	// FSForegroundFetchComplete is called by KBFS when a foreground fetch
+	// triggered by a user opening a not-yet-cached file finishes, so the
+	// GUI can transition from a loading spinner to showing the file (or an
+	// error) instead of waiting on a background FSSyncEvent.
+	FSForegroundFetchComplete(context.Context, ForegroundFetchCompleteArg) error
+}
+
+// newKbfsTypeError wraps rpc.NewTypeError with the protocol and method name
+// being served, so a decode mismatch between mismatched client and server
+// versions of this protocol can be attributed to the offending RPC from the
+// log line alone, rather than just the bare Go type names.
+func newKbfsTypeError(method string, expected, actual interface{}) error {
+	return fmt.Errorf("keybase.1.kbfs.%s: %v", method, rpc.NewTypeError(expected, actual))
 }
 
 func KbfsProtocol(i KbfsInterface) rpc.Protocol {
@@ -144,7 +234,7 @@ func KbfsProtocol(i KbfsInterface) rpc.Protocol {
 				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
 					typedArgs, ok := args.(*[1]FSEventArg)
 					if !ok {
-						err = rpc.NewTypeError((*[1]FSEventArg)(nil), args)
+						err = newKbfsTypeError("FSEvent", (*[1]FSEventArg)(nil), args)
 						return
 					}
 					err = i.FSEvent(ctx, typedArgs[0].Event)
@@ -159,7 +249,7 @@ func KbfsProtocol(i KbfsInterface) rpc.Protocol {
 				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
 					typedArgs, ok := args.(*[1]FSPathUpdateArg)
 					if !ok {
-						err = rpc.NewTypeError((*[1]FSPathUpdateArg)(nil), args)
+						err = newKbfsTypeError("FSPathUpdate", (*[1]FSPathUpdateArg)(nil), args)
 						return
 					}
 					err = i.FSPathUpdate(ctx, typedArgs[0].Path)
@@ -174,7 +264,7 @@ func KbfsProtocol(i KbfsInterface) rpc.Protocol {
 				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
 					typedArgs, ok := args.(*[1]FSEditListArg)
 					if !ok {
-						err = rpc.NewTypeError((*[1]FSEditListArg)(nil), args)
+						err = newKbfsTypeError("FSEditList", (*[1]FSEditListArg)(nil), args)
 						return
 					}
 					err = i.FSEditList(ctx, typedArgs[0])
@@ -189,7 +279,7 @@ func KbfsProtocol(i KbfsInterface) rpc.Protocol {
 				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
 					typedArgs, ok := args.(*[1]FSSyncStatusArg)
 					if !ok {
-						err = rpc.NewTypeError((*[1]FSSyncStatusArg)(nil), args)
+						err = newKbfsTypeError("FSSyncStatus", (*[1]FSSyncStatusArg)(nil), args)
 						return
 					}
 					err = i.FSSyncStatus(ctx, typedArgs[0])
@@ -204,7 +294,7 @@ func KbfsProtocol(i KbfsInterface) rpc.Protocol {
 				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
 					typedArgs, ok := args.(*[1]FSSyncEventArg)
 					if !ok {
-						err = rpc.NewTypeError((*[1]FSSyncEventArg)(nil), args)
+						err = newKbfsTypeError("FSSyncEvent", (*[1]FSSyncEventArg)(nil), args)
 						return
 					}
 					err = i.FSSyncEvent(ctx, typedArgs[0].Event)
@@ -219,7 +309,7 @@ func KbfsProtocol(i KbfsInterface) rpc.Protocol {
 				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
 					typedArgs, ok := args.(*[1]FSOverallSyncEventArg)
 					if !ok {
-						err = rpc.NewTypeError((*[1]FSOverallSyncEventArg)(nil), args)
+						err = newKbfsTypeError("FSOverallSyncEvent", (*[1]FSOverallSyncEventArg)(nil), args)
 						return
 					}
 					err = i.FSOverallSyncEvent(ctx, typedArgs[0].Status)
@@ -234,7 +324,7 @@ func KbfsProtocol(i KbfsInterface) rpc.Protocol {
 				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
 					typedArgs, ok := args.(*[1]FSOnlineStatusChangedEventArg)
 					if !ok {
-						err = rpc.NewTypeError((*[1]FSOnlineStatusChangedEventArg)(nil), args)
+						err = newKbfsTypeError("FSOnlineStatusChangedEvent", (*[1]FSOnlineStatusChangedEventArg)(nil), args)
 						return
 					}
 					err = i.FSOnlineStatusChangedEvent(ctx, typedArgs[0].Online)
@@ -259,7 +349,7 @@ func KbfsProtocol(i KbfsInterface) rpc.Protocol {
 				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
 					typedArgs, ok := args.(*[1]FSSubscriptionNotifyPathEventArg)
 					if !ok {
-						err = rpc.NewTypeError((*[1]FSSubscriptionNotifyPathEventArg)(nil), args)
+						err = newKbfsTypeError("FSSubscriptionNotifyPathEvent", (*[1]FSSubscriptionNotifyPathEventArg)(nil), args)
 						return
 					}
 					err = i.FSSubscriptionNotifyPathEvent(ctx, typedArgs[0])
@@ -274,7 +364,7 @@ func KbfsProtocol(i KbfsInterface) rpc.Protocol {
 				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
 					typedArgs, ok := args.(*[1]FSSubscriptionNotifyEventArg)
 					if !ok {
-						err = rpc.NewTypeError((*[1]FSSubscriptionNotifyEventArg)(nil), args)
+						err = newKbfsTypeError("FSSubscriptionNotifyEvent", (*[1]FSSubscriptionNotifyEventArg)(nil), args)
 						return
 					}
 					err = i.FSSubscriptionNotifyEvent(ctx, typedArgs[0])
@@ -289,7 +379,7 @@ func KbfsProtocol(i KbfsInterface) rpc.Protocol {
 				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
 					typedArgs, ok := args.(*[1]CreateTLFArg)
 					if !ok {
-						err = rpc.NewTypeError((*[1]CreateTLFArg)(nil), args)
+						err = newKbfsTypeError("createTLF", (*[1]CreateTLFArg)(nil), args)
 						return
 					}
 					err = i.CreateTLF(ctx, typedArgs[0])
@@ -304,7 +394,7 @@ func KbfsProtocol(i KbfsInterface) rpc.Protocol {
 				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
 					typedArgs, ok := args.(*[1]GetKBFSTeamSettingsArg)
 					if !ok {
-						err = rpc.NewTypeError((*[1]GetKBFSTeamSettingsArg)(nil), args)
+						err = newKbfsTypeError("getKBFSTeamSettings", (*[1]GetKBFSTeamSettingsArg)(nil), args)
 						return
 					}
 					ret, err = i.GetKBFSTeamSettings(ctx, typedArgs[0])
@@ -319,7 +409,7 @@ func KbfsProtocol(i KbfsInterface) rpc.Protocol {
 				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
 					typedArgs, ok := args.(*[1]UpgradeTLFArg)
 					if !ok {
-						err = rpc.NewTypeError((*[1]UpgradeTLFArg)(nil), args)
+						err = newKbfsTypeError("upgradeTLF", (*[1]UpgradeTLFArg)(nil), args)
 						return
 					}
 					err = i.UpgradeTLF(ctx, typedArgs[0])
@@ -334,7 +424,7 @@ func KbfsProtocol(i KbfsInterface) rpc.Protocol {
 				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
 					typedArgs, ok := args.(*[1]EncryptFavoritesArg)
 					if !ok {
-						err = rpc.NewTypeError((*[1]EncryptFavoritesArg)(nil), args)
+						err = newKbfsTypeError("encryptFavorites", (*[1]EncryptFavoritesArg)(nil), args)
 						return
 					}
 					ret, err = i.EncryptFavorites(ctx, typedArgs[0].DataToEncrypt)
@@ -349,13 +439,133 @@ func KbfsProtocol(i KbfsInterface) rpc.Protocol {
 				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
 					typedArgs, ok := args.(*[1]DecryptFavoritesArg)
 					if !ok {
-						err = rpc.NewTypeError((*[1]DecryptFavoritesArg)(nil), args)
+						err = newKbfsTypeError("decryptFavorites", (*[1]DecryptFavoritesArg)(nil), args)
 						return
 					}
 					ret, err = i.DecryptFavorites(ctx, typedArgs[0].DataToEncrypt)
 					return
 				},
 			},
+			"FSKeyGenerationRotated": {
+				MakeArg: func() interface{} {
+					var ret [1]KeyGenerationRotatedArg
+					return &ret
+				},
+				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
+					typedArgs, ok := args.(*[1]KeyGenerationRotatedArg)
+					if !ok {
+						err = newKbfsTypeError("FSKeyGenerationRotated", (*[1]KeyGenerationRotatedArg)(nil), args)
+						return
+					}
+					err = i.FSKeyGenerationRotated(ctx, typedArgs[0])
+					return
+				},
+			},
+			"FSDirListProgress": {
+				MakeArg: func() interface{} {
+					var ret [1]DirListProgressArg
+					return &ret
+				},
+				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
+					typedArgs, ok := args.(*[1]DirListProgressArg)
+					if !ok {
+						err = newKbfsTypeError("FSDirListProgress", (*[1]DirListProgressArg)(nil), args)
+						return
+					}
+					err = i.FSDirListProgress(ctx, typedArgs[0])
+					return
+				},
+			},
+			"FSRemoteFileUpdated": {
+				MakeArg: func() interface{} {
+					var ret [1]RemoteFileUpdatedArg
+					return &ret
+				},
+				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
+					typedArgs, ok := args.(*[1]RemoteFileUpdatedArg)
+					if !ok {
+						err = newKbfsTypeError("FSRemoteFileUpdated", (*[1]RemoteFileUpdatedArg)(nil), args)
+						return
+					}
+					err = i.FSRemoteFileUpdated(ctx, typedArgs[0])
+					return
+				},
+			},
+			"FSFolderPathChanged": {
+				MakeArg: func() interface{} {
+					var ret [1]FolderPathChangedArg
+					return &ret
+				},
+				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
+					typedArgs, ok := args.(*[1]FolderPathChangedArg)
+					if !ok {
+						err = newKbfsTypeError("FSFolderPathChanged", (*[1]FolderPathChangedArg)(nil), args)
+						return
+					}
+					err = i.FSFolderPathChanged(ctx, typedArgs[0])
+					return
+				},
+			},
+			"FSSnapshotComplete": {
+				MakeArg: func() interface{} {
+					var ret [1]SnapshotCompleteArg
+					return &ret
+				},
+				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
+					typedArgs, ok := args.(*[1]SnapshotCompleteArg)
+					if !ok {
+						err = newKbfsTypeError("FSSnapshotComplete", (*[1]SnapshotCompleteArg)(nil), args)
+						return
+					}
+					err = i.FSSnapshotComplete(ctx, typedArgs[0])
+					return
+				},
+			},
+			"FSOfflineEditsAtRisk": {
+				MakeArg: func() interface{} {
+					var ret [1]OfflineEditsAtRiskArg
+					return &ret
+				},
+				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
+					typedArgs, ok := args.(*[1]OfflineEditsAtRiskArg)
+					if !ok {
+						err = newKbfsTypeError("FSOfflineEditsAtRisk", (*[1]OfflineEditsAtRiskArg)(nil), args)
+						return
+					}
+					err = i.FSOfflineEditsAtRisk(ctx, typedArgs[0])
+					return
+				},
+			},
+			"FSStagedChangesCount": {
+				MakeArg: func() interface{} {
+					var ret [1]StagedChangesCountArg
+					return &ret
+				},
+				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
+					typedArgs, ok := args.(*[1]StagedChangesCountArg)
+					if !ok {
+						err = newKbfsTypeError("FSStagedChangesCount", (*[1]StagedChangesCountArg)(nil), args)
+						return
+					}
+					err = i.FSStagedChangesCount(ctx, typedArgs[0])
+					return
+				},
+			},
+			"FSForegroundFetchComplete": {
+				MakeArg: func() interface{} {
+					var ret [1]ForegroundFetchCompleteArg
+					return &ret
+				},
+				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
+					typedArgs, ok := args.(*[1]ForegroundFetchCompleteArg)
+					if !ok {
+						err = newKbfsTypeError("FSForegroundFetchComplete", (*[1]ForegroundFetchCompleteArg)(nil), args)
+						return
+					}
+					err = i.FSForegroundFetchComplete(ctx, typedArgs[0])
+					return
+				},
+			},
 		},
 	}
 }
@@ -471,3 +681,70 @@ func (c KbfsClient) DecryptFavorites(ctx context.Context, dataToEncrypt []byte)
 	err = c.Cli.Call(ctx, "keybase.1.kbfs.decryptFavorites", []interface{}{__arg}, &res, 0*time.Millisecond)
 	return
 }
+
+// FSKeyGenerationRotated is called by KBFS when a TLF's encryption key is
+// rotated to a new generation, so that clients can note it for a
+// security-audit log.
+func (c KbfsClient) FSKeyGenerationRotated(ctx context.Context, __arg KeyGenerationRotatedArg) (err error) {
+	err = c.Cli.Call(ctx, "keybase.1.kbfs.FSKeyGenerationRotated", []interface{}{__arg}, nil, 0*time.Millisecond)
+	return
+}
+
+// FSDirListProgress is called by KBFS while it's still enumerating a large
+// directory, so the GUI can show a progressive listing with a spinner
+// instead of blocking on the full enumeration.
+func (c KbfsClient) FSDirListProgress(ctx context.Context, __arg DirListProgressArg) (err error) {
+	err = c.Cli.Call(ctx, "keybase.1.kbfs.FSDirListProgress", []interface{}{__arg}, nil, 0*time.Millisecond)
+	return
+}
+
+// FSRemoteFileUpdated is called by KBFS when the server version of a file
+// that's open locally advances past what the client has seen, so an editor
+// can prompt the user to reload instead of silently overwriting the newer
+// version on save.
+func (c KbfsClient) FSRemoteFileUpdated(ctx context.Context, __arg RemoteFileUpdatedArg) (err error) {
+	err = c.Cli.Call(ctx, "keybase.1.kbfs.FSRemoteFileUpdated", []interface{}{__arg}, nil, 0*time.Millisecond)
+	return
+}
+
+// FSFolderPathChanged is called by KBFS when a folder's canonical path
+// changes, e.g. because a member of an implicit team folder changed
+// usernames, so the GUI can update breadcrumbs and open handles to the new
+// canonical path.
+func (c KbfsClient) FSFolderPathChanged(ctx context.Context, __arg FolderPathChangedArg) (err error) {
+	err = c.Cli.Call(ctx, "keybase.1.kbfs.FSFolderPathChanged", []interface{}{__arg}, nil, 0*time.Millisecond)
+	return
+}
+
+// FSSnapshotComplete is called by KBFS once a background-archive snapshot
+// of a folder has been fully persisted, so the GUI can confirm to the user
+// that the snapshot is ready to restore from.
+func (c KbfsClient) FSSnapshotComplete(ctx context.Context, __arg SnapshotCompleteArg) (err error) {
+	err = c.Cli.Call(ctx, "keybase.1.kbfs.FSSnapshotComplete", []interface{}{__arg}, nil, 0*time.Millisecond)
+	return
+}
+
+// FSOfflineEditsAtRisk is called by KBFS when a conflict resolution is about
+// to discard (or has already discarded) edits made while offline, so the
+// GUI can warn the user to save a copy before they're lost.
+func (c KbfsClient) FSOfflineEditsAtRisk(ctx context.Context, __arg OfflineEditsAtRiskArg) (err error) {
+	err = c.Cli.Call(ctx, "keybase.1.kbfs.FSOfflineEditsAtRisk", []interface{}{__arg}, nil, 0*time.Millisecond)
+	return
+}
+
+// FSStagedChangesCount is called by KBFS whenever the number of locally
+// staged-but-not-yet-flushed operations for a folder changes, so the GUI
+// can show an "N unsaved changes" badge.
+func (c KbfsClient) FSStagedChangesCount(ctx context.Context, __arg StagedChangesCountArg) (err error) {
+	err = c.Cli.Call(ctx, "keybase.1.kbfs.FSStagedChangesCount", []interface{}{__arg}, nil, 0*time.Millisecond)
+	return
+}
+
+// FSForegroundFetchComplete is called by KBFS when a foreground fetch
+// triggered by a user opening a not-yet-cached file finishes, so the GUI
+// can transition from a loading spinner to showing the file (or an error)
+// instead of waiting on a background FSSyncEvent.
+func (c KbfsClient) FSForegroundFetchComplete(ctx context.Context, __arg ForegroundFetchCompleteArg) (err error) {
+	err = c.Cli.Call(ctx, "keybase.1.kbfs.FSForegroundFetchComplete", []interface{}{__arg}, nil, 0*time.Millisecond)
+	return
+}
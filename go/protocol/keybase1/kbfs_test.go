@@ -0,0 +1,25 @@
+// Copyright 2015 Keybase, Inc. All rights reserved. Use of
+// this source code is governed by the included BSD license.
+
+package keybase1
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestKbfsProtocolTypeErrorIncludesMethod(t *testing.T) {
+	protocol := KbfsProtocol(nil)
+	handler, ok := protocol.Methods["FSPathUpdate"]
+	require.True(t, ok)
+
+	// Deliberately decode the wrong payload shape, as would happen if a
+	// stale client and server disagreed about this RPC's argument type.
+	_, err := handler.Handler(context.Background(), &[1]FSEventArg{})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "keybase.1.kbfs.FSPathUpdate")
+	require.Contains(t, err.Error(), "FSPathUpdateArg")
+	require.Contains(t, err.Error(), "FSEventArg")
+}
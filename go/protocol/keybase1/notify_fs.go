@@ -55,6 +55,51 @@ type FSSubscriptionNotifyArg struct {
 	Topic           SubscriptionTopic `codec:"topic" json:"topic"`
 }
 
+type FSKeyGenerationRotatedArg struct {
+	Folder           Folder `codec:"folder" json:"folder"`
+	NewKeyGeneration int    `codec:"newKeyGeneration" json:"newKeyGeneration"`
+}
+
+type FSDirListProgressArg struct {
+	Path          string `codec:"path" json:"path"`
+	EntriesLoaded int    `codec:"entriesLoaded" json:"entriesLoaded"`
+	Complete      bool   `codec:"complete" json:"complete"`
+}
+
+type FSRemoteFileUpdatedArg struct {
+	Path       string `codec:"path" json:"path"`
+	Revision   int64  `codec:"revision" json:"revision"`
+	ModifiedBy string `codec:"modifiedBy" json:"modifiedBy"`
+}
+
+type FSSnapshotCompleteArg struct {
+	Folder    Folder `codec:"folder" json:"folder"`
+	Revision  int64  `codec:"revision" json:"revision"`
+	SizeBytes int64  `codec:"sizeBytes" json:"sizeBytes"`
+}
+
+type FSFolderPathChangedArg struct {
+	OldPath string `codec:"oldPath" json:"oldPath"`
+	NewPath string `codec:"newPath" json:"newPath"`
+}
+
+type FSOfflineEditsAtRiskArg struct {
+	Folder    Folder `codec:"folder" json:"folder"`
+	NumEdits  int    `codec:"numEdits" json:"numEdits"`
+	Discarded bool   `codec:"discarded" json:"discarded"`
+}
+
+type FSStagedChangesCountArg struct {
+	Folder Folder `codec:"folder" json:"folder"`
+	Count  int    `codec:"count" json:"count"`
+}
+
+type FSForegroundFetchCompleteArg struct {
+	Path    string `codec:"path" json:"path"`
+	Success bool   `codec:"success" json:"success"`
+	Error   string `codec:"error" json:"error"`
+}
+
 type NotifyFSInterface interface {
 	FSActivity(context.Context, FSNotification) error
 	FSPathUpdated(context.Context, string) error
@@ -66,6 +111,14 @@ type NotifyFSInterface interface {
 	FSOnlineStatusChanged(context.Context, bool) error
 	FSSubscriptionNotifyPath(context.Context, FSSubscriptionNotifyPathArg) error
 	FSSubscriptionNotify(context.Context, FSSubscriptionNotifyArg) error
+	FSKeyGenerationRotated(context.Context, FSKeyGenerationRotatedArg) error
+	FSDirListProgress(context.Context, FSDirListProgressArg) error
+	FSRemoteFileUpdated(context.Context, FSRemoteFileUpdatedArg) error
+	FSSnapshotComplete(context.Context, FSSnapshotCompleteArg) error
+	FSFolderPathChanged(context.Context, FSFolderPathChangedArg) error
+	FSOfflineEditsAtRisk(context.Context, FSOfflineEditsAtRiskArg) error
+	FSStagedChangesCount(context.Context, FSStagedChangesCountArg) error
+	FSForegroundFetchComplete(context.Context, FSForegroundFetchCompleteArg) error
 }
 
 func NotifyFSProtocol(i NotifyFSInterface) rpc.Protocol {
@@ -217,6 +270,126 @@ func NotifyFSProtocol(i NotifyFSInterface) rpc.Protocol {
 					return
 				},
 			},
+			"FSKeyGenerationRotated": {
+				MakeArg: func() interface{} {
+					var ret [1]FSKeyGenerationRotatedArg
+					return &ret
+				},
+				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
+					typedArgs, ok := args.(*[1]FSKeyGenerationRotatedArg)
+					if !ok {
+						err = rpc.NewTypeError((*[1]FSKeyGenerationRotatedArg)(nil), args)
+						return
+					}
+					err = i.FSKeyGenerationRotated(ctx, typedArgs[0])
+					return
+				},
+			},
+			"FSDirListProgress": {
+				MakeArg: func() interface{} {
+					var ret [1]FSDirListProgressArg
+					return &ret
+				},
+				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
+					typedArgs, ok := args.(*[1]FSDirListProgressArg)
+					if !ok {
+						err = rpc.NewTypeError((*[1]FSDirListProgressArg)(nil), args)
+						return
+					}
+					err = i.FSDirListProgress(ctx, typedArgs[0])
+					return
+				},
+			},
+			"FSRemoteFileUpdated": {
+				MakeArg: func() interface{} {
+					var ret [1]FSRemoteFileUpdatedArg
+					return &ret
+				},
+				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
+					typedArgs, ok := args.(*[1]FSRemoteFileUpdatedArg)
+					if !ok {
+						err = rpc.NewTypeError((*[1]FSRemoteFileUpdatedArg)(nil), args)
+						return
+					}
+					err = i.FSRemoteFileUpdated(ctx, typedArgs[0])
+					return
+				},
+			},
+			"FSSnapshotComplete": {
+				MakeArg: func() interface{} {
+					var ret [1]FSSnapshotCompleteArg
+					return &ret
+				},
+				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
+					typedArgs, ok := args.(*[1]FSSnapshotCompleteArg)
+					if !ok {
+						err = rpc.NewTypeError((*[1]FSSnapshotCompleteArg)(nil), args)
+						return
+					}
+					err = i.FSSnapshotComplete(ctx, typedArgs[0])
+					return
+				},
+			},
+			"FSFolderPathChanged": {
+				MakeArg: func() interface{} {
+					var ret [1]FSFolderPathChangedArg
+					return &ret
+				},
+				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
+					typedArgs, ok := args.(*[1]FSFolderPathChangedArg)
+					if !ok {
+						err = rpc.NewTypeError((*[1]FSFolderPathChangedArg)(nil), args)
+						return
+					}
+					err = i.FSFolderPathChanged(ctx, typedArgs[0])
+					return
+				},
+			},
+			"FSOfflineEditsAtRisk": {
+				MakeArg: func() interface{} {
+					var ret [1]FSOfflineEditsAtRiskArg
+					return &ret
+				},
+				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
+					typedArgs, ok := args.(*[1]FSOfflineEditsAtRiskArg)
+					if !ok {
+						err = rpc.NewTypeError((*[1]FSOfflineEditsAtRiskArg)(nil), args)
+						return
+					}
+					err = i.FSOfflineEditsAtRisk(ctx, typedArgs[0])
+					return
+				},
+			},
+			"FSStagedChangesCount": {
+				MakeArg: func() interface{} {
+					var ret [1]FSStagedChangesCountArg
+					return &ret
+				},
+				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
+					typedArgs, ok := args.(*[1]FSStagedChangesCountArg)
+					if !ok {
+						err = rpc.NewTypeError((*[1]FSStagedChangesCountArg)(nil), args)
+						return
+					}
+					err = i.FSStagedChangesCount(ctx, typedArgs[0])
+					return
+				},
+			},
+			"FSForegroundFetchComplete": {
+				MakeArg: func() interface{} {
+					var ret [1]FSForegroundFetchCompleteArg
+					return &ret
+				},
+				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
+					typedArgs, ok := args.(*[1]FSForegroundFetchCompleteArg)
+					if !ok {
+						err = rpc.NewTypeError((*[1]FSForegroundFetchCompleteArg)(nil), args)
+						return
+					}
+					err = i.FSForegroundFetchComplete(ctx, typedArgs[0])
+					return
+				},
+			},
 		},
 	}
 }
@@ -279,3 +452,43 @@ func (c NotifyFSClient) FSSubscriptionNotify(ctx context.Context, __arg FSSubscr
 	err = c.Cli.Notify(ctx, "keybase.1.NotifyFS.FSSubscriptionNotify", []interface{}{__arg}, 0*time.Millisecond)
 	return
 }
+
+func (c NotifyFSClient) FSKeyGenerationRotated(ctx context.Context, __arg FSKeyGenerationRotatedArg) (err error) {
+	err = c.Cli.Notify(ctx, "keybase.1.NotifyFS.FSKeyGenerationRotated", []interface{}{__arg}, 0*time.Millisecond)
+	return
+}
+
+func (c NotifyFSClient) FSDirListProgress(ctx context.Context, __arg FSDirListProgressArg) (err error) {
+	err = c.Cli.Notify(ctx, "keybase.1.NotifyFS.FSDirListProgress", []interface{}{__arg}, 0*time.Millisecond)
+	return
+}
+
+func (c NotifyFSClient) FSRemoteFileUpdated(ctx context.Context, __arg FSRemoteFileUpdatedArg) (err error) {
+	err = c.Cli.Notify(ctx, "keybase.1.NotifyFS.FSRemoteFileUpdated", []interface{}{__arg}, 0*time.Millisecond)
+	return
+}
+
+func (c NotifyFSClient) FSSnapshotComplete(ctx context.Context, __arg FSSnapshotCompleteArg) (err error) {
+	err = c.Cli.Notify(ctx, "keybase.1.NotifyFS.FSSnapshotComplete", []interface{}{__arg}, 0*time.Millisecond)
+	return
+}
+
+func (c NotifyFSClient) FSFolderPathChanged(ctx context.Context, __arg FSFolderPathChangedArg) (err error) {
+	err = c.Cli.Notify(ctx, "keybase.1.NotifyFS.FSFolderPathChanged", []interface{}{__arg}, 0*time.Millisecond)
+	return
+}
+
+func (c NotifyFSClient) FSOfflineEditsAtRisk(ctx context.Context, __arg FSOfflineEditsAtRiskArg) (err error) {
+	err = c.Cli.Notify(ctx, "keybase.1.NotifyFS.FSOfflineEditsAtRisk", []interface{}{__arg}, 0*time.Millisecond)
+	return
+}
+
+func (c NotifyFSClient) FSStagedChangesCount(ctx context.Context, __arg FSStagedChangesCountArg) (err error) {
+	err = c.Cli.Notify(ctx, "keybase.1.NotifyFS.FSStagedChangesCount", []interface{}{__arg}, 0*time.Millisecond)
+	return
+}
+
+func (c NotifyFSClient) FSForegroundFetchComplete(ctx context.Context, __arg FSForegroundFetchCompleteArg) (err error) {
+	err = c.Cli.Notify(ctx, "keybase.1.NotifyFS.FSForegroundFetchComplete", []interface{}{__arg}, 0*time.Millisecond)
+	return
+}
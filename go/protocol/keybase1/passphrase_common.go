@@ -41,6 +41,7 @@ const (
 	PassphraseType_PAPER_KEY          PassphraseType = 1
 	PassphraseType_PASS_PHRASE        PassphraseType = 2
 	PassphraseType_VERIFY_PASS_PHRASE PassphraseType = 3
+	PassphraseType_NEW_PASS_PHRASE    PassphraseType = 4
 )
 
 func (o PassphraseType) DeepCopy() PassphraseType { return o }
@@ -50,6 +51,7 @@ var PassphraseTypeMap = map[string]PassphraseType{
 	"PAPER_KEY":          1,
 	"PASS_PHRASE":        2,
 	"VERIFY_PASS_PHRASE": 3,
+	"NEW_PASS_PHRASE":    4,
 }
 
 var PassphraseTypeRevMap = map[PassphraseType]string{
@@ -57,6 +59,7 @@ var PassphraseTypeRevMap = map[PassphraseType]string{
 	1: "PAPER_KEY",
 	2: "PASS_PHRASE",
 	3: "VERIFY_PASS_PHRASE",
+	4: "NEW_PASS_PHRASE",
 }
 
 func (e PassphraseType) String() string {
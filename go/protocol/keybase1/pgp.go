@@ -83,15 +83,96 @@ func (o PGPEncryptOptions) DeepCopy() PGPEncryptOptions {
 	}
 }
 
+type HashSecurityWarningKind int
+
+const (
+	HashSecurityWarningKind_UNKNOWN                  HashSecurityWarningKind = 0
+	HashSecurityWarningKind_SIGNATURE_HASH           HashSecurityWarningKind = 1
+	HashSecurityWarningKind_SIGNERS_IDENTITY_HASH    HashSecurityWarningKind = 2
+	HashSecurityWarningKind_RECIPIENTS_IDENTITY_HASH HashSecurityWarningKind = 3
+	HashSecurityWarningKind_OUR_IDENTITY_HASH        HashSecurityWarningKind = 4
+)
+
+func (o HashSecurityWarningKind) DeepCopy() HashSecurityWarningKind { return o }
+
+var HashSecurityWarningKindMap = map[string]HashSecurityWarningKind{
+	"UNKNOWN":                  0,
+	"SIGNATURE_HASH":           1,
+	"SIGNERS_IDENTITY_HASH":    2,
+	"RECIPIENTS_IDENTITY_HASH": 3,
+	"OUR_IDENTITY_HASH":        4,
+}
+
+var HashSecurityWarningKindRevMap = map[HashSecurityWarningKind]string{
+	0: "UNKNOWN",
+	1: "SIGNATURE_HASH",
+	2: "SIGNERS_IDENTITY_HASH",
+	3: "RECIPIENTS_IDENTITY_HASH",
+	4: "OUR_IDENTITY_HASH",
+}
+
+func (e HashSecurityWarningKind) String() string {
+	if v, ok := HashSecurityWarningKindRevMap[e]; ok {
+		return v
+	}
+	return fmt.Sprintf("%v", int(e))
+}
+
+type HashSecurityWarningSeverity int
+
+const (
+	HashSecurityWarningSeverity_MODERATE HashSecurityWarningSeverity = 0
+	HashSecurityWarningSeverity_CRITICAL HashSecurityWarningSeverity = 1
+)
+
+func (o HashSecurityWarningSeverity) DeepCopy() HashSecurityWarningSeverity { return o }
+
+var HashSecurityWarningSeverityMap = map[string]HashSecurityWarningSeverity{
+	"MODERATE": 0,
+	"CRITICAL": 1,
+}
+
+var HashSecurityWarningSeverityRevMap = map[HashSecurityWarningSeverity]string{
+	0: "MODERATE",
+	1: "CRITICAL",
+}
+
+func (e HashSecurityWarningSeverity) String() string {
+	if v, ok := HashSecurityWarningSeverityRevMap[e]; ok {
+		return v
+	}
+	return fmt.Sprintf("%v", int(e))
+}
+
+// HashSecurityWarning is the structured form of a weak-hash warning, letting
+// the GUI color-code by Severity and show Remediation as a concrete "fix it"
+// action instead of just a rendered sentence.
+type HashSecurityWarning struct {
+	Kind        HashSecurityWarningKind     `codec:"kind" json:"kind"`
+	Severity    HashSecurityWarningSeverity `codec:"severity" json:"severity"`
+	Description string                      `codec:"description" json:"description"`
+	Remediation string                      `codec:"remediation" json:"remediation"`
+}
+
+func (o HashSecurityWarning) DeepCopy() HashSecurityWarning {
+	return HashSecurityWarning{
+		Kind:        o.Kind.DeepCopy(),
+		Severity:    o.Severity.DeepCopy(),
+		Description: o.Description,
+		Remediation: o.Remediation,
+	}
+}
+
 // PGPSigVerification is returned by pgpDecrypt and pgpVerify with information
 // about the signature verification. If isSigned is false, there was no
 // signature, and the rest of the fields should be ignored.
 type PGPSigVerification struct {
-	IsSigned bool      `codec:"isSigned" json:"isSigned"`
-	Verified bool      `codec:"verified" json:"verified"`
-	Signer   User      `codec:"signer" json:"signer"`
-	SignKey  PublicKey `codec:"signKey" json:"signKey"`
-	Warnings []string  `codec:"warnings" json:"warnings"`
+	IsSigned           bool                  `codec:"isSigned" json:"isSigned"`
+	Verified           bool                  `codec:"verified" json:"verified"`
+	Signer             User                  `codec:"signer" json:"signer"`
+	SignKey            PublicKey             `codec:"signKey" json:"signKey"`
+	Warnings           []string              `codec:"warnings" json:"warnings"`
+	StructuredWarnings []HashSecurityWarning `codec:"structuredWarnings" json:"structuredWarnings"`
 }
 
 func (o PGPSigVerification) DeepCopy() PGPSigVerification {
@@ -111,6 +192,17 @@ func (o PGPSigVerification) DeepCopy() PGPSigVerification {
 			}
 			return ret
 		})(o.Warnings),
+		StructuredWarnings: (func(x []HashSecurityWarning) []HashSecurityWarning {
+			if x == nil {
+				return nil
+			}
+			ret := make([]HashSecurityWarning, len(x))
+			for i, v := range x {
+				vCopy := v.DeepCopy()
+				ret[i] = vCopy
+			}
+			return ret
+		})(o.StructuredWarnings),
 	}
 }
 
@@ -171,6 +263,18 @@ func (o PGPQuery) DeepCopy() PGPQuery {
 	}
 }
 
+type PGPS2KOptions struct {
+	Iterations   int  `codec:"iterations" json:"iterations"`
+	StrongCipher bool `codec:"strongCipher" json:"strongCipher"`
+}
+
+func (o PGPS2KOptions) DeepCopy() PGPS2KOptions {
+	return PGPS2KOptions{
+		Iterations:   o.Iterations,
+		StrongCipher: o.StrongCipher,
+	}
+}
+
 type PGPCreateUids struct {
 	UseDefault bool          `codec:"useDefault" json:"useDefault"`
 	Ids        []PGPIdentity `codec:"ids" json:"ids"`
@@ -195,7 +299,8 @@ func (o PGPCreateUids) DeepCopy() PGPCreateUids {
 
 // Export all pgp keys in lksec, then if doPurge is true, remove the keys from lksec.
 type PGPPurgeRes struct {
-	Filenames []string `codec:"filenames" json:"filenames"`
+	Filenames       []string `codec:"filenames" json:"filenames"`
+	PurgedCacheKIDs []KID    `codec:"purgedCacheKIDs" json:"purgedCacheKIDs"`
 }
 
 func (o PGPPurgeRes) DeepCopy() PGPPurgeRes {
@@ -211,6 +316,17 @@ func (o PGPPurgeRes) DeepCopy() PGPPurgeRes {
 			}
 			return ret
 		})(o.Filenames),
+		PurgedCacheKIDs: (func(x []KID) []KID {
+			if x == nil {
+				return nil
+			}
+			ret := make([]KID, len(x))
+			for i, v := range x {
+				vCopy := v
+				ret[i] = vCopy
+			}
+			return ret
+		})(o.PurgedCacheKIDs),
 	}
 }
 
@@ -247,27 +363,31 @@ type PGPVerifyArg struct {
 }
 
 type PGPImportArg struct {
-	SessionID  int    `codec:"sessionID" json:"sessionID"`
-	Key        []byte `codec:"key" json:"key"`
-	PushSecret bool   `codec:"pushSecret" json:"pushSecret"`
+	SessionID      int    `codec:"sessionID" json:"sessionID"`
+	Key            []byte `codec:"key" json:"key"`
+	PushSecret     bool   `codec:"pushSecret" json:"pushSecret"`
+	ReEncryptLocal bool   `codec:"reEncryptLocal" json:"reEncryptLocal"`
 }
 
 type PGPExportArg struct {
-	SessionID int      `codec:"sessionID" json:"sessionID"`
-	Options   PGPQuery `codec:"options" json:"options"`
-	Encrypted bool     `codec:"encrypted" json:"encrypted"`
+	SessionID int           `codec:"sessionID" json:"sessionID"`
+	Options   PGPQuery      `codec:"options" json:"options"`
+	Encrypted bool          `codec:"encrypted" json:"encrypted"`
+	S2k       PGPS2KOptions `codec:"s2k" json:"s2k"`
 }
 
 type PGPExportByFingerprintArg struct {
-	SessionID int      `codec:"sessionID" json:"sessionID"`
-	Options   PGPQuery `codec:"options" json:"options"`
-	Encrypted bool     `codec:"encrypted" json:"encrypted"`
+	SessionID int           `codec:"sessionID" json:"sessionID"`
+	Options   PGPQuery      `codec:"options" json:"options"`
+	Encrypted bool          `codec:"encrypted" json:"encrypted"`
+	S2k       PGPS2KOptions `codec:"s2k" json:"s2k"`
 }
 
 type PGPExportByKIDArg struct {
-	SessionID int      `codec:"sessionID" json:"sessionID"`
-	Options   PGPQuery `codec:"options" json:"options"`
-	Encrypted bool     `codec:"encrypted" json:"encrypted"`
+	SessionID int           `codec:"sessionID" json:"sessionID"`
+	Options   PGPQuery      `codec:"options" json:"options"`
+	Encrypted bool          `codec:"encrypted" json:"encrypted"`
+	S2k       PGPS2KOptions `codec:"s2k" json:"s2k"`
 }
 
 type PGPKeyGenArg struct {
@@ -302,11 +422,28 @@ type PGPUpdateArg struct {
 	SessionID    int      `codec:"sessionID" json:"sessionID"`
 	All          bool     `codec:"all" json:"all"`
 	Fingerprints []string `codec:"fingerprints" json:"fingerprints"`
+	// Extend, if nonzero, is the number of seconds by which to extend each
+	// updated key's expiration, re-signing it in the process.
+	Extend int `codec:"extend" json:"extend"`
+	// AddUids are PGP-style identities ("Full Name (Comment) <email>") to
+	// add to each updated key.
+	AddUids []string `codec:"addUids" json:"addUids"`
+	// RevokeUids are UIDs to revoke on each updated key.
+	RevokeUids []string `codec:"revokeUids" json:"revokeUids"`
+}
+
+type PGPGenRevokeArg struct {
+	SessionID   int    `codec:"sessionID" json:"sessionID"`
+	KeyQuery    string `codec:"keyQuery" json:"keyQuery"`
+	SubkeyIndex int    `codec:"subkeyIndex" json:"subkeyIndex"`
+	Reason      int    `codec:"reason" json:"reason"`
+	Description string `codec:"description" json:"description"`
 }
 
 type PGPPurgeArg struct {
-	SessionID int  `codec:"sessionID" json:"sessionID"`
-	DoPurge   bool `codec:"doPurge" json:"doPurge"`
+	SessionID         int  `codec:"sessionID" json:"sessionID"`
+	DoPurge           bool `codec:"doPurge" json:"doPurge"`
+	DoPurgeLocalCache bool `codec:"doPurgeLocalCache" json:"doPurgeLocalCache"`
 }
 
 type PGPStorageDismissArg struct {
@@ -323,6 +460,16 @@ type PGPPullPrivateArg struct {
 	Fingerprints []PGPFingerprint `codec:"fingerprints" json:"fingerprints"`
 }
 
+type PGPPullRemoteArg struct {
+	SessionID int    `codec:"sessionID" json:"sessionID"`
+	Query     string `codec:"query" json:"query"`
+}
+
+type PGPPushRemoteArg struct {
+	SessionID int    `codec:"sessionID" json:"sessionID"`
+	Query     string `codec:"query" json:"query"`
+}
+
 type PGPInterface interface {
 	PGPSign(context.Context, PGPSignArg) error
 	// Download PGP keys for tracked users and update the local GPG keyring.
@@ -343,6 +490,11 @@ type PGPInterface interface {
 	PGPSelect(context.Context, PGPSelectArg) error
 	// Push updated key(s) to the server.
 	PGPUpdate(context.Context, PGPUpdateArg) error
+	// Generate a standalone armored revocation certificate for the PGP key
+	// matching keyQuery, without pushing anything to the server. subkeyIndex
+	// selects one of the key's subkeys to revoke instead of the primary key;
+	// pass -1 to revoke the primary key itself.
+	PGPGenRevoke(context.Context, PGPGenRevokeArg) (string, error)
 	PGPPurge(context.Context, PGPPurgeArg) (PGPPurgeRes, error)
 	// Dismiss the PGP unlock via secret_store_file notification.
 	PGPStorageDismiss(context.Context, int) error
@@ -352,6 +504,12 @@ type PGPInterface interface {
 	// pull the given PGP keys from KBFS to the local GnuPG keychain. If it is empty, then
 	// attempt to pull all matching PGP keys in the user's sigchain.
 	PGPPullPrivate(context.Context, PGPPullPrivateArg) error
+	// Fetch a PGP key matching query from the configured HKP keyserver and
+	// import it into the local GnuPG keyring.
+	PGPPullRemote(context.Context, PGPPullRemoteArg) error
+	// Publish the caller's own active PGP key(s) to the configured HKP
+	// keyserver. If query is empty, all active keys are pushed.
+	PGPPushRemote(context.Context, PGPPushRemoteArg) error
 }
 
 func PGPProtocol(i PGPInterface) rpc.Protocol {
@@ -568,6 +726,21 @@ func PGPProtocol(i PGPInterface) rpc.Protocol {
 					return
 				},
 			},
+			"pgpGenRevoke": {
+				MakeArg: func() interface{} {
+					var ret [1]PGPGenRevokeArg
+					return &ret
+				},
+				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
+					typedArgs, ok := args.(*[1]PGPGenRevokeArg)
+					if !ok {
+						err = rpc.NewTypeError((*[1]PGPGenRevokeArg)(nil), args)
+						return
+					}
+					ret, err = i.PGPGenRevoke(ctx, typedArgs[0])
+					return
+				},
+			},
 			"pgpPurge": {
 				MakeArg: func() interface{} {
 					var ret [1]PGPPurgeArg
@@ -628,6 +801,36 @@ func PGPProtocol(i PGPInterface) rpc.Protocol {
 					return
 				},
 			},
+			"pgpPullRemote": {
+				MakeArg: func() interface{} {
+					var ret [1]PGPPullRemoteArg
+					return &ret
+				},
+				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
+					typedArgs, ok := args.(*[1]PGPPullRemoteArg)
+					if !ok {
+						err = rpc.NewTypeError((*[1]PGPPullRemoteArg)(nil), args)
+						return
+					}
+					err = i.PGPPullRemote(ctx, typedArgs[0])
+					return
+				},
+			},
+			"pgpPushRemote": {
+				MakeArg: func() interface{} {
+					var ret [1]PGPPushRemoteArg
+					return &ret
+				},
+				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
+					typedArgs, ok := args.(*[1]PGPPushRemoteArg)
+					if !ok {
+						err = rpc.NewTypeError((*[1]PGPPushRemoteArg)(nil), args)
+						return
+					}
+					err = i.PGPPushRemote(ctx, typedArgs[0])
+					return
+				},
+			},
 		},
 	}
 }
@@ -712,6 +915,15 @@ func (c PGPClient) PGPUpdate(ctx context.Context, __arg PGPUpdateArg) (err error
 	return
 }
 
+// Generate a standalone armored revocation certificate for the PGP key
+// matching keyQuery, without pushing anything to the server. subkeyIndex
+// selects one of the key's subkeys to revoke instead of the primary key;
+// pass -1 to revoke the primary key itself.
+func (c PGPClient) PGPGenRevoke(ctx context.Context, __arg PGPGenRevokeArg) (res string, err error) {
+	err = c.Cli.Call(ctx, "keybase.1.pgp.pgpGenRevoke", []interface{}{__arg}, &res, 0*time.Millisecond)
+	return
+}
+
 func (c PGPClient) PGPPurge(ctx context.Context, __arg PGPPurgeArg) (res PGPPurgeRes, err error) {
 	err = c.Cli.Call(ctx, "keybase.1.pgp.pgpPurge", []interface{}{__arg}, &res, 0*time.Millisecond)
 	return
@@ -737,3 +949,17 @@ func (c PGPClient) PGPPullPrivate(ctx context.Context, __arg PGPPullPrivateArg)
 	err = c.Cli.Call(ctx, "keybase.1.pgp.pgpPullPrivate", []interface{}{__arg}, nil, 0*time.Millisecond)
 	return
 }
+
+// Fetch a PGP key matching query from the configured HKP keyserver and
+// import it into the local GnuPG keyring.
+func (c PGPClient) PGPPullRemote(ctx context.Context, __arg PGPPullRemoteArg) (err error) {
+	err = c.Cli.Call(ctx, "keybase.1.pgp.pgpPullRemote", []interface{}{__arg}, nil, 0*time.Millisecond)
+	return
+}
+
+// Publish the caller's own active PGP key(s) to the configured HKP
+// keyserver. If query is empty, all active keys are pushed.
+func (c PGPClient) PGPPushRemote(ctx context.Context, __arg PGPPushRemoteArg) (err error) {
+	err = c.Cli.Call(ctx, "keybase.1.pgp.pgpPushRemote", []interface{}{__arg}, nil, 0*time.Millisecond)
+	return
+}
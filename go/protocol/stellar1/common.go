@@ -351,12 +351,14 @@ func (o NoteRecipient) DeepCopy() NoteRecipient {
 type NoteContents struct {
 	Note      string        `codec:"note" json:"note"`
 	StellarID TransactionID `codec:"stellarID" json:"stellarID"`
+	Category  string        `codec:"category,omitempty" json:"category,omitempty"`
 }
 
 func (o NoteContents) DeepCopy() NoteContents {
 	return NoteContents{
 		Note:      o.Note,
 		StellarID: o.StellarID.DeepCopy(),
+		Category:  o.Category,
 	}
 }
 
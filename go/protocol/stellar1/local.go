@@ -288,6 +288,7 @@ type PaymentLocal struct {
 	OriginalToAssertion string                 `codec:"originalToAssertion" json:"originalToAssertion"`
 	Note                string                 `codec:"note" json:"note"`
 	NoteErr             string                 `codec:"noteErr" json:"noteErr"`
+	Category            string                 `codec:"category" json:"category"`
 	SourceAmountMax     string                 `codec:"sourceAmountMax" json:"sourceAmountMax"`
 	SourceAmountActual  string                 `codec:"sourceAmountActual" json:"sourceAmountActual"`
 	SourceAsset         Asset                  `codec:"sourceAsset" json:"sourceAsset"`
@@ -343,6 +344,7 @@ func (o PaymentLocal) DeepCopy() PaymentLocal {
 		OriginalToAssertion: o.OriginalToAssertion,
 		Note:                o.Note,
 		NoteErr:             o.NoteErr,
+		Category:            o.Category,
 		SourceAmountMax:     o.SourceAmountMax,
 		SourceAmountActual:  o.SourceAmountActual,
 		SourceAsset:         o.SourceAsset.DeepCopy(),
@@ -582,6 +584,36 @@ func (o SendPaymentResLocal) DeepCopy() SendPaymentResLocal {
 	}
 }
 
+type MinSendableAmountResultLocal struct {
+	Min             string `codec:"min" json:"min"`
+	AccountCreation bool   `codec:"accountCreation" json:"accountCreation"`
+}
+
+func (o MinSendableAmountResultLocal) DeepCopy() MinSendableAmountResultLocal {
+	return MinSendableAmountResultLocal{
+		Min:             o.Min,
+		AccountCreation: o.AccountCreation,
+	}
+}
+
+type PreviewPaymentEffectResultLocal struct {
+	SenderBalanceBefore      string `codec:"senderBalanceBefore" json:"senderBalanceBefore"`
+	SenderBalanceAfter       string `codec:"senderBalanceAfter" json:"senderBalanceAfter"`
+	RecipientAccountCreation bool   `codec:"recipientAccountCreation" json:"recipientAccountCreation"`
+	RecipientBalanceBefore   string `codec:"recipientBalanceBefore" json:"recipientBalanceBefore"`
+	RecipientBalanceAfter    string `codec:"recipientBalanceAfter" json:"recipientBalanceAfter"`
+}
+
+func (o PreviewPaymentEffectResultLocal) DeepCopy() PreviewPaymentEffectResultLocal {
+	return PreviewPaymentEffectResultLocal{
+		SenderBalanceBefore:      o.SenderBalanceBefore,
+		SenderBalanceAfter:       o.SenderBalanceAfter,
+		RecipientAccountCreation: o.RecipientAccountCreation,
+		RecipientBalanceBefore:   o.RecipientBalanceBefore,
+		RecipientBalanceAfter:    o.RecipientBalanceAfter,
+	}
+}
+
 type BuildRequestResLocal struct {
 	ReadyToRequest      bool              `codec:"readyToRequest" json:"readyToRequest"`
 	ToErrMsg            string            `codec:"toErrMsg" json:"toErrMsg"`
@@ -1355,6 +1387,11 @@ type GetGenericPaymentDetailsLocalArg struct {
 	Id        PaymentID `codec:"id" json:"id"`
 }
 
+type GetTransactionByHashLocalArg struct {
+	SessionID int    `codec:"sessionID" json:"sessionID"`
+	TxHash    string `codec:"txHash" json:"txHash"`
+}
+
 type GetDisplayCurrenciesLocalArg struct {
 	SessionID int `codec:"sessionID" json:"sessionID"`
 }
@@ -1413,6 +1450,11 @@ type GetDisplayCurrencyLocalArg struct {
 	AccountID *AccountID `codec:"accountID,omitempty" json:"accountID,omitempty"`
 }
 
+type ResyncSequenceLocalArg struct {
+	SessionID int       `codec:"sessionID" json:"sessionID"`
+	AccountID AccountID `codec:"accountID" json:"accountID"`
+}
+
 type HasAcceptedDisclaimerLocalArg struct {
 	SessionID int `codec:"sessionID" json:"sessionID"`
 }
@@ -1481,6 +1523,20 @@ type SendPaymentLocalArg struct {
 	SecretNote    string               `codec:"secretNote" json:"secretNote"`
 	PublicMemo    string               `codec:"publicMemo" json:"publicMemo"`
 	QuickReturn   bool                 `codec:"quickReturn" json:"quickReturn"`
+	Category      string               `codec:"category,omitempty" json:"category,omitempty"`
+}
+
+type SetPaymentCategoryLocalArg struct {
+	SessionID int                  `codec:"sessionID" json:"sessionID"`
+	AccountID AccountID            `codec:"accountID" json:"accountID"`
+	KbTxID    KeybaseTransactionID `codec:"kbTxID" json:"kbTxID"`
+	Category  string               `codec:"category" json:"category"`
+}
+
+type GetPaymentsByCategoryLocalArg struct {
+	SessionID int       `codec:"sessionID" json:"sessionID"`
+	AccountID AccountID `codec:"accountID" json:"accountID"`
+	Category  string    `codec:"category" json:"category"`
 }
 
 type SendPathLocalArg struct {
@@ -1491,6 +1547,28 @@ type SendPathLocalArg struct {
 	PublicNote string      `codec:"publicNote" json:"publicNote"`
 }
 
+type EstimateConfirmationTimeLocalArg struct {
+	FeeStroops int `codec:"feeStroops" json:"feeStroops"`
+}
+
+type MinSendableAmountLocalArg struct {
+	Recipient string `codec:"recipient" json:"recipient"`
+}
+
+type PreviewPaymentEffectLocalArg struct {
+	AccountID AccountID `codec:"accountID" json:"accountID"`
+	Recipient string    `codec:"recipient" json:"recipient"`
+	Amount    string    `codec:"amount" json:"amount"`
+}
+
+type AbandonPendingPaymentLocalArg struct {
+	KbTxID KeybaseTransactionID `codec:"kbTxID" json:"kbTxID"`
+}
+
+type RetryPaymentLocalArg struct {
+	KbTxID KeybaseTransactionID `codec:"kbTxID" json:"kbTxID"`
+}
+
 type BuildRequestLocalArg struct {
 	SessionID  int                  `codec:"sessionID" json:"sessionID"`
 	To         string               `codec:"to" json:"to"`
@@ -1669,6 +1747,10 @@ type PaymentDetailCLILocalArg struct {
 	TxID string `codec:"txID" json:"txID"`
 }
 
+type DecryptPaymentNoteLocalArg struct {
+	KbTxID KeybaseTransactionID `codec:"kbTxID" json:"kbTxID"`
+}
+
 type WalletInitLocalArg struct {
 }
 
@@ -1774,6 +1856,7 @@ type LocalInterface interface {
 	MarkAsReadLocal(context.Context, MarkAsReadLocalArg) error
 	GetPaymentDetailsLocal(context.Context, GetPaymentDetailsLocalArg) (PaymentDetailsLocal, error)
 	GetGenericPaymentDetailsLocal(context.Context, GetGenericPaymentDetailsLocalArg) (PaymentDetailsLocal, error)
+	GetTransactionByHashLocal(context.Context, GetTransactionByHashLocalArg) (TransactionDetails, error)
 	GetDisplayCurrenciesLocal(context.Context, int) ([]CurrencyLocal, error)
 	ValidateAccountIDLocal(context.Context, ValidateAccountIDLocalArg) error
 	ValidateSecretKeyLocal(context.Context, ValidateSecretKeyLocalArg) error
@@ -1785,6 +1868,7 @@ type LocalInterface interface {
 	CreateWalletAccountLocal(context.Context, CreateWalletAccountLocalArg) (AccountID, error)
 	ChangeDisplayCurrencyLocal(context.Context, ChangeDisplayCurrencyLocalArg) (CurrencyLocal, error)
 	GetDisplayCurrencyLocal(context.Context, GetDisplayCurrencyLocalArg) (CurrencyLocal, error)
+	ResyncSequenceLocal(context.Context, ResyncSequenceLocalArg) error
 	HasAcceptedDisclaimerLocal(context.Context, int) (bool, error)
 	AcceptDisclaimerLocal(context.Context, int) error
 	GetWalletAccountPublicKeyLocal(context.Context, GetWalletAccountPublicKeyLocalArg) (string, error)
@@ -1795,7 +1879,14 @@ type LocalInterface interface {
 	BuildPaymentLocal(context.Context, BuildPaymentLocalArg) (BuildPaymentResLocal, error)
 	ReviewPaymentLocal(context.Context, ReviewPaymentLocalArg) error
 	SendPaymentLocal(context.Context, SendPaymentLocalArg) (SendPaymentResLocal, error)
+	SetPaymentCategoryLocal(context.Context, SetPaymentCategoryLocalArg) error
+	GetPaymentsByCategoryLocal(context.Context, GetPaymentsByCategoryLocalArg) ([]PaymentOrErrorLocal, error)
 	SendPathLocal(context.Context, SendPathLocalArg) (SendPaymentResLocal, error)
+	EstimateConfirmationTimeLocal(context.Context, int) (TimeMs, error)
+	MinSendableAmountLocal(context.Context, string) (MinSendableAmountResultLocal, error)
+	PreviewPaymentEffectLocal(context.Context, PreviewPaymentEffectLocalArg) (PreviewPaymentEffectResultLocal, error)
+	AbandonPendingPaymentLocal(context.Context, KeybaseTransactionID) error
+	RetryPaymentLocal(context.Context, KeybaseTransactionID) (SendPaymentResLocal, error)
 	BuildRequestLocal(context.Context, BuildRequestLocalArg) (BuildRequestResLocal, error)
 	GetRequestDetailsLocal(context.Context, GetRequestDetailsLocalArg) (RequestDetailsLocal, error)
 	CancelRequestLocal(context.Context, CancelRequestLocalArg) error
@@ -1827,6 +1918,7 @@ type LocalInterface interface {
 	ClaimCLILocal(context.Context, ClaimCLILocalArg) (RelayClaimResult, error)
 	RecentPaymentsCLILocal(context.Context, *AccountID) ([]PaymentOrErrorCLILocal, error)
 	PaymentDetailCLILocal(context.Context, string) (PaymentCLILocal, error)
+	DecryptPaymentNoteLocal(context.Context, KeybaseTransactionID) (string, error)
 	WalletInitLocal(context.Context) error
 	WalletDumpLocal(context.Context) (Bundle, error)
 	WalletGetAccountsCLILocal(context.Context) ([]OwnAccountCLILocal, error)
@@ -1973,6 +2065,21 @@ func LocalProtocol(i LocalInterface) rpc.Protocol {
 					return
 				},
 			},
+			"getTransactionByHashLocal": {
+				MakeArg: func() interface{} {
+					var ret [1]GetTransactionByHashLocalArg
+					return &ret
+				},
+				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
+					typedArgs, ok := args.(*[1]GetTransactionByHashLocalArg)
+					if !ok {
+						err = rpc.NewTypeError((*[1]GetTransactionByHashLocalArg)(nil), args)
+						return
+					}
+					ret, err = i.GetTransactionByHashLocal(ctx, typedArgs[0])
+					return
+				},
+			},
 			"getDisplayCurrenciesLocal": {
 				MakeArg: func() interface{} {
 					var ret [1]GetDisplayCurrenciesLocalArg
@@ -2123,6 +2230,21 @@ func LocalProtocol(i LocalInterface) rpc.Protocol {
 					return
 				},
 			},
+			"resyncSequenceLocal": {
+				MakeArg: func() interface{} {
+					var ret [1]ResyncSequenceLocalArg
+					return &ret
+				},
+				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
+					typedArgs, ok := args.(*[1]ResyncSequenceLocalArg)
+					if !ok {
+						err = rpc.NewTypeError((*[1]ResyncSequenceLocalArg)(nil), args)
+						return
+					}
+					err = i.ResyncSequenceLocal(ctx, typedArgs[0])
+					return
+				},
+			},
 			"getDisplayCurrencyLocal": {
 				MakeArg: func() interface{} {
 					var ret [1]GetDisplayCurrencyLocalArg
@@ -2288,6 +2410,36 @@ func LocalProtocol(i LocalInterface) rpc.Protocol {
 					return
 				},
 			},
+			"setPaymentCategoryLocal": {
+				MakeArg: func() interface{} {
+					var ret [1]SetPaymentCategoryLocalArg
+					return &ret
+				},
+				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
+					typedArgs, ok := args.(*[1]SetPaymentCategoryLocalArg)
+					if !ok {
+						err = rpc.NewTypeError((*[1]SetPaymentCategoryLocalArg)(nil), args)
+						return
+					}
+					err = i.SetPaymentCategoryLocal(ctx, typedArgs[0])
+					return
+				},
+			},
+			"getPaymentsByCategoryLocal": {
+				MakeArg: func() interface{} {
+					var ret [1]GetPaymentsByCategoryLocalArg
+					return &ret
+				},
+				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
+					typedArgs, ok := args.(*[1]GetPaymentsByCategoryLocalArg)
+					if !ok {
+						err = rpc.NewTypeError((*[1]GetPaymentsByCategoryLocalArg)(nil), args)
+						return
+					}
+					ret, err = i.GetPaymentsByCategoryLocal(ctx, typedArgs[0])
+					return
+				},
+			},
 			"sendPathLocal": {
 				MakeArg: func() interface{} {
 					var ret [1]SendPathLocalArg
@@ -2303,6 +2455,81 @@ func LocalProtocol(i LocalInterface) rpc.Protocol {
 					return
 				},
 			},
+			"estimateConfirmationTimeLocal": {
+				MakeArg: func() interface{} {
+					var ret [1]EstimateConfirmationTimeLocalArg
+					return &ret
+				},
+				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
+					typedArgs, ok := args.(*[1]EstimateConfirmationTimeLocalArg)
+					if !ok {
+						err = rpc.NewTypeError((*[1]EstimateConfirmationTimeLocalArg)(nil), args)
+						return
+					}
+					ret, err = i.EstimateConfirmationTimeLocal(ctx, typedArgs[0].FeeStroops)
+					return
+				},
+			},
+			"minSendableAmountLocal": {
+				MakeArg: func() interface{} {
+					var ret [1]MinSendableAmountLocalArg
+					return &ret
+				},
+				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
+					typedArgs, ok := args.(*[1]MinSendableAmountLocalArg)
+					if !ok {
+						err = rpc.NewTypeError((*[1]MinSendableAmountLocalArg)(nil), args)
+						return
+					}
+					ret, err = i.MinSendableAmountLocal(ctx, typedArgs[0].Recipient)
+					return
+				},
+			},
+			"previewPaymentEffectLocal": {
+				MakeArg: func() interface{} {
+					var ret [1]PreviewPaymentEffectLocalArg
+					return &ret
+				},
+				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
+					typedArgs, ok := args.(*[1]PreviewPaymentEffectLocalArg)
+					if !ok {
+						err = rpc.NewTypeError((*[1]PreviewPaymentEffectLocalArg)(nil), args)
+						return
+					}
+					ret, err = i.PreviewPaymentEffectLocal(ctx, typedArgs[0])
+					return
+				},
+			},
+			"abandonPendingPaymentLocal": {
+				MakeArg: func() interface{} {
+					var ret [1]AbandonPendingPaymentLocalArg
+					return &ret
+				},
+				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
+					typedArgs, ok := args.(*[1]AbandonPendingPaymentLocalArg)
+					if !ok {
+						err = rpc.NewTypeError((*[1]AbandonPendingPaymentLocalArg)(nil), args)
+						return
+					}
+					err = i.AbandonPendingPaymentLocal(ctx, typedArgs[0].KbTxID)
+					return
+				},
+			},
+			"retryPaymentLocal": {
+				MakeArg: func() interface{} {
+					var ret [1]RetryPaymentLocalArg
+					return &ret
+				},
+				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
+					typedArgs, ok := args.(*[1]RetryPaymentLocalArg)
+					if !ok {
+						err = rpc.NewTypeError((*[1]RetryPaymentLocalArg)(nil), args)
+						return
+					}
+					ret, err = i.RetryPaymentLocal(ctx, typedArgs[0].KbTxID)
+					return
+				},
+			},
 			"buildRequestLocal": {
 				MakeArg: func() interface{} {
 					var ret [1]BuildRequestLocalArg
@@ -2768,6 +2995,21 @@ func LocalProtocol(i LocalInterface) rpc.Protocol {
 					return
 				},
 			},
+			"decryptPaymentNoteLocal": {
+				MakeArg: func() interface{} {
+					var ret [1]DecryptPaymentNoteLocalArg
+					return &ret
+				},
+				Handler: func(ctx context.Context, args interface{}) (ret interface{}, err error) {
+					typedArgs, ok := args.(*[1]DecryptPaymentNoteLocalArg)
+					if !ok {
+						err = rpc.NewTypeError((*[1]DecryptPaymentNoteLocalArg)(nil), args)
+						return
+					}
+					ret, err = i.DecryptPaymentNoteLocal(ctx, typedArgs[0].KbTxID)
+					return
+				},
+			},
 			"walletInitLocal": {
 				MakeArg: func() interface{} {
 					var ret [1]WalletInitLocalArg
@@ -3092,6 +3334,11 @@ func (c LocalClient) GetGenericPaymentDetailsLocal(ctx context.Context, __arg Ge
 	return
 }
 
+func (c LocalClient) GetTransactionByHashLocal(ctx context.Context, __arg GetTransactionByHashLocalArg) (res TransactionDetails, err error) {
+	err = c.Cli.Call(ctx, "stellar.1.local.getTransactionByHashLocal", []interface{}{__arg}, &res, 0*time.Millisecond)
+	return
+}
+
 func (c LocalClient) GetDisplayCurrenciesLocal(ctx context.Context, sessionID int) (res []CurrencyLocal, err error) {
 	__arg := GetDisplayCurrenciesLocalArg{SessionID: sessionID}
 	err = c.Cli.Call(ctx, "stellar.1.local.getDisplayCurrenciesLocal", []interface{}{__arg}, &res, 0*time.Millisecond)
@@ -3148,6 +3395,11 @@ func (c LocalClient) GetDisplayCurrencyLocal(ctx context.Context, __arg GetDispl
 	return
 }
 
+func (c LocalClient) ResyncSequenceLocal(ctx context.Context, __arg ResyncSequenceLocalArg) (err error) {
+	err = c.Cli.Call(ctx, "stellar.1.local.resyncSequenceLocal", []interface{}{__arg}, nil, 0*time.Millisecond)
+	return
+}
+
 func (c LocalClient) HasAcceptedDisclaimerLocal(ctx context.Context, sessionID int) (res bool, err error) {
 	__arg := HasAcceptedDisclaimerLocalArg{SessionID: sessionID}
 	err = c.Cli.Call(ctx, "stellar.1.local.hasAcceptedDisclaimerLocal", []interface{}{__arg}, &res, 0*time.Millisecond)
@@ -3201,11 +3453,50 @@ func (c LocalClient) SendPaymentLocal(ctx context.Context, __arg SendPaymentLoca
 	return
 }
 
+func (c LocalClient) SetPaymentCategoryLocal(ctx context.Context, __arg SetPaymentCategoryLocalArg) (err error) {
+	err = c.Cli.Call(ctx, "stellar.1.local.setPaymentCategoryLocal", []interface{}{__arg}, nil, 0*time.Millisecond)
+	return
+}
+
+func (c LocalClient) GetPaymentsByCategoryLocal(ctx context.Context, __arg GetPaymentsByCategoryLocalArg) (res []PaymentOrErrorLocal, err error) {
+	err = c.Cli.Call(ctx, "stellar.1.local.getPaymentsByCategoryLocal", []interface{}{__arg}, &res, 0*time.Millisecond)
+	return
+}
+
 func (c LocalClient) SendPathLocal(ctx context.Context, __arg SendPathLocalArg) (res SendPaymentResLocal, err error) {
 	err = c.Cli.Call(ctx, "stellar.1.local.sendPathLocal", []interface{}{__arg}, &res, 0*time.Millisecond)
 	return
 }
 
+func (c LocalClient) EstimateConfirmationTimeLocal(ctx context.Context, feeStroops int) (res TimeMs, err error) {
+	__arg := EstimateConfirmationTimeLocalArg{FeeStroops: feeStroops}
+	err = c.Cli.Call(ctx, "stellar.1.local.estimateConfirmationTimeLocal", []interface{}{__arg}, &res, 0*time.Millisecond)
+	return
+}
+
+func (c LocalClient) MinSendableAmountLocal(ctx context.Context, recipient string) (res MinSendableAmountResultLocal, err error) {
+	__arg := MinSendableAmountLocalArg{Recipient: recipient}
+	err = c.Cli.Call(ctx, "stellar.1.local.minSendableAmountLocal", []interface{}{__arg}, &res, 0*time.Millisecond)
+	return
+}
+
+func (c LocalClient) PreviewPaymentEffectLocal(ctx context.Context, __arg PreviewPaymentEffectLocalArg) (res PreviewPaymentEffectResultLocal, err error) {
+	err = c.Cli.Call(ctx, "stellar.1.local.previewPaymentEffectLocal", []interface{}{__arg}, &res, 0*time.Millisecond)
+	return
+}
+
+func (c LocalClient) AbandonPendingPaymentLocal(ctx context.Context, kbTxID KeybaseTransactionID) (err error) {
+	__arg := AbandonPendingPaymentLocalArg{KbTxID: kbTxID}
+	err = c.Cli.Call(ctx, "stellar.1.local.abandonPendingPaymentLocal", []interface{}{__arg}, nil, 0*time.Millisecond)
+	return
+}
+
+func (c LocalClient) RetryPaymentLocal(ctx context.Context, kbTxID KeybaseTransactionID) (res SendPaymentResLocal, err error) {
+	__arg := RetryPaymentLocalArg{KbTxID: kbTxID}
+	err = c.Cli.Call(ctx, "stellar.1.local.retryPaymentLocal", []interface{}{__arg}, &res, 0*time.Millisecond)
+	return
+}
+
 func (c LocalClient) BuildRequestLocal(ctx context.Context, __arg BuildRequestLocalArg) (res BuildRequestResLocal, err error) {
 	err = c.Cli.Call(ctx, "stellar.1.local.buildRequestLocal", []interface{}{__arg}, &res, 0*time.Millisecond)
 	return
@@ -3368,6 +3659,12 @@ func (c LocalClient) PaymentDetailCLILocal(ctx context.Context, txID string) (re
 	return
 }
 
+func (c LocalClient) DecryptPaymentNoteLocal(ctx context.Context, kbTxID KeybaseTransactionID) (res string, err error) {
+	__arg := DecryptPaymentNoteLocalArg{KbTxID: kbTxID}
+	err = c.Cli.Call(ctx, "stellar.1.local.decryptPaymentNoteLocal", []interface{}{__arg}, &res, 0*time.Millisecond)
+	return
+}
+
 func (c LocalClient) WalletInitLocal(ctx context.Context) (err error) {
 	err = c.Cli.Call(ctx, "stellar.1.local.walletInitLocal", []interface{}{WalletInitLocalArg{}}, nil, 0*time.Millisecond)
 	return
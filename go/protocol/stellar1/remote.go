@@ -646,6 +646,35 @@ func (o PaymentDetails) DeepCopy() PaymentDetails {
 	}
 }
 
+type TransactionDetails struct {
+	Hash       string   `codec:"hash" json:"hash"`
+	Ledger     int64    `codec:"ledger" json:"ledger"`
+	FeeCharged string   `codec:"feeCharged" json:"feeCharged"`
+	Memo       string   `codec:"memo" json:"memo"`
+	MemoType   string   `codec:"memoType" json:"memoType"`
+	Operations []string `codec:"operations" json:"operations"`
+}
+
+func (o TransactionDetails) DeepCopy() TransactionDetails {
+	return TransactionDetails{
+		Hash:       o.Hash,
+		Ledger:     o.Ledger,
+		FeeCharged: o.FeeCharged,
+		Memo:       o.Memo,
+		MemoType:   o.MemoType,
+		Operations: (func(x []string) []string {
+			if x == nil {
+				return nil
+			}
+			ret := make([]string, len(x))
+			for i, v := range x {
+				ret[i] = v
+			}
+			return ret
+		})(o.Operations),
+	}
+}
+
 type AccountDetails struct {
 	AccountID            AccountID        `codec:"accountID" json:"accountID"`
 	Seqno                string           `codec:"seqno" json:"seqno"`
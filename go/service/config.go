@@ -557,6 +557,11 @@ func (h ConfigHandler) ToggleRuntimeStats(ctx context.Context) error {
 	return nil
 }
 
+func (h ConfigHandler) SetLogModuleLevel(ctx context.Context, arg keybase1.SetLogModuleLevelArg) error {
+	h.G().ModuleLogLevels.Set(arg.Module, arg.Level)
+	return nil
+}
+
 func (h ConfigHandler) AppendGUILogs(ctx context.Context, content string) error {
 	wr := h.G().GetGUILogWriter()
 	if wr == nil {
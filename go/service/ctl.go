@@ -57,6 +57,11 @@ func (c *CtlHandler) DbClean(ctx context.Context, arg keybase1.DbCleanArg) (err
 	defer mctx.Trace("DbClean", &err)()
 	switch arg.DbType {
 	case keybase1.DbType_MAIN:
+		if migrated, migrateErr := libkb.MigratePGPKeyDbRows(mctx); migrateErr != nil {
+			mctx.Warning("DbClean: failed to migrate PGP key DB rows: %s", migrateErr)
+		} else if migrated > 0 {
+			mctx.Debug("DbClean: migrated %d PGP key DB row(s) to the current version", migrated)
+		}
 		return c.G().LocalDb.Clean(arg.Force)
 	case keybase1.DbType_CHAT:
 		return c.G().LocalChatDb.Clean(arg.Force)
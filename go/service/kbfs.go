@@ -6,6 +6,7 @@ package service
 import (
 	"path/filepath"
 	"strings"
+	"time"
 
 	"github.com/keybase/client/go/encrypteddb"
 
@@ -38,6 +39,19 @@ func NewKBFSHandler(xp rpc.Transporter, g *libkb.GlobalContext, cg *globals.Chat
 	}
 }
 
+// kbfsServerDeadlineOptions bounds how long a KbfsProtocol handler may run
+// before it's considered wedged. FSEditList streams edit-history responses
+// back over an unbounded number of round trips, so it's exempted.
+func kbfsServerDeadlineOptions() libkb.DeadlineOptions {
+	return libkb.DeadlineOptions{
+		Soft: 10 * time.Second,
+		Hard: 30 * time.Second,
+		AllowList: map[string]bool{
+			"FSEditList": true,
+		},
+	}
+}
+
 func (h *KBFSHandler) FSOnlineStatusChangedEvent(_ context.Context, online bool) error {
 	h.G().NotifyRouter.HandleFSOnlineStatusChanged(online)
 	return nil
@@ -97,6 +111,46 @@ func (h *KBFSHandler) FSSubscriptionNotifyPathEvent(_ context.Context, arg keyba
 	return nil
 }
 
+func (h *KBFSHandler) FSKeyGenerationRotated(_ context.Context, arg keybase1.KeyGenerationRotatedArg) error {
+	h.G().NotifyRouter.HandleFSKeyGenerationRotated(arg)
+	return nil
+}
+
+func (h *KBFSHandler) FSDirListProgress(_ context.Context, arg keybase1.DirListProgressArg) error {
+	h.G().NotifyRouter.HandleFSDirListProgress(arg)
+	return nil
+}
+
+func (h *KBFSHandler) FSRemoteFileUpdated(_ context.Context, arg keybase1.RemoteFileUpdatedArg) error {
+	h.G().NotifyRouter.HandleFSRemoteFileUpdated(arg)
+	return nil
+}
+
+func (h *KBFSHandler) FSSnapshotComplete(_ context.Context, arg keybase1.SnapshotCompleteArg) error {
+	h.G().NotifyRouter.HandleFSSnapshotComplete(arg)
+	return nil
+}
+
+func (h *KBFSHandler) FSOfflineEditsAtRisk(_ context.Context, arg keybase1.OfflineEditsAtRiskArg) error {
+	h.G().NotifyRouter.HandleFSOfflineEditsAtRisk(arg)
+	return nil
+}
+
+func (h *KBFSHandler) FSFolderPathChanged(_ context.Context, arg keybase1.FolderPathChangedArg) error {
+	h.G().NotifyRouter.HandleFSFolderPathChanged(arg)
+	return nil
+}
+
+func (h *KBFSHandler) FSStagedChangesCount(_ context.Context, arg keybase1.StagedChangesCountArg) error {
+	h.G().NotifyRouter.HandleFSStagedChangesCount(arg)
+	return nil
+}
+
+func (h *KBFSHandler) FSForegroundFetchComplete(_ context.Context, arg keybase1.ForegroundFetchCompleteArg) error {
+	h.G().NotifyRouter.HandleFSForegroundFetchComplete(arg)
+	return nil
+}
+
 // checkConversationRekey looks for rekey finished notifications and tries to
 // find any conversations associated with the rekeyed TLF.  If it finds any,
 // it will send ChatThreadsStale notifications for them.
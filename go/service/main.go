@@ -141,7 +141,7 @@ func (d *Service) RegisterProtocols(srv *rpc.Server, xp rpc.Transporter, connID
 		keybase1.IdentifyProtocol(NewIdentifyHandler(xp, g, d)),
 		keybase1.IncomingShareProtocol(NewIncomingShareHandler(xp, g)),
 		keybase1.InstallProtocol(NewInstallHandler(xp, g)),
-		keybase1.KbfsProtocol(NewKBFSHandler(xp, g, d.ChatG(), d)),
+		libkb.WrapProtocolWithDeadline(g, keybase1.KbfsProtocol(NewKBFSHandler(xp, g, d.ChatG(), d)), kbfsServerDeadlineOptions()),
 		keybase1.KbfsMountProtocol(NewKBFSMountHandler(xp, g)),
 		keybase1.KvstoreProtocol(NewKVStoreHandler(xp, g)),
 		keybase1.LogProtocol(NewLogHandler(xp, logReg, g)),
@@ -512,6 +512,10 @@ func (d *Service) SetupChatModules(ri func() chat1.RemoteInterface) {
 	g.AddLogoutHook(g.TeamChannelSource, "TeamChannelSource")
 	g.AddDbNukeHook(g.TeamChannelSource, "TeamChannelSource")
 
+	// per-user namespaced PGP key cache; leave rows in place on logout,
+	// namespacing alone is enough to stop cross-account bleed
+	g.AddLogoutHook(libkb.NewPGPKeyDBLogoutHook(false), "PGPKeyDB")
+
 	if g.Standalone {
 		g.AttachmentURLSrv = types.DummyAttachmentHTTPSrv{}
 	} else {
@@ -4,6 +4,8 @@
 package service
 
 import (
+	"time"
+
 	"github.com/keybase/client/go/engine"
 	"github.com/keybase/client/go/libkb"
 	keybase1 "github.com/keybase/client/go/protocol/keybase1"
@@ -186,6 +188,7 @@ func sigVer(g *libkb.GlobalContext, ss *libkb.SignatureStatus, signer *libkb.Use
 		}
 		if len(ss.Warnings) > 0 {
 			res.Warnings = ss.Warnings.Strings()
+			res.StructuredWarnings = ss.Warnings.Export()
 		}
 	}
 	return res
@@ -201,6 +204,7 @@ func (h *PGPHandler) PGPImport(ctx context.Context, arg keybase1.PGPImportArg) e
 	if err != nil {
 		return err
 	}
+	eng.SetReEncryptWithPassphraseStream(arg.ReEncryptLocal)
 	m := libkb.NewMetaContext(ctx, h.G()).WithUIs(uis)
 	err = engine.RunEngine2(m, eng)
 	return err
@@ -298,10 +302,43 @@ func (h *PGPHandler) PGPUpdate(ctx context.Context, arg keybase1.PGPUpdateArg) e
 		SessionID: arg.SessionID,
 	}
 	eng := engine.NewPGPUpdateEngine(h.G(), arg.Fingerprints, arg.All)
+	eng.SetExtend(time.Duration(arg.Extend) * time.Second)
+	if len(arg.AddUids) > 0 {
+		addIdentities := make([]libkb.Identity, 0, len(arg.AddUids))
+		for _, raw := range arg.AddUids {
+			ident, err := libkb.ParseIdentity(raw)
+			if err != nil {
+				return err
+			}
+			addIdentities = append(addIdentities, *ident)
+		}
+		eng.SetIdentityChanges(addIdentities, arg.RevokeUids)
+	} else if len(arg.RevokeUids) > 0 {
+		eng.SetIdentityChanges(nil, arg.RevokeUids)
+	}
 	m := libkb.NewMetaContext(ctx, h.G()).WithUIs(uis)
 	return engine.RunEngine2(m, eng)
 }
 
+func (h *PGPHandler) PGPGenRevoke(ctx context.Context, arg keybase1.PGPGenRevokeArg) (string, error) {
+	uis := libkb.UIs{
+		LogUI:     h.getLogUI(arg.SessionID),
+		SecretUI:  h.getSecretUI(arg.SessionID, h.G()),
+		SessionID: arg.SessionID,
+	}
+	eng := engine.NewPGPRevoke(h.G(), engine.PGPRevokeArg{
+		KeyQuery:    arg.KeyQuery,
+		SubkeyIndex: arg.SubkeyIndex,
+		Reason:      libkb.PGPRevocationReasonCode(arg.Reason),
+		Description: arg.Description,
+	})
+	m := libkb.NewMetaContext(ctx, h.G()).WithUIs(uis)
+	if err := engine.RunEngine2(m, eng); err != nil {
+		return "", err
+	}
+	return eng.Armored()
+}
+
 func (h *PGPHandler) PGPPurge(ctx context.Context, arg keybase1.PGPPurgeArg) (keybase1.PGPPurgeRes, error) {
 	uis := libkb.UIs{
 		LogUI:      h.getLogUI(arg.SessionID),
@@ -316,6 +353,7 @@ func (h *PGPHandler) PGPPurge(ctx context.Context, arg keybase1.PGPPurgeArg) (ke
 		return res, err
 	}
 	res.Filenames = eng.KeyFiles()
+	res.PurgedCacheKIDs = eng.PurgedCacheKIDs()
 	return res, nil
 }
 
@@ -353,3 +391,23 @@ func (h *PGPHandler) PGPPullPrivate(ctx context.Context, arg keybase1.PGPPullPri
 	m := libkb.NewMetaContext(ctx, h.G()).WithUIs(uis)
 	return engine.RunEngine2(m, eng)
 }
+
+func (h *PGPHandler) PGPPullRemote(ctx context.Context, arg keybase1.PGPPullRemoteArg) error {
+	uis := libkb.UIs{
+		LogUI:     h.getLogUI(arg.SessionID),
+		SessionID: arg.SessionID,
+	}
+	eng := engine.NewPGPPullRemoteEngine(h.G(), &engine.PGPPullRemoteEngineArg{Query: arg.Query})
+	m := libkb.NewMetaContext(ctx, h.G()).WithUIs(uis)
+	return engine.RunEngine2(m, eng)
+}
+
+func (h *PGPHandler) PGPPushRemote(ctx context.Context, arg keybase1.PGPPushRemoteArg) error {
+	uis := libkb.UIs{
+		LogUI:     h.getLogUI(arg.SessionID),
+		SessionID: arg.SessionID,
+	}
+	eng := engine.NewPGPPushRemoteEngine(h.G(), &engine.PGPPushRemoteEngineArg{Query: arg.Query})
+	m := libkb.NewMetaContext(ctx, h.G()).WithUIs(uis)
+	return engine.RunEngine2(m, eng)
+}
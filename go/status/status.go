@@ -119,6 +119,7 @@ func GetExtendedStatus(mctx libkb.MetaContext) (res keybase1.ExtendedStatus, err
 		res.DeviceEkNames = dekNames
 	}
 
+	res.LocalDbDegraded, res.LocalDbDegradedReason, _ = g.LocalDbDegraded()
 	res.LocalDbStats = strings.Split(g.LocalDb.Stats(), "\n")
 	res.LocalChatDbStats = strings.Split(g.LocalChatDb.Stats(), "\n")
 	if cacheSizeInfo, err := CacheSizeInfo(g); err == nil {
@@ -0,0 +1,77 @@
+package stellar
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/keybase/client/go/libkb"
+	"github.com/keybase/client/go/protocol/stellar1"
+	"github.com/keybase/client/go/stellar/remote"
+)
+
+// CannotAbandonError is returned by AbandonPendingPaymentLocal when a
+// pending payment might still be included in a ledger, so marking it
+// canceled on the Keybase side could leave that record out of sync with
+// what eventually happens on-chain.
+type CannotAbandonError struct {
+	Reason string
+}
+
+func (e CannotAbandonError) Error() string {
+	return fmt.Sprintf("cannot abandon payment: %s", e.Reason)
+}
+
+// AbandonPendingPaymentLocal marks a payment stuck pending -- typically due
+// to a sequence number or fee problem that's keeping it out of a ledger --
+// as canceled on the Keybase side, so the UI can stop showing it as
+// indefinitely pending. It only does this once the transaction's time bound
+// has passed, since Stellar guarantees a transaction submitted with a
+// MaxTime in the past can never land in a ledger afterward. If the deadline
+// hasn't passed yet, or the payment isn't the kind this can apply to, it
+// returns a CannotAbandonError explaining why.
+func AbandonPendingPaymentLocal(mctx libkb.MetaContext, kbTxID stellar1.KeybaseTransactionID) (err error) {
+	defer mctx.Trace("Stellar.AbandonPendingPaymentLocal", &err)()
+
+	txID := stellar1.TransactionIDFromPaymentID(stellar1.PaymentID(kbTxID))
+	details, err := remote.PaymentDetailsGeneric(mctx.Ctx(), mctx.G(), txID.String())
+	if err != nil {
+		return err
+	}
+
+	typ, err := details.Summary.Typ()
+	if err != nil {
+		return err
+	}
+	if typ != stellar1.PaymentSummaryType_DIRECT {
+		return CannotAbandonError{Reason: "only a directly-sent payment stuck pending can be abandoned this way; relay payments can be reclaimed with CancelPaymentLocal"}
+	}
+
+	status, err := details.Summary.TransactionStatus()
+	if err != nil {
+		return err
+	}
+	if status != stellar1.TransactionStatus_PENDING {
+		return CannotAbandonError{Reason: fmt.Sprintf("payment is no longer pending (status: %s)", status.String())}
+	}
+
+	// The time bound actually used when the transaction was built isn't
+	// retained once it's been submitted, so approximate its deadline as the
+	// payment's creation time plus however long we currently tell clients
+	// to use for timeouts. The server's recommended timeout doesn't change
+	// often enough for that approximation to matter in practice.
+	rec, err := remote.ServerTimeboundsRecommendation(mctx.Ctx(), mctx.G())
+	if err != nil {
+		return err
+	}
+	if rec.TimeNow == 0 || rec.Timeout == 0 {
+		return CannotAbandonError{Reason: "server did not provide enough information to check the payment's deadline"}
+	}
+
+	direct := details.Summary.Direct()
+	deadline := direct.Ctime.Time().Add(time.Duration(rec.Timeout) * time.Second)
+	if rec.TimeNow.Time().Before(deadline) {
+		return CannotAbandonError{Reason: "payment's time bound has not passed yet and it may still be included in a ledger"}
+	}
+
+	return remote.AbandonPayment(mctx.Ctx(), mctx.G(), kbTxID)
+}
@@ -932,7 +932,7 @@ func buildPaymentAmountHelper(mctx libkb.MetaContext, bpc BuildPaymentCache, arg
 		}
 
 		res.displayAmountXLM = xlmAmountFormatted
-		res.displayAmountFiat, err = FormatCurrencyWithCodeSuffix(mctx, convertAmountOutside, *arg.Currency, stellarnet.Round)
+		res.displayAmountFiat, err = FormatDisplayBalance(mctx, DisplayBalance{Amount: convertAmountOutside, Currency: string(*arg.Currency)}, "")
 		if err != nil {
 			log("error converting for displayAmountFiat: %q / %q : %s", convertAmountOutside, arg.Currency, err)
 			res.displayAmountFiat = ""
@@ -985,7 +985,7 @@ func buildPaymentAmountHelper(mctx libkb.MetaContext, bpc BuildPaymentCache, arg
 			log("error converting: %v", err)
 			return res
 		}
-		outsideAmountFormatted, err := FormatCurrencyWithCodeSuffix(mctx, outsideAmount, xrate.Currency, stellarnet.Round)
+		outsideAmountFormatted, err := FormatDisplayBalance(mctx, DisplayBalance{Amount: outsideAmount, Currency: string(xrate.Currency)}, "")
 		if err != nil {
 			log("error formatting converted outside amount: %v", err)
 			return res
@@ -1004,7 +1004,7 @@ func buildPaymentAmountHelper(mctx libkb.MetaContext, bpc BuildPaymentCache, arg
 				log("error formatting xlm %q: %s", arg.Amount, err)
 				res.displayAmountXLM = ""
 			}
-			res.displayAmountFiat, err = FormatCurrencyWithCodeSuffix(mctx, outsideAmount, xrate.Currency, stellarnet.Round)
+			res.displayAmountFiat, err = FormatDisplayBalance(mctx, DisplayBalance{Amount: outsideAmount, Currency: string(xrate.Currency)}, "")
 			if err != nil {
 				log("error formatting fiat %q / %v: %s", outsideAmount, xrate.Currency, err)
 				res.displayAmountFiat = ""
@@ -0,0 +1,84 @@
+package stellar
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/keybase/client/go/libkb"
+	"github.com/keybase/client/go/protocol/keybase1"
+	"github.com/keybase/client/go/protocol/stellar1"
+	"github.com/keybase/client/go/stellar/remote"
+)
+
+// SetPaymentCategoryLocal re-tags an already-sent direct payment with a new
+// client-defined category, re-encrypting and replacing its secret note on
+// the server. Categories only exist for direct payments: relay payments
+// encrypt their note as part of the funding transaction's boxed secret, so
+// there's nothing on the server to update after the fact.
+func SetPaymentCategoryLocal(mctx libkb.MetaContext, remoter remote.Remoter, kbTxID stellar1.KeybaseTransactionID, category string) error {
+	if len(category) > libkb.MaxStellarPaymentCategoryLength {
+		return fmt.Errorf("category of size %d bytes exceeds the maximum length of %d bytes",
+			len(category), libkb.MaxStellarPaymentCategoryLength)
+	}
+
+	details, err := remoter.PaymentDetailsGeneric(mctx.Ctx(), kbTxID.String())
+	if err != nil {
+		return err
+	}
+	typ, err := details.Summary.Typ()
+	if err != nil {
+		return err
+	}
+	if typ != stellar1.PaymentSummaryType_DIRECT {
+		return errors.New("can only set the category of a direct payment")
+	}
+	direct := details.Summary.Direct()
+
+	noteClear := stellar1.NoteContents{StellarID: direct.TxID, Category: category}
+	if len(direct.NoteB64) > 0 {
+		existing, err := NoteDecryptB64(mctx, direct.NoteB64)
+		if err != nil {
+			return fmt.Errorf("error decrypting existing note: %v", err)
+		}
+		noteClear.Note = existing.Note
+	}
+
+	var recipientUv *keybase1.UserVersion
+	if direct.To != nil {
+		recipientUv = direct.To
+	}
+	noteB64, err := NoteEncryptB64(mctx, noteClear, recipientUv)
+	if err != nil {
+		return fmt.Errorf("error encrypting note: %v", err)
+	}
+
+	return remoter.UpdateNote(mctx.Ctx(), direct.FromStellar, direct.TxID, noteB64)
+}
+
+// GetPaymentsByCategoryLocal returns the account's most recent payments
+// whose note was tagged with category. Categories live inside the
+// end-to-end encrypted secret note, so the server can't filter by them;
+// this fetches the normal recent-payments page and filters client-side
+// after decryption.
+func GetPaymentsByCategoryLocal(mctx libkb.MetaContext, remoter remote.Remoter, accountID stellar1.AccountID, category string) ([]stellar1.PaymentOrErrorLocal, error) {
+	page, err := remoter.RecentPayments(mctx.Ctx(), remote.RecentPaymentsArg{
+		AccountID:       accountID,
+		SkipPending:     true,
+		IncludeAdvanced: true,
+	})
+	if err != nil {
+		return nil, err
+	}
+	local, err := RemoteRecentPaymentsToPage(mctx, remoter, accountID, page)
+	if err != nil {
+		return nil, err
+	}
+
+	var ret []stellar1.PaymentOrErrorLocal
+	for _, p := range local.Payments {
+		if p.Payment != nil && p.Payment.Category == category {
+			ret = append(ret, p)
+		}
+	}
+	return ret, nil
+}
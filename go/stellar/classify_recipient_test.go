@@ -0,0 +1,62 @@
+package stellar
+
+import (
+	"testing"
+
+	"github.com/keybase/client/go/libkb"
+	"github.com/keybase/client/go/stellar/stellarcommon"
+	"github.com/stretchr/testify/require"
+)
+
+type classifyTest struct {
+	input stellarcommon.RecipientInput
+	typ   stellarcommon.RecipientInputType
+}
+
+var classifyTests = []classifyTest{
+	// Stellar account IDs.
+	{"GDZ6MXXNH6ZQPI64TTSVPOUMYFYQ3LEFZBIS6CREEIL3CJPXFVXXXYOC", stellarcommon.RecipientInputStellarAccountID},
+	// Lowercase 'g' does not count, matching LookupRecipient's own check;
+	// it falls through to assertion parsing and fails there.
+	{"gdz6mxxnh6zqpi64ttsvpoumyfyq3lefzbis6creeil3cjpxfvxxxyoc", stellarcommon.RecipientInputInvalid},
+	// A trailing space breaks the checksum; still classified as an attempted
+	// account ID, just an invalid one.
+	{"GDZ6MXXNH6ZQPI64TTSVPOUMYFYQ3LEFZBIS6CREEIL3CJPXFVXXXYOC ", stellarcommon.RecipientInputInvalid},
+
+	// Muxed accounts are recognized but always rejected: this client's
+	// Stellar library doesn't support them.
+	{"MA7QYNF7SOWQ3GLR2BGMZEHXAVAVOTNMZF3B4CGTOGJQ38DYJVWE", stellarcommon.RecipientInputMuxedAccount},
+
+	// Federation addresses.
+	{"bob*stellar.org", stellarcommon.RecipientInputFederationAddress},
+	// A keybase.io federation address is really a Keybase assertion.
+	{"bob*keybase.io", stellarcommon.RecipientInputKeybaseUser},
+	{"bob@twitter*keybase.io", stellarcommon.RecipientInputSBSAssertion},
+
+	// Keybase usernames, including ones that happen to look like a domain.
+	{"bob", stellarcommon.RecipientInputKeybaseUser},
+	{"bob.smith", stellarcommon.RecipientInputKeybaseUser},
+
+	// Social (SBS) assertions.
+	{"bob@twitter", stellarcommon.RecipientInputSBSAssertion},
+	{"bob@reddit", stellarcommon.RecipientInputSBSAssertion},
+
+	// Garbage.
+	{"", stellarcommon.RecipientInputInvalid},
+	{"   ", stellarcommon.RecipientInputInvalid},
+	{"bob@", stellarcommon.RecipientInputInvalid},
+}
+
+func TestClassifyRecipient(t *testing.T) {
+	tc := libkb.SetupTest(t, "classifyrecipient", 1)
+	defer tc.Cleanup()
+
+	mctx := libkb.NewMetaContextForTest(tc)
+	for _, test := range classifyTests {
+		res := ClassifyRecipient(mctx, test.input)
+		require.Equal(t, test.typ, res.Typ, "input: %q, reason: %q", test.input, res.Reason)
+		if test.typ == stellarcommon.RecipientInputInvalid || test.typ == stellarcommon.RecipientInputMuxedAccount {
+			require.NotEmpty(t, res.Reason, "input: %q", test.input)
+		}
+	}
+}
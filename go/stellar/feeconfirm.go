@@ -0,0 +1,96 @@
+package stellar
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/keybase/client/go/libkb"
+	"github.com/keybase/stellarnet"
+	"github.com/stellar/go/protocols/horizon"
+)
+
+// feeStatsMaxAge bounds how long we trust a fee-stats snapshot from Horizon
+// as "fresh". Past this, congestion may have shifted enough that a precise
+// estimate isn't worth much.
+const feeStatsMaxAge = 30 * time.Second
+
+const feeStatsCacheKey = "fee_stats"
+
+// feeStatsCache holds the fee-stats snapshot for as long as it's fresh.
+var feeStatsCache = NewTimeCache("FeeStats", 1, feeStatsMaxAge)
+
+// feeStatsLastKnown never expires, so we have something to fall back on
+// (and flag as stale) if Horizon is unreachable or the fresh cache expired.
+var feeStatsLastKnown = NewTimeCache("FeeStatsLastKnown", 1, 0)
+
+func fetchFeeStats(mctx libkb.MetaContext) (stats horizon.FeeStats, stale bool, err error) {
+	if ok := feeStatsCache.Get(mctx, feeStatsCacheKey, &stats); ok {
+		return stats, false, nil
+	}
+
+	client := stellarnet.Client()
+	resp, ferr := client.HTTP.Get(client.URL + "/fee_stats")
+	if ferr == nil {
+		defer resp.Body.Close()
+		var fresh horizon.FeeStats
+		var derr error
+		if derr = json.NewDecoder(resp.Body).Decode(&fresh); derr == nil {
+			feeStatsCache.Put(mctx, feeStatsCacheKey, fresh)
+			feeStatsLastKnown.Put(mctx, feeStatsCacheKey, fresh)
+			return fresh, false, nil
+		}
+		err = derr
+	} else {
+		err = ferr
+	}
+
+	mctx.Debug("EstimateConfirmationTimeLocal: could not refresh fee stats (%s), falling back to last known", err)
+	if ok := feeStatsLastKnown.Get(mctx, feeStatsCacheKey, &stats); ok {
+		return stats, true, nil
+	}
+	return horizon.FeeStats{}, false, err
+}
+
+// EstimateConfirmationTimeLocal estimates how long a transaction submitted
+// at feeStroops is likely to take to get included in a ledger, based on
+// where that fee falls in the current fee-stats percentiles. If the
+// congestion data we have is stale (Horizon was unreachable and we're
+// relying on an old snapshot), it returns a deliberately wide estimate
+// rather than a falsely precise one.
+func EstimateConfirmationTimeLocal(mctx libkb.MetaContext, feeStroops int) (time.Duration, error) {
+	stats, stale, err := fetchFeeStats(mctx)
+	if err != nil {
+		return 0, err
+	}
+
+	if stale {
+		mctx.Debug("EstimateConfirmationTimeLocal: congestion data is stale, returning wide estimate")
+		return 5 * time.Minute, nil
+	}
+
+	// One ledger closes roughly every 5 seconds. Map the fee to the worst
+	// percentile bucket it clears, and estimate needing that many ledgers
+	// to get in (lower percentile clearance == more competition == more
+	// ledgers of waiting).
+	const ledgerCloseTime = 5 * time.Second
+	buckets := []struct {
+		fee     int
+		ledgers int
+	}{
+		{stats.P99AcceptedFee, 1},
+		{stats.P90AcceptedFee, 1},
+		{stats.P50AcceptedFee, 2},
+		{stats.P20AcceptedFee, 4},
+		{stats.P10AcceptedFee, 8},
+	}
+
+	for _, b := range buckets {
+		if b.fee > 0 && feeStroops >= b.fee {
+			return time.Duration(b.ledgers) * ledgerCloseTime, nil
+		}
+	}
+
+	// Below even the 10th-percentile accepted fee: no promises.
+	return 0, fmt.Errorf("fee of %d stroops is below the lowest recently-accepted fee (%d stroops)", feeStroops, stats.P10AcceptedFee)
+}
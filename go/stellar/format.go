@@ -2,12 +2,136 @@ package stellar
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/keybase/client/go/libkb"
 	"github.com/keybase/client/go/protocol/stellar1"
 	"github.com/keybase/stellarnet"
 )
 
+// localeSeparators holds the thousands and decimal separators a locale uses
+// to write numbers.
+type localeSeparators struct {
+	thousands string
+	decimal   string
+}
+
+// localeNumberFormats covers the locales FormatDisplayBalance and
+// FormatDisplayBalanceParts know how to localize numbers for. An unlisted
+// (or empty) locale falls back to the "," / "." separators that
+// stellarnet.FmtAmount already produces, so it's a no-op.
+var localeNumberFormats = map[string]localeSeparators{
+	"en_US": {",", "."},
+	"en_GB": {",", "."},
+	"de_DE": {".", ","},
+	"fr_FR": {" ", ","},
+	"es_ES": {".", ","},
+	"it_IT": {".", ","},
+	"pt_BR": {".", ","},
+	"ru_RU": {" ", ","},
+}
+
+// localizeAmount rewrites a stellarnet.FmtAmount-formatted amount (which
+// always uses "," for thousands and "." for the decimal point) to use the
+// separators of locale. An unrecognized locale is left untouched.
+func localizeAmount(amount, locale string) string {
+	seps, ok := localeNumberFormats[locale]
+	if !ok {
+		return amount
+	}
+	var b strings.Builder
+	for _, r := range amount {
+		switch r {
+		case ',':
+			b.WriteString(seps.thousands)
+		case '.':
+			b.WriteString(seps.decimal)
+		default:
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// FormattedBalance is a DisplayBalance broken into the pieces a caller needs
+// to lay out on its own -- for example to put the currency symbol in a
+// smaller font than the amount. Symbol is empty for a currency code
+// FormatDisplayBalanceParts doesn't recognize, in which case Code should
+// always be shown since there's no symbol to stand in for it.
+type FormattedBalance struct {
+	Symbol string
+	Amount string
+	Code   string
+	// Postfix is true if Symbol belongs after Amount rather than before it.
+	Postfix bool
+}
+
+// FormatDisplayBalanceParts breaks db down into symbol, localized amount,
+// and currency code using the same per-currency symbol/placement metadata
+// as FormatCurrency, for a caller (like a confirmation screen) that wants to
+// style the pieces differently rather than take a single formatted string.
+// An unrecognized currency code comes back with an empty Symbol and
+// Postfix true, so the caller's natural "Amount Symbol" layout degrades to
+// the "AMOUNT CODE" fallback FormatDisplayBalance uses.
+func FormatDisplayBalanceParts(mctx libkb.MetaContext, db DisplayBalance, locale string) (res FormattedBalance, err error) {
+	amount, err := stellarnet.FmtAmount(db.Amount, true /* precisionTwo */, stellarnet.Round)
+	if err != nil {
+		return res, err
+	}
+	res.Amount = localizeAmount(amount, locale)
+	res.Code = db.Currency
+	res.Postfix = true
+
+	conf, err := mctx.G().GetStellar().GetServerDefinitions(mctx.Ctx())
+	if err != nil {
+		return res, err
+	}
+	currency, ok := conf.Currencies[stellar1.OutsideCurrencyCode(db.Currency)]
+	if !ok {
+		// Unknown currency code: leave Symbol empty so the caller falls
+		// back to showing the raw code.
+		return res, nil
+	}
+	res.Symbol = currency.Symbol.Symbol
+	res.Postfix = currency.Symbol.Postfix
+	return res, nil
+}
+
+// FormatDisplayBalance formats db the way a user should see it: symbol and
+// amount placed per the currency's own convention, localized to locale, with
+// the currency code appended unless the symbol already says it (e.g. "CHF").
+// A currency code FormatDisplayBalanceParts doesn't recognize falls back to
+// "AMOUNT CODE" rather than failing, so a DisplayBalance referencing a
+// currency that's been retired since the payment was made can still be
+// shown in history.
+func FormatDisplayBalance(mctx libkb.MetaContext, db DisplayBalance, locale string) (string, error) {
+	parts, err := FormatDisplayBalanceParts(mctx, db, locale)
+	if err != nil {
+		return "", err
+	}
+	return composeFormattedBalance(parts), nil
+}
+
+// composeFormattedBalance lays out an already-localized FormattedBalance into
+// the single string a user sees, split out from FormatDisplayBalance so the
+// layout rules can be golden-tested without a currency lookup.
+func composeFormattedBalance(parts FormattedBalance) string {
+	if parts.Symbol == "" {
+		return fmt.Sprintf("%s %s", parts.Amount, parts.Code)
+	}
+	var formatted string
+	if parts.Postfix {
+		formatted = fmt.Sprintf("%s %s", parts.Amount, parts.Symbol)
+	} else {
+		formatted = fmt.Sprintf("%s%s", parts.Symbol, parts.Amount)
+	}
+	if parts.Postfix && parts.Symbol == parts.Code {
+		// Some currencies have the same symbol as code (CHF).
+		return formatted
+	}
+	return fmt.Sprintf("%s %s", formatted, parts.Code)
+}
+
 func FormatCurrency(mctx libkb.MetaContext, amount string, code stellar1.OutsideCurrencyCode, rounding stellarnet.FmtRoundingBehavior) (string, error) {
 	conf, err := mctx.G().GetStellar().GetServerDefinitions(mctx.Ctx())
 	if err != nil {
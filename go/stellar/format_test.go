@@ -0,0 +1,70 @@
+package stellar
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLocalizeAmount(t *testing.T) {
+	cases := []struct {
+		amount string
+		locale string
+		out    string
+	}{
+		// Unrecognized/empty locale is a no-op: stellarnet already formats
+		// in this "," / "." style.
+		{"1,234.56", "", "1,234.56"},
+		{"1,234.56", "xx_XX", "1,234.56"},
+		{"1,234.56", "en_US", "1,234.56"},
+		{"1,234.56", "en_GB", "1,234.56"},
+		// European-style locales swap the two separators.
+		{"1,234.56", "de_DE", "1.234,56"},
+		{"1,234.56", "es_ES", "1.234,56"},
+		{"1,234.56", "it_IT", "1.234,56"},
+		{"1,234.56", "pt_BR", "1.234,56"},
+		// French and Russian use a space for thousands.
+		{"1,234.56", "fr_FR", "1 234,56"},
+		{"1,234.56", "ru_RU", "1 234,56"},
+		// No thousands separator present: only the decimal point moves.
+		{"56.12", "de_DE", "56,12"},
+		// Negative amounts and amounts with no fractional part.
+		{"-1,234.56", "de_DE", "-1.234,56"},
+		{"1,234", "de_DE", "1.234"},
+	}
+	for _, c := range cases {
+		require.Equal(t, c.out, localizeAmount(c.amount, c.locale),
+			"localizeAmount(%q, %q)", c.amount, c.locale)
+	}
+}
+
+func TestComposeFormattedBalance(t *testing.T) {
+	cases := []struct {
+		parts FormattedBalance
+		out   string
+	}{
+		// Prefix symbol, standard case (USD, CAD, AUD, ...).
+		{FormattedBalance{Symbol: "$", Amount: "123.45", Code: "USD"}, "$123.45 USD"},
+		{FormattedBalance{Symbol: "£", Amount: "123.45", Code: "GBP"}, "£123.45 GBP"},
+		{FormattedBalance{Symbol: "€", Amount: "123.45", Code: "EUR"}, "€123.45 EUR"},
+		{FormattedBalance{Symbol: "¥", Amount: "123", Code: "JPY"}, "¥123 JPY"},
+		{FormattedBalance{Symbol: "₹", Amount: "123.45", Code: "INR"}, "₹123.45 INR"},
+		{FormattedBalance{Symbol: "₩", Amount: "123", Code: "KRW"}, "₩123 KRW"},
+		{FormattedBalance{Symbol: "R$", Amount: "123,45", Code: "BRL"}, "R$123,45 BRL"},
+		{FormattedBalance{Symbol: "-$", Amount: "123.45", Code: "MXN"}, "-$123.45 MXN"},
+		// Postfix symbol, code differs from symbol (kr for several Nordic
+		// currencies).
+		{FormattedBalance{Symbol: "kr", Amount: "123.45", Code: "SEK", Postfix: true}, "123.45 kr SEK"},
+		{FormattedBalance{Symbol: "kr", Amount: "123.45", Code: "NOK", Postfix: true}, "123.45 kr NOK"},
+		{FormattedBalance{Symbol: "zł", Amount: "123.45", Code: "PLN", Postfix: true}, "123.45 zł PLN"},
+		// Postfix symbol that equals the code: no redundant suffix (CHF).
+		{FormattedBalance{Symbol: "CHF", Amount: "123.45", Code: "CHF", Postfix: true}, "123.45 CHF"},
+		// Unknown currency: no symbol, falls back to "AMOUNT CODE".
+		{FormattedBalance{Amount: "123.45", Code: "ZZZ", Postfix: true}, "123.45 ZZZ"},
+		// Negative amount carries straight through.
+		{FormattedBalance{Symbol: "$", Amount: "-123.45", Code: "USD"}, "$-123.45 USD"},
+	}
+	for _, c := range cases {
+		require.Equal(t, c.out, composeFormattedBalance(c.parts), "parts: %+v", c.parts)
+	}
+}
@@ -0,0 +1,37 @@
+package stellar
+
+import (
+	"fmt"
+
+	"github.com/keybase/client/go/libkb"
+	"github.com/keybase/client/go/protocol/stellar1"
+	"github.com/keybase/client/go/stellar/stellarcommon"
+)
+
+// MinSendableAmountLocal returns the minimum XLM amount that can be sent to
+// recipient, and whether sending implies creating the recipient's account.
+// An unfunded recipient needs enough to clear the network's account-creation
+// minimum; a funded one only needs to clear the network's smallest unit, one
+// stroop. This is the same minimum enforced by SendPaymentLocal, so the send
+// UI can disable its button and show the right number before the user even
+// tries to send.
+func MinSendableAmountLocal(mctx libkb.MetaContext, walletState *WalletState, recipient stellarcommon.RecipientInput) (min string, accountCreation bool, err error) {
+	defer mctx.Trace("Stellar.MinSendableAmountLocal", &err)()
+
+	resolved, err := LookupRecipient(mctx, recipient, false)
+	if err != nil {
+		return "", false, err
+	}
+
+	if resolved.AccountID == nil {
+		// No stellar account at all yet, so this would be a relay payment.
+		return minAmountCreateAccountXLM, true, nil
+	}
+
+	funded, err := isAccountFunded(mctx.Ctx(), walletState, stellar1.AccountID(resolved.AccountID.String()))
+	if err != nil {
+		return "", false, fmt.Errorf("error checking destination account balance: %v", err)
+	}
+
+	return minSendableAmountXLM(funded), !funded, nil
+}
@@ -144,6 +144,10 @@ func NoteEncryptB64(mctx libkb.MetaContext, note stellar1.NoteContents, other *k
 		return "", fmt.Errorf("Note of size %d bytes exceeds the maximum length of %d bytes",
 			len(note.Note), libkb.MaxStellarPaymentNoteLength)
 	}
+	if len(note.Category) > libkb.MaxStellarPaymentCategoryLength {
+		return "", fmt.Errorf("Category of size %d bytes exceeds the maximum length of %d bytes",
+			len(note.Category), libkb.MaxStellarPaymentCategoryLength)
+	}
 	obj, err := noteEncrypt(mctx, note, other)
 	if err != nil {
 		return "", err
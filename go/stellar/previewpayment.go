@@ -0,0 +1,106 @@
+package stellar
+
+import (
+	"fmt"
+
+	"github.com/keybase/client/go/libkb"
+	"github.com/keybase/client/go/protocol/stellar1"
+	"github.com/keybase/client/go/stellar/stellarcommon"
+	"github.com/keybase/stellarnet"
+)
+
+// PreviewPaymentEffectArg bundles the send parameters PreviewPaymentEffectLocal
+// needs to work out what a payment would do to each side's balance.
+type PreviewPaymentEffectArg struct {
+	From      stellar1.AccountID
+	Recipient stellarcommon.RecipientInput
+	Amount    string
+}
+
+// PreviewPaymentEffectResultLocal describes the before/after spendable
+// balance on each side of a prospective payment.
+type PreviewPaymentEffectResultLocal struct {
+	SenderBalanceBefore string
+	SenderBalanceAfter  string
+
+	// RecipientAccountCreation is true if sending would fund a brand new
+	// account for the recipient, rather than topping up an existing one.
+	RecipientAccountCreation bool
+	RecipientBalanceBefore   string
+	RecipientBalanceAfter    string
+}
+
+// PreviewPaymentEffectLocal previews what sending arg.Amount from arg.From to
+// arg.Recipient would do to both sides' spendable balances, for a
+// confirmation screen ("your balance: X -> Y, their balance: A -> B"). It is
+// read-only: unlike BuildPaymentLocal/SendPaymentLocal it never builds,
+// reviews, or consumes a Bid.
+func PreviewPaymentEffectLocal(mctx libkb.MetaContext, walletState *WalletState, arg PreviewPaymentEffectArg) (res PreviewPaymentEffectResultLocal, err error) {
+	defer mctx.Trace("Stellar.PreviewPaymentEffectLocal", &err)()
+
+	if c := ClassifyRecipient(mctx, arg.Recipient); c.Typ == stellarcommon.RecipientInputInvalid || c.Typ == stellarcommon.RecipientInputMuxedAccount {
+		return res, fmt.Errorf("recipient: %s", c.Reason)
+	}
+
+	amount, err := stellarnet.ParseStellarAmount(arg.Amount)
+	if err != nil {
+		return res, fmt.Errorf("invalid amount: %v", err)
+	}
+
+	senderDetails, err := walletState.Details(mctx.Ctx(), arg.From)
+	if err != nil {
+		return res, err
+	}
+	senderBefore, err := stellarnet.ParseStellarAmount(senderDetails.Available)
+	if err != nil {
+		return res, err
+	}
+	res.SenderBalanceBefore = senderDetails.Available
+
+	senderAfter := senderBefore - amount - int64(walletState.BaseFee(mctx))
+	if senderAfter < 0 {
+		// The actual send will fail validation elsewhere; here we just
+		// don't show a negative balance.
+		senderAfter = 0
+	}
+	res.SenderBalanceAfter = stellarnet.StringFromStellarAmount(senderAfter)
+
+	resolved, err := LookupRecipient(mctx, arg.Recipient, false)
+	if err != nil {
+		return res, err
+	}
+
+	if resolved.AccountID == nil {
+		// No stellar account at all yet, so this would be a relay payment
+		// that funds a new account once claimed, starting at Amount.
+		res.RecipientAccountCreation = true
+		res.RecipientBalanceBefore = "0"
+		res.RecipientBalanceAfter = arg.Amount
+		return res, nil
+	}
+
+	recipientAccountID := stellar1.AccountID(resolved.AccountID.String())
+	funded, err := isAccountFunded(mctx.Ctx(), walletState, recipientAccountID)
+	if err != nil {
+		return res, fmt.Errorf("error checking destination account balance: %v", err)
+	}
+
+	if !funded {
+		res.RecipientAccountCreation = true
+		res.RecipientBalanceBefore = "0"
+		res.RecipientBalanceAfter = arg.Amount
+		return res, nil
+	}
+
+	recipientDetails, err := walletState.Details(mctx.Ctx(), recipientAccountID)
+	if err != nil {
+		return res, err
+	}
+	recipientBefore, err := stellarnet.ParseStellarAmount(recipientDetails.Available)
+	if err != nil {
+		return res, err
+	}
+	res.RecipientBalanceBefore = recipientDetails.Available
+	res.RecipientBalanceAfter = stellarnet.StringFromStellarAmount(recipientBefore + amount)
+	return res, nil
+}
@@ -31,12 +31,14 @@ type Remoter interface {
 	PendingPayments(ctx context.Context, accountID stellar1.AccountID, limit int) ([]stellar1.PaymentSummary, error)
 	PaymentDetails(ctx context.Context, accountID stellar1.AccountID, txID string) (res stellar1.PaymentDetails, err error)
 	PaymentDetailsGeneric(ctx context.Context, txID string) (res stellar1.PaymentDetails, err error)
+	TransactionDetails(ctx context.Context, txHash string) (res stellar1.TransactionDetails, err error)
 	GetAccountDisplayCurrency(ctx context.Context, accountID stellar1.AccountID) (string, error)
 	ExchangeRate(ctx context.Context, currency string) (stellar1.OutsideExchangeRate, error)
 	SubmitRequest(ctx context.Context, post stellar1.RequestPost) (stellar1.KeybaseRequestID, error)
 	RequestDetails(ctx context.Context, requestID stellar1.KeybaseRequestID) (stellar1.RequestDetails, error)
 	CancelRequest(ctx context.Context, requestID stellar1.KeybaseRequestID) error
 	MarkAsRead(ctx context.Context, accountID stellar1.AccountID, mostRecentID stellar1.TransactionID) error
+	UpdateNote(ctx context.Context, accountID stellar1.AccountID, txID stellar1.TransactionID, noteB64 string) error
 	IsAccountMobileOnly(ctx context.Context, accountID stellar1.AccountID) (bool, error)
 	SetAccountMobileOnly(ctx context.Context, accountID stellar1.AccountID) error
 	MakeAccountAllDevices(ctx context.Context, accountID stellar1.AccountID) error
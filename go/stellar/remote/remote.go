@@ -602,6 +602,37 @@ func PaymentDetailsGeneric(ctx context.Context, g *libkb.GlobalContext, txID str
 	return apiRes.Result, err
 }
 
+type transactionDetailResult struct {
+	libkb.AppStatusEmbed
+	Result stellar1.TransactionDetails `json:"res"`
+}
+
+// TransactionDetails looks up a transaction directly by its Stellar hash,
+// independent of whether Keybase is tracking it as a payment, so callers can
+// debug activity that doesn't appear in the Keybase payment history.
+func TransactionDetails(ctx context.Context, g *libkb.GlobalContext, txHash string) (res stellar1.TransactionDetails, err error) {
+	mctx := libkb.NewMetaContext(ctx, g)
+	apiArg := libkb.APIArg{
+		Endpoint:    "stellar/transactiondetail",
+		SessionType: libkb.APISessionTypeREQUIRED,
+		Args: libkb.HTTPArgs{
+			"tx_hash": libkb.S{Val: txHash},
+		},
+		AppStatusCodes:  []int{libkb.SCOk, libkb.SCNotFound},
+		RetryCount:      3,
+		RetryMultiplier: 1.5,
+		InitialTimeout:  10 * time.Second,
+	}
+	var apiRes transactionDetailResult
+	if err = mctx.G().API.GetDecode(mctx, apiArg, &apiRes); err != nil {
+		return res, err
+	}
+	if apiRes.Status.Code == libkb.SCNotFound {
+		return res, libkb.NotFoundError{Msg: fmt.Sprintf("no transaction found with hash %q", txHash)}
+	}
+	return apiRes.Result, nil
+}
+
 type tickerResult struct {
 	libkb.AppStatusEmbed
 	Price      string        `json:"price"`
@@ -774,6 +805,22 @@ func CancelRequest(ctx context.Context, g *libkb.GlobalContext, requestID stella
 	return g.API.PostDecode(mctx, apiArg, &res)
 }
 
+// AbandonPayment marks a payment as canceled on the Keybase side, for use
+// once the caller has confirmed its transaction can no longer be included
+// in a ledger.
+func AbandonPayment(ctx context.Context, g *libkb.GlobalContext, kbTxID stellar1.KeybaseTransactionID) error {
+	payload := make(libkb.JSONPayload)
+	payload["kb_tx_id"] = kbTxID
+	apiArg := libkb.APIArg{
+		Endpoint:    "stellar/abandonpayment",
+		SessionType: libkb.APISessionTypeREQUIRED,
+		JSONPayload: payload,
+	}
+	var res libkb.AppStatusEmbed
+	mctx := libkb.NewMetaContext(ctx, g)
+	return g.API.PostDecode(mctx, apiArg, &res)
+}
+
 func MarkAsRead(ctx context.Context, g *libkb.GlobalContext, accountID stellar1.AccountID, mostRecentID stellar1.TransactionID) error {
 	payload := make(libkb.JSONPayload)
 	payload["account_id"] = accountID
@@ -788,6 +835,23 @@ func MarkAsRead(ctx context.Context, g *libkb.GlobalContext, accountID stellar1.
 	return g.API.PostDecode(mctx, apiArg, &res)
 }
 
+// UpdateNote replaces the encrypted secret note attached to an already-submitted
+// payment, e.g. so the category it's tagged with can change after the fact.
+func UpdateNote(ctx context.Context, g *libkb.GlobalContext, accountID stellar1.AccountID, txID stellar1.TransactionID, noteB64 string) error {
+	payload := make(libkb.JSONPayload)
+	payload["account_id"] = accountID
+	payload["tx_id"] = txID
+	payload["note_b64"] = noteB64
+	apiArg := libkb.APIArg{
+		Endpoint:    "stellar/updatenote",
+		SessionType: libkb.APISessionTypeREQUIRED,
+		JSONPayload: payload,
+	}
+	var res libkb.AppStatusEmbed
+	mctx := libkb.NewMetaContext(ctx, g)
+	return g.API.PostDecode(mctx, apiArg, &res)
+}
+
 func IsAccountMobileOnly(ctx context.Context, g *libkb.GlobalContext, accountID stellar1.AccountID) (bool, error) {
 	mctx := libkb.NewMetaContext(ctx, g)
 	bundle, err := FetchSecretlessBundle(mctx)
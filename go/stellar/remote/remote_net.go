@@ -78,6 +78,10 @@ func (r *RemoteNet) PaymentDetailsGeneric(ctx context.Context, txID string) (res
 	return PaymentDetailsGeneric(ctx, r.G(), txID)
 }
 
+func (r *RemoteNet) TransactionDetails(ctx context.Context, txHash string) (res stellar1.TransactionDetails, err error) {
+	return TransactionDetails(ctx, r.G(), txHash)
+}
+
 func (r *RemoteNet) GetAccountDisplayCurrency(ctx context.Context, accountID stellar1.AccountID) (string, error) {
 	return GetAccountDisplayCurrency(ctx, r.G(), accountID)
 }
@@ -102,6 +106,10 @@ func (r *RemoteNet) MarkAsRead(ctx context.Context, accountID stellar1.AccountID
 	return MarkAsRead(ctx, r.G(), accountID, mostRecentID)
 }
 
+func (r *RemoteNet) UpdateNote(ctx context.Context, accountID stellar1.AccountID, txID stellar1.TransactionID, noteB64 string) error {
+	return UpdateNote(ctx, r.G(), accountID, txID, noteB64)
+}
+
 func (r *RemoteNet) IsAccountMobileOnly(ctx context.Context, accountID stellar1.AccountID) (bool, error) {
 	return IsAccountMobileOnly(ctx, r.G(), accountID)
 }
@@ -0,0 +1,109 @@
+package stellar
+
+import (
+	"fmt"
+
+	"github.com/keybase/client/go/libkb"
+	"github.com/keybase/client/go/protocol/stellar1"
+	"github.com/keybase/client/go/stellar/remote"
+	"github.com/keybase/client/go/stellar/stellarcommon"
+	"github.com/keybase/stellarnet"
+)
+
+// AlreadySentError is returned by RetryPaymentLocal when the payment it was
+// asked to retry actually made it into a ledger, so resending it would risk
+// paying the recipient twice.
+type AlreadySentError struct {
+	TxStatus stellar1.TransactionStatus
+}
+
+func (e AlreadySentError) Error() string {
+	return fmt.Sprintf("payment already sent (status: %s)", e.TxStatus.String())
+}
+
+// RetryPaymentLocal re-sends a payment that failed to reach a ledger,
+// rebuilding it with a fresh fee and sequence number rather than replaying
+// the original (now-stale) signed transaction. It reuses the original
+// recipient, amount, public memo, secret note, and category; it refuses to
+// touch a payment that's pending or already succeeded, returning
+// AlreadySentError, since retrying one of those could double-pay the
+// recipient.
+func RetryPaymentLocal(mctx libkb.MetaContext, kbTxID stellar1.KeybaseTransactionID) (res stellar1.SendPaymentResLocal, err error) {
+	defer mctx.Trace("Stellar.RetryPaymentLocal", &err)()
+
+	txID := stellar1.TransactionIDFromPaymentID(stellar1.PaymentID(kbTxID))
+	details, err := remote.PaymentDetailsGeneric(mctx.Ctx(), mctx.G(), txID.String())
+	if err != nil {
+		return res, err
+	}
+
+	typ, err := details.Summary.Typ()
+	if err != nil {
+		return res, err
+	}
+	if typ != stellar1.PaymentSummaryType_DIRECT {
+		return res, fmt.Errorf("only a directly-sent payment can be retried this way")
+	}
+
+	p := details.Summary.Direct()
+	switch p.TxStatus {
+	case stellar1.TransactionStatus_SUCCESS, stellar1.TransactionStatus_PENDING:
+		return res, AlreadySentError{TxStatus: p.TxStatus}
+	}
+
+	meUV, err := mctx.G().GetMeUV(mctx.Ctx())
+	if err != nil {
+		return res, err
+	}
+	if !p.From.Eq(meUV) {
+		return res, fmt.Errorf("only the sender of a payment can retry it")
+	}
+
+	var secretNote, category string
+	if len(p.NoteB64) > 0 {
+		decrypted, nerr := NoteDecryptB64(mctx, p.NoteB64)
+		if nerr != nil {
+			mctx.Debug("RetryPaymentLocal: failed to decrypt original note, retrying without it: %s", nerr)
+		} else if decrypted.StellarID == p.TxID {
+			secretNote = decrypted.Note
+			category = decrypted.Category
+		}
+	}
+
+	var displayBalance DisplayBalance
+	if len(p.FromDisplayAmount) > 0 && len(p.FromDisplayCurrency) > 0 {
+		displayBalance = DisplayBalance{
+			Amount:   p.FromDisplayAmount,
+			Currency: p.FromDisplayCurrency,
+		}
+	}
+
+	var pubMemo *stellarnet.Memo
+	if details.Memo != "" || (details.MemoType != "" && details.MemoType != "none") {
+		parsedMemo, merr := stellarnet.NewMemoFromStrings(details.Memo, details.MemoType)
+		if merr != nil {
+			mctx.Debug("RetryPaymentLocal: failed to parse original memo, retrying without it: %s", merr)
+		} else {
+			pubMemo = parsedMemo
+		}
+	}
+
+	sendRes, err := SendPaymentGUI(mctx, getGlobal(mctx.G()).walletState, SendPaymentArg{
+		From:           p.FromStellar,
+		To:             stellarcommon.RecipientInput(p.ToStellar),
+		Amount:         p.Amount,
+		DisplayBalance: displayBalance,
+		SecretNote:     secretNote,
+		PublicMemo:     pubMemo,
+		Category:       category,
+	})
+	if err != nil {
+		return res, err
+	}
+
+	return stellar1.SendPaymentResLocal{
+		KbTxID:     sendRes.KbTxID,
+		Pending:    sendRes.Pending,
+		JumpToChat: sendRes.JumpToChat,
+	}, nil
+}
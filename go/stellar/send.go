@@ -27,8 +27,9 @@ func SendPaymentLocal(mctx libkb.MetaContext, arg stellar1.SendPaymentLocalArg)
 			// Not expected.
 			return res, fmt.Errorf("the payment to send was not found")
 		}
-		mctx.Debug("got state readyToReview:%v readyToSend:%v set:%v",
-			data.ReadyToReview, data.ReadyToSend, data.Frozen != nil)
+		mctx.Debugw("got build state",
+			"bid", arg.Bid, "readyToReview", data.ReadyToReview,
+			"readyToSend", data.ReadyToSend, "frozen", data.Frozen != nil)
 		if arg.BypassReview {
 			// Pretend that a review occurred and succeeded.
 			// Mutating this without the DataLock is not great, but nothing
@@ -57,6 +58,10 @@ func SendPaymentLocal(mctx libkb.MetaContext, arg stellar1.SendPaymentLocalArg)
 		to = toAccountID.String()
 	}
 
+	if c := ClassifyRecipient(mctx, stellarcommon.RecipientInput(to)); c.Typ == stellarcommon.RecipientInputInvalid || c.Typ == stellarcommon.RecipientInputMuxedAccount {
+		return res, fmt.Errorf("recipient: %s", c.Reason)
+	}
+
 	if !arg.Asset.IsNativeXLM() {
 		return res, fmt.Errorf("sending non-XLM assets is not supported")
 	}
@@ -70,6 +75,9 @@ func SendPaymentLocal(mctx libkb.MetaContext, arg stellar1.SendPaymentLocalArg)
 			Amount:   arg.WorthAmount,
 			Currency: arg.WorthCurrency.String(),
 		}
+		if _, err := FormatDisplayBalance(mctx, displayBalance, ""); err != nil {
+			return res, fmt.Errorf("invalid worth amount: %v", err)
+		}
 	}
 
 	var cancel func()
@@ -90,6 +98,7 @@ func SendPaymentLocal(mctx libkb.MetaContext, arg stellar1.SendPaymentLocalArg)
 		PublicMemo:     pubMemo,
 		ForceRelay:     false,
 		QuickReturn:    arg.QuickReturn,
+		Category:       arg.Category,
 	})
 	if err != nil {
 		if isTimeoutError(err) {
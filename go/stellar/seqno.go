@@ -1,6 +1,7 @@
 package stellar
 
 import (
+	"strings"
 	"sync"
 
 	"github.com/keybase/client/go/libkb"
@@ -8,6 +9,17 @@ import (
 	"github.com/stellar/go/xdr"
 )
 
+// isSeqnoError returns true if err looks like it came from Horizon
+// rejecting a transaction for using the wrong sequence number.  The
+// server doesn't give us a structured error for this, just the Horizon
+// result code embedded in the error string.
+func isSeqnoError(err error) bool {
+	if err == nil {
+		return false
+	}
+	return strings.Contains(err.Error(), "tx_bad_seq")
+}
+
 // SeqnoProvider implements build.SequenceProvider.  It can be
 // used for several transactions in a row.
 type SeqnoProvider struct {
@@ -32,6 +44,25 @@ func NewSeqnoProvider(mctx libkb.MetaContext, walletState *WalletState) (seqnoPr
 	}, walletState.SeqnoUnlock
 }
 
+// NewCachedSeqnoProvider creates a SeqnoProvider that skips the initial
+// network fetch of the account's sequence number and trusts whatever
+// WalletState already has cached for it.  It otherwise behaves exactly
+// like the SeqnoProvider returned by NewSeqnoProvider.
+//
+// This is for callers (e.g. bots) that send many payments in quick
+// succession and have already paid the cost of a refresh for one of
+// them -- every subsequent SeqnoProvider would otherwise make its own
+// redundant network round trip before it could hand out a seqno.
+func NewCachedSeqnoProvider(mctx libkb.MetaContext, walletState *WalletState) (seqnoProvider *SeqnoProvider, unlock func()) {
+	walletState.SeqnoLock()
+	sp := &SeqnoProvider{
+		mctx:        mctx,
+		walletState: walletState,
+	}
+	sp.refresh.Do(func() {})
+	return sp, walletState.SeqnoUnlock
+}
+
 // SequenceForAccount implements build.SequenceProvider.
 func (s *SeqnoProvider) SequenceForAccount(aid string) (xdr.SequenceNumber, error) {
 	s.refresh.Do(func() {
@@ -0,0 +1,60 @@
+package stellar
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+
+	"github.com/keybase/client/go/libkb"
+	"github.com/keybase/client/go/protocol/stellar1"
+	"github.com/keybase/client/go/stellar/remote"
+	"github.com/stretchr/testify/require"
+)
+
+// countingSeqnoRemoter embeds a nil remote.Remoter and only implements
+// AccountSeqno, counting how many times it's actually called so tests
+// can assert on whether a network fetch happened.
+type countingSeqnoRemoter struct {
+	remote.Remoter
+	calls int64
+	seqno uint64
+}
+
+func (r *countingSeqnoRemoter) AccountSeqno(ctx context.Context, accountID stellar1.AccountID) (uint64, error) {
+	atomic.AddInt64(&r.calls, 1)
+	return r.seqno, nil
+}
+
+func TestNewCachedSeqnoProviderSkipsRefresh(t *testing.T) {
+	tc := libkb.SetupTest(t, "seqno", 1)
+	defer tc.Cleanup()
+
+	remoter := &countingSeqnoRemoter{seqno: 100}
+	ws := NewWalletState(tc.G, remoter)
+	defer func() { _ = ws.Shutdown(tc.MetaContext()) }()
+
+	accountID := stellar1.AccountID("GCHRSHZ6NDGCDVVNR46LR3CIDGDWFFZHTAMSO2FZHXJ33XMZTVCI4FP3")
+	ws.accountStateBuild(accountID)
+	require.NoError(t, ws.ForceSeqnoRefresh(tc.MetaContext(), accountID))
+	require.EqualValues(t, 1, atomic.LoadInt64(&remoter.calls), "priming the cache should have fetched the seqno once")
+
+	sp, unlock := NewCachedSeqnoProvider(tc.MetaContext(), ws)
+	seqno, err := sp.SequenceForAccount(accountID.String())
+	unlock()
+	require.NoError(t, err)
+	require.EqualValues(t, 100, seqno)
+	require.EqualValues(t, 1, atomic.LoadInt64(&remoter.calls), "cached provider should not have refetched the seqno")
+
+	sp, unlock = NewSeqnoProvider(tc.MetaContext(), ws)
+	_, err = sp.SequenceForAccount(accountID.String())
+	unlock()
+	require.NoError(t, err)
+	require.EqualValues(t, 2, atomic.LoadInt64(&remoter.calls), "uncached provider should still refetch the seqno")
+}
+
+func TestIsSeqnoError(t *testing.T) {
+	require.False(t, isSeqnoError(nil))
+	require.False(t, isSeqnoError(errors.New("some other error")))
+	require.True(t, isSeqnoError(errors.New("horizon: transaction failed: tx_bad_seq")))
+}
@@ -457,6 +457,13 @@ func LookupRecipient(m libkb.MetaContext, to stellarcommon.RecipientInput, isCLI
 		return res, err
 	}
 
+	// Muxed account. ClassifyRecipient recognizes these so the GUI can say
+	// so explicitly, but this client's Stellar library can't resolve one, so
+	// neither can we.
+	if to[0] == 'M' && len(to) > 16 {
+		return res, fmt.Errorf("muxed accounts are not yet supported")
+	}
+
 	maybeUsername, err := lookupRecipientAssertion(m, string(to), isCLI)
 	if err != nil {
 		return res, err
@@ -502,6 +509,93 @@ func LookupRecipient(m libkb.MetaContext, to stellarcommon.RecipientInput, isCLI
 	return res, err
 }
 
+// ClassifyRecipient figures out what kind of thing `to` names -- a Keybase
+// username, a Stellar account ID, a muxed account, a federation address, or
+// a social (SBS) assertion -- without doing any of the resolution work that
+// LookupRecipient does. It uses the same parsing and validation LookupRecipient
+// does, so a caller previewing a payment and the send path itself can never
+// disagree about what kind of recipient they're looking at.
+//
+// Precedence, for inputs that could plausibly match more than one category:
+//  1. A federation address (contains a "*") wins over everything else. A
+//     federation address under the keybase.io domain is a Keybase assertion
+//     in federation-address clothing, so it's reclassified using the part
+//     before the "*".
+//  2. A string starting with an uppercase "G" is treated as a Stellar
+//     account ID, matching LookupRecipient's own check; a lowercase "g" is
+//     not recognized as one and falls through to assertion parsing instead.
+//  3. A string starting with "M" that's long enough to be a muxed account is
+//     recognized as one, but is always Invalid: this client's Stellar library
+//     doesn't support muxed accounts yet.
+//  4. Everything else is parsed as a Keybase assertion. A bare username
+//     becomes KeybaseUser; anything else that parses as a social assertion
+//     (email, phone) becomes SBSAssertion; anything that doesn't parse is
+//     Invalid.
+//
+// No field of `to` is trimmed or otherwise normalized before classifying, so
+// stray whitespace will generally fail every check and come back Invalid.
+func ClassifyRecipient(m libkb.MetaContext, to stellarcommon.RecipientInput) (res stellarcommon.RecipientClassification) {
+	if len(to) == 0 {
+		return stellarcommon.RecipientClassification{
+			Typ:    stellarcommon.RecipientInputInvalid,
+			Reason: "empty recipient parameter",
+		}
+	}
+
+	if strings.Contains(string(to), stellarAddress.Separator) {
+		name, domain, err := stellarAddress.Split(string(to))
+		if err != nil {
+			return stellarcommon.RecipientClassification{
+				Typ:    stellarcommon.RecipientInputInvalid,
+				Reason: err.Error(),
+			}
+		}
+		if domain == "keybase.io" {
+			return classifyAssertion(m, name)
+		}
+		return stellarcommon.RecipientClassification{Typ: stellarcommon.RecipientInputFederationAddress}
+	}
+
+	if to[0] == 'G' && len(to) > 16 {
+		if _, err := libkb.ParseStellarAccountID(string(to)); err != nil {
+			return stellarcommon.RecipientClassification{
+				Typ:    stellarcommon.RecipientInputInvalid,
+				Reason: err.Error(),
+			}
+		}
+		return stellarcommon.RecipientClassification{Typ: stellarcommon.RecipientInputStellarAccountID}
+	}
+
+	if to[0] == 'M' && len(to) > 16 {
+		return stellarcommon.RecipientClassification{
+			Typ:    stellarcommon.RecipientInputMuxedAccount,
+			Reason: "muxed accounts are not yet supported",
+		}
+	}
+
+	return classifyAssertion(m, string(to))
+}
+
+func classifyAssertion(m libkb.MetaContext, s string) stellarcommon.RecipientClassification {
+	expr, err := externals.AssertionParse(m, s)
+	if err != nil {
+		return stellarcommon.RecipientClassification{
+			Typ:    stellarcommon.RecipientInputInvalid,
+			Reason: fmt.Sprintf("invalid recipient %q: %s", s, err),
+		}
+	}
+	if _, ok := expr.(libkb.AssertionKeybase); ok {
+		return stellarcommon.RecipientClassification{Typ: stellarcommon.RecipientInputKeybaseUser}
+	}
+	if _, err := expr.ToSocialAssertion(); err == nil {
+		return stellarcommon.RecipientClassification{Typ: stellarcommon.RecipientInputSBSAssertion}
+	}
+	return stellarcommon.RecipientClassification{
+		Typ:    stellarcommon.RecipientInputInvalid,
+		Reason: fmt.Sprintf("invalid recipient %q", s),
+	}
+}
+
 type DisplayBalance struct {
 	Amount   string
 	Currency string
@@ -553,6 +647,13 @@ type SendPaymentArg struct {
 	PublicMemo     *stellarnet.Memo // Optional.
 	ForceRelay     bool
 	QuickReturn    bool
+	Category       string // Optional. Free-form client-defined tag stored alongside the secret note.
+	// UseCachedSeqno skips the network fetch of the sender's sequence
+	// number and trusts WalletState's local cache instead.  It's meant
+	// for bots sending many payments in a row that have already paid
+	// for a refresh on an earlier send in the batch; ordinary callers
+	// should leave this false.
+	UseCachedSeqno bool
 }
 
 type SendPaymentResult struct {
@@ -644,15 +745,24 @@ func sendPayment(mctx libkb.MetaContext, walletState *WalletState, sendArg SendP
 	}
 
 	// check if recipient account exists
-	funded, err := isAccountFunded(mctx.Ctx(), walletState, stellar1.AccountID(recipient.AccountID.String()))
+	minXLM, accountCreation, err := MinSendableAmountLocal(mctx, walletState, sendArg.To)
 	if err != nil {
-		return res, fmt.Errorf("error checking destination account balance: %v", err)
+		return res, err
 	}
-	if !funded && isAmountLessThanMin(sendArg.Amount, minAmountCreateAccountXLM) {
-		return res, fmt.Errorf("you must send at least %s XLM to fund the account for %s", minAmountCreateAccountXLM, sendArg.To)
+	if isAmountLessThanMin(sendArg.Amount, minXLM) {
+		if accountCreation {
+			return res, fmt.Errorf("you must send at least %s XLM to fund the account for %s", minXLM, sendArg.To)
+		}
+		return res, fmt.Errorf("you must send at least %s XLM", minXLM)
 	}
 
-	sp, unlock := NewSeqnoProvider(mctx, walletState)
+	var sp *SeqnoProvider
+	var unlock func()
+	if sendArg.UseCachedSeqno {
+		sp, unlock = NewCachedSeqnoProvider(mctx, walletState)
+	} else {
+		sp, unlock = NewSeqnoProvider(mctx, walletState)
+	}
 	defer unlock()
 
 	tb, err := getTimeboundsForSending(mctx, walletState)
@@ -672,7 +782,7 @@ func sendPayment(mctx libkb.MetaContext, walletState *WalletState, sendArg SendP
 
 	var txID string
 	var seqno uint64
-	if !funded {
+	if accountCreation {
 		// if no balance, create_account operation
 		sig, err := stellarnet.CreateAccountXLMTransactionWithMemo(senderSeed2, *recipient.AccountID, sendArg.Amount, sendArg.PublicMemo, sp, tb, baseFee)
 		if err != nil {
@@ -696,10 +806,11 @@ func sendPayment(mctx libkb.MetaContext, walletState *WalletState, sendArg SendP
 		mctx.Debug("error calling AddPendingTx: %s", err)
 	}
 
-	if len(sendArg.SecretNote) > 0 {
+	if len(sendArg.SecretNote) > 0 || len(sendArg.Category) > 0 {
 		noteClear := stellar1.NoteContents{
 			Note:      sendArg.SecretNote,
 			StellarID: stellar1.TransactionID(txID),
+			Category:  sendArg.Category,
 		}
 		var recipientUv *keybase1.UserVersion
 		if recipient.User != nil {
@@ -718,6 +829,12 @@ func sendPayment(mctx libkb.MetaContext, walletState *WalletState, sendArg SendP
 		if rerr := walletState.RemovePendingTx(mctx.Ctx(), senderAccountID, stellar1.TransactionID(txID)); rerr != nil {
 			mctx.Debug("error calling RemovePendingTx: %s", rerr)
 		}
+		if isSeqnoError(err) {
+			mctx.Debug("SubmitPayment failed with a sequence error, forcing a seqno refresh for %s", senderAccountID)
+			if rerr := walletState.ForceSeqnoRefresh(mctx, senderAccountID); rerr != nil {
+				mctx.Debug("error calling ForceSeqnoRefresh: %s", rerr)
+			}
+		}
 		return res, err
 	}
 	mctx.Debug("sent payment (direct) kbTxID:%v txID:%v pending:%v", seqno, rres.KeybaseID, rres.StellarID, rres.Pending)
@@ -1517,6 +1634,76 @@ func PaymentDetailCLILocal(ctx context.Context, g *libkb.GlobalContext, remoter
 	return p, nil
 }
 
+// ErrPaymentNoteNotFound is returned by DecryptPaymentNoteLocal when the
+// payment has no secret note attached.
+var ErrPaymentNoteNotFound = errors.New("no note attached to this payment")
+
+// ErrPaymentNoteAccessDenied is returned by DecryptPaymentNoteLocal when the
+// logged-in user is neither the sender nor the recipient of the payment.
+var ErrPaymentNoteAccessDenied = errors.New("not a party to this payment")
+
+// DecryptPaymentNoteLocal fetches the payment kbTxID and decrypts its secret
+// note for the logged-in user. It is for callers that only need the
+// plaintext note, to avoid the localization work PaymentDetailCLILocal does
+// for the rest of the payment.
+func DecryptPaymentNoteLocal(ctx context.Context, g *libkb.GlobalContext, remoter remote.Remoter, kbTxID stellar1.KeybaseTransactionID) (note string, err error) {
+	defer g.CTrace(ctx, "Stellar.DecryptPaymentNoteLocal", &err)()
+	mctx := libkb.NewMetaContext(ctx, g)
+
+	details, err := remoter.PaymentDetailsGeneric(ctx, kbTxID.String())
+	if err != nil {
+		return "", err
+	}
+	typ, err := details.Summary.Typ()
+	if err != nil {
+		return "", fmt.Errorf("malformed payment summary: %v", err)
+	}
+
+	meUV, err := g.GetMeUV(ctx)
+	if err != nil {
+		return "", err
+	}
+	isParty := func(from keybase1.UserVersion, to *keybase1.UserVersion) bool {
+		return from.Eq(meUV) || (to != nil && to.Eq(meUV))
+	}
+
+	switch typ {
+	case stellar1.PaymentSummaryType_DIRECT:
+		p := details.Summary.Direct()
+		if !isParty(p.From, p.To) {
+			return "", ErrPaymentNoteAccessDenied
+		}
+		if len(p.NoteB64) == 0 {
+			return "", ErrPaymentNoteNotFound
+		}
+		decrypted, err := NoteDecryptB64(mctx, p.NoteB64)
+		if err != nil {
+			return "", fmt.Errorf("failed to decrypt payment note: %v", err)
+		}
+		if decrypted.StellarID != p.TxID {
+			return "", errors.New("discarded note for wrong transaction ID")
+		}
+		return decrypted.Note, nil
+	case stellar1.PaymentSummaryType_RELAY:
+		p := details.Summary.Relay()
+		if !isParty(p.From, p.To) {
+			return "", ErrPaymentNoteAccessDenied
+		}
+		if len(p.BoxB64) == 0 {
+			return "", ErrPaymentNoteNotFound
+		}
+		relaySecrets, err := relays.DecryptB64(mctx, p.TeamID, p.BoxB64)
+		if err != nil {
+			return "", fmt.Errorf("failed to decrypt payment note: %v", err)
+		}
+		return relaySecrets.Note, nil
+	default:
+		// Stellar-type payments are plain on-chain payments with no
+		// Keybase-side note to decrypt.
+		return "", ErrPaymentNoteNotFound
+	}
+}
+
 // When isCLI : Identifies the recipient checking track breaks and all.
 // When not isCLI: Does a verified lookup of the assertion.
 // Returns an error if a resolution was found but failed.
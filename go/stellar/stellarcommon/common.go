@@ -12,6 +12,45 @@ import (
 
 type RecipientInput string
 
+// RecipientInputType classifies a RecipientInput by what kind of thing it
+// names, without actually resolving it to a Recipient.
+type RecipientInputType int
+
+const (
+	RecipientInputInvalid RecipientInputType = iota
+	RecipientInputKeybaseUser
+	RecipientInputStellarAccountID
+	RecipientInputMuxedAccount
+	RecipientInputFederationAddress
+	RecipientInputSBSAssertion
+)
+
+func (t RecipientInputType) String() string {
+	switch t {
+	case RecipientInputKeybaseUser:
+		return "KeybaseUser"
+	case RecipientInputStellarAccountID:
+		return "StellarAccountID"
+	case RecipientInputMuxedAccount:
+		return "MuxedAccount"
+	case RecipientInputFederationAddress:
+		return "FederationAddress"
+	case RecipientInputSBSAssertion:
+		return "SBSAssertion"
+	default:
+		return "Invalid"
+	}
+}
+
+// RecipientClassification is the result of classifying a RecipientInput.
+// Reason is populated for RecipientInputInvalid, and may also be populated
+// for a recognized-but-unusable type (for example a muxed account, which
+// this client cannot resolve) to explain why it can't be used as-is.
+type RecipientClassification struct {
+	Typ    RecipientInputType
+	Reason string
+}
+
 type Recipient struct {
 	Input RecipientInput
 	// These 5 fields are nullable.
@@ -7,6 +7,7 @@ import (
 	"errors"
 	"fmt"
 	"sort"
+	"time"
 	"unicode/utf8"
 
 	"github.com/keybase/client/go/chat/msgchecker"
@@ -333,6 +334,36 @@ func (s *Server) GetPaymentsLocal(ctx context.Context, arg stellar1.GetPaymentsL
 	return stellar.RemoteRecentPaymentsToPage(mctx, s.remoter, arg.AccountID, srvPayments)
 }
 
+func (s *Server) SetPaymentCategoryLocal(ctx context.Context, arg stellar1.SetPaymentCategoryLocalArg) (err error) {
+	mctx, fin, err := s.Preamble(ctx, preambleArg{
+		RPCName:       "SetPaymentCategoryLocal",
+		Err:           &err,
+		RequireWallet: true,
+	})
+	defer fin()
+	if err != nil {
+		return err
+	}
+	return stellar.SetPaymentCategoryLocal(mctx, s.remoter, arg.KbTxID, arg.Category)
+}
+
+func (s *Server) GetPaymentsByCategoryLocal(ctx context.Context, arg stellar1.GetPaymentsByCategoryLocalArg) (payments []stellar1.PaymentOrErrorLocal, err error) {
+	mctx, fin, err := s.Preamble(ctx, preambleArg{
+		RPCName:       "GetPaymentsByCategoryLocal",
+		Err:           &err,
+		RequireWallet: true,
+	})
+	defer fin()
+	if err != nil {
+		return nil, err
+	}
+	if arg.AccountID.IsNil() {
+		s.G().Log.CDebugf(ctx, "GetPaymentsByCategoryLocal called with an empty account id")
+		return nil, ErrAccountIDMissing
+	}
+	return stellar.GetPaymentsByCategoryLocal(mctx, s.remoter, arg.AccountID, arg.Category)
+}
+
 func (s *Server) GetPendingPaymentsLocal(ctx context.Context, arg stellar1.GetPendingPaymentsLocalArg) (payments []stellar1.PaymentOrErrorLocal, err error) {
 	mctx, fin, err := s.Preamble(ctx, preambleArg{
 		RPCName:       "GetPendingPaymentsLocal",
@@ -437,6 +468,23 @@ func (s *Server) GetGenericPaymentDetailsLocal(ctx context.Context, arg stellar1
 	}, nil
 }
 
+func (s *Server) GetTransactionByHashLocal(ctx context.Context, arg stellar1.GetTransactionByHashLocalArg) (res stellar1.TransactionDetails, err error) {
+	mctx, fin, err := s.Preamble(ctx, preambleArg{
+		RPCName: "GetTransactionByHashLocal",
+		Err:     &err,
+	})
+	defer fin()
+	if err != nil {
+		return res, err
+	}
+
+	if arg.TxHash == "" {
+		return res, errors.New("TxHash required for GetTransactionByHashLocal")
+	}
+
+	return s.remoter.TransactionDetails(mctx.Ctx(), arg.TxHash)
+}
+
 func (s *Server) CancelPaymentLocal(ctx context.Context, arg stellar1.CancelPaymentLocalArg) (res stellar1.RelayClaimResult, err error) {
 	mctx, fin, err := s.Preamble(ctx, preambleArg{
 		RPCName:       "CancelPaymentLocal",
@@ -609,6 +657,7 @@ func (s *Server) ChangeDisplayCurrencyLocal(ctx context.Context, arg stellar1.Ch
 	if err != nil {
 		return res, err
 	}
+	s.walletState.InformDisplayCurrencyChanged()
 	return stellar.GetCurrencySetting(mctx, arg.AccountID)
 }
 
@@ -781,6 +830,96 @@ func (s *Server) SendPathLocal(ctx context.Context, arg stellar1.SendPathLocalAr
 	}, nil
 }
 
+func (s *Server) EstimateConfirmationTimeLocal(ctx context.Context, feeStroops int) (res stellar1.TimeMs, err error) {
+	mctx, fin, err := s.Preamble(ctx, preambleArg{
+		RPCName: "EstimateConfirmationTimeLocal",
+		Err:     &err,
+	})
+	defer fin()
+	if err != nil {
+		return res, err
+	}
+
+	dur, err := stellar.EstimateConfirmationTimeLocal(mctx, feeStroops)
+	if err != nil {
+		return res, err
+	}
+	return stellar1.TimeMs(dur / time.Millisecond), nil
+}
+
+func (s *Server) MinSendableAmountLocal(ctx context.Context, recipient string) (res stellar1.MinSendableAmountResultLocal, err error) {
+	mctx, fin, err := s.Preamble(ctx, preambleArg{
+		RPCName:       "MinSendableAmountLocal",
+		Err:           &err,
+		RequireWallet: true,
+	})
+	defer fin()
+	if err != nil {
+		return res, err
+	}
+
+	min, accountCreation, err := stellar.MinSendableAmountLocal(mctx, s.walletState, stellarcommon.RecipientInput(recipient))
+	if err != nil {
+		return res, err
+	}
+	return stellar1.MinSendableAmountResultLocal{Min: min, AccountCreation: accountCreation}, nil
+}
+
+func (s *Server) PreviewPaymentEffectLocal(ctx context.Context, arg stellar1.PreviewPaymentEffectLocalArg) (res stellar1.PreviewPaymentEffectResultLocal, err error) {
+	mctx, fin, err := s.Preamble(ctx, preambleArg{
+		RPCName:       "PreviewPaymentEffectLocal",
+		Err:           &err,
+		RequireWallet: true,
+	})
+	defer fin()
+	if err != nil {
+		return res, err
+	}
+
+	effect, err := stellar.PreviewPaymentEffectLocal(mctx, s.walletState, stellar.PreviewPaymentEffectArg{
+		From:      arg.AccountID,
+		Recipient: stellarcommon.RecipientInput(arg.Recipient),
+		Amount:    arg.Amount,
+	})
+	if err != nil {
+		return res, err
+	}
+	return stellar1.PreviewPaymentEffectResultLocal{
+		SenderBalanceBefore:      effect.SenderBalanceBefore,
+		SenderBalanceAfter:       effect.SenderBalanceAfter,
+		RecipientAccountCreation: effect.RecipientAccountCreation,
+		RecipientBalanceBefore:   effect.RecipientBalanceBefore,
+		RecipientBalanceAfter:    effect.RecipientBalanceAfter,
+	}, nil
+}
+
+func (s *Server) AbandonPendingPaymentLocal(ctx context.Context, kbTxID stellar1.KeybaseTransactionID) (err error) {
+	mctx, fin, err := s.Preamble(ctx, preambleArg{
+		RPCName: "AbandonPendingPaymentLocal",
+		Err:     &err,
+	})
+	defer fin()
+	if err != nil {
+		return err
+	}
+
+	return stellar.AbandonPendingPaymentLocal(mctx, kbTxID)
+}
+
+func (s *Server) RetryPaymentLocal(ctx context.Context, kbTxID stellar1.KeybaseTransactionID) (res stellar1.SendPaymentResLocal, err error) {
+	mctx, fin, err := s.Preamble(ctx, preambleArg{
+		RPCName:       "RetryPaymentLocal",
+		Err:           &err,
+		RequireWallet: true,
+	})
+	defer fin()
+	if err != nil {
+		return res, err
+	}
+
+	return stellar.RetryPaymentLocal(mctx, kbTxID)
+}
+
 func (s *Server) CreateWalletAccountLocal(ctx context.Context, arg stellar1.CreateWalletAccountLocalArg) (res stellar1.AccountID, err error) {
 	mctx, fin, err := s.Preamble(ctx, preambleArg{
 		RPCName:       "CreateWalletAccountLocal",
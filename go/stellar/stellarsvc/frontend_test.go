@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"regexp"
 	"sort"
+	"strings"
 	"testing"
 	"time"
 
@@ -17,6 +18,7 @@ import (
 	"github.com/keybase/client/go/protocol/stellar1"
 	"github.com/keybase/client/go/stellar"
 	"github.com/keybase/client/go/stellar/remote"
+	"github.com/keybase/client/go/stellar/stellarcommon"
 	"github.com/keybase/stellarnet"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -1052,6 +1054,73 @@ func TestGetPaymentsLocal(t *testing.T) {
 	require.NotEmpty(t, p.NoteErr) // can't send encrypted note to stellar address
 }
 
+func TestSendPaymentLocalCategory(t *testing.T) {
+	tcs, cleanup := setupNTests(t, 2)
+	defer cleanup()
+
+	acceptDisclaimer(tcs[0])
+	acceptDisclaimer(tcs[1])
+
+	srvSender := tcs[0].Srv
+	rm := tcs[0].Backend
+	accountIDSender := rm.AddAccount(tcs[0].Fu.GetUID())
+	rm.AddAccount(tcs[1].Fu.GetUID())
+
+	err := srvSender.ImportSecretKeyLocal(context.Background(), stellar1.ImportSecretKeyLocalArg{
+		SecretKey:   rm.SecretKey(accountIDSender),
+		MakePrimary: true,
+		Name:        "uu",
+	})
+	require.NoError(t, err)
+
+	sendRes, err := srvSender.SendPaymentLocal(context.Background(), stellar1.SendPaymentLocalArg{
+		BypassBid:     true,
+		From:          accountIDSender,
+		To:            tcs[1].Fu.Username,
+		ToIsAccountID: false,
+		Amount:        "15",
+		Asset:         stellar1.AssetNative(),
+		SecretNote:    "lunch",
+		Category:      "food",
+	})
+	require.NoError(t, err)
+
+	paymentsPage, err := srvSender.GetPaymentsLocal(context.Background(), stellar1.GetPaymentsLocalArg{AccountID: accountIDSender})
+	require.NoError(t, err)
+	require.Len(t, paymentsPage.Payments, 1)
+	require.Equal(t, "food", paymentsPage.Payments[0].Payment.Category)
+	require.Equal(t, "lunch", paymentsPage.Payments[0].Payment.Note)
+
+	byCategory, err := srvSender.GetPaymentsByCategoryLocal(context.Background(), stellar1.GetPaymentsByCategoryLocalArg{
+		AccountID: accountIDSender,
+		Category:  "food",
+	})
+	require.NoError(t, err)
+	require.Len(t, byCategory, 1)
+	require.NotNil(t, byCategory[0].Payment)
+	require.Equal(t, "food", byCategory[0].Payment.Category)
+
+	none, err := srvSender.GetPaymentsByCategoryLocal(context.Background(), stellar1.GetPaymentsByCategoryLocalArg{
+		AccountID: accountIDSender,
+		Category:  "travel",
+	})
+	require.NoError(t, err)
+	require.Len(t, none, 0)
+
+	err = srvSender.SetPaymentCategoryLocal(context.Background(), stellar1.SetPaymentCategoryLocalArg{
+		AccountID: accountIDSender,
+		KbTxID:    sendRes.KbTxID,
+		Category:  "travel",
+	})
+	require.NoError(t, err)
+
+	paymentsPage, err = srvSender.GetPaymentsLocal(context.Background(), stellar1.GetPaymentsLocalArg{AccountID: accountIDSender})
+	require.NoError(t, err)
+	require.Len(t, paymentsPage.Payments, 1)
+	require.Equal(t, "travel", paymentsPage.Payments[0].Payment.Category)
+	require.Equal(t, "lunch", paymentsPage.Payments[0].Payment.Note)
+}
+
 func TestSendToSelf(t *testing.T) {
 	tcs, cleanup := setupNTests(t, 1)
 	defer cleanup()
@@ -3029,6 +3098,12 @@ func TestManageTrustlines(t *testing.T) {
 	require.NoError(t, err)
 	require.Len(t, trustlines, 0)
 
+	// An unfunded, non-Keybase recipient can't receive any non-XLM assets
+	// yet, but that's not an error.
+	acceptedAssets, err := stellar.RecipientAcceptedAssetsLocal(tcs[0].MetaContext(), stellarcommon.RecipientInput(otherAccountID.String()))
+	require.NoError(t, err)
+	require.Len(t, acceptedAssets, 0)
+
 	acceptDisclaimer(tcs[0])
 	accounts := tcs[0].Backend.ImportAccountsForUser(tcs[0])
 
@@ -3084,6 +3159,12 @@ func TestManageTrustlines(t *testing.T) {
 	require.Equal(t, "922337203685.4775807", rtlines.Trustlines[0].Limit) // max limit
 	require.Equal(t, rtlines.RecipientType, stellar1.ParticipantType_KEYBASE)
 
+	// Check if it shows up in RecipientAcceptedAssetsLocal
+	acceptedAssets, err := stellar.RecipientAcceptedAssetsLocal(tcs[0].MetaContext(), stellarcommon.RecipientInput(tcs[0].Fu.Username))
+	require.NoError(t, err)
+	require.Len(t, acceptedAssets, 1)
+	require.Equal(t, keys, acceptedAssets[0])
+
 	// Change limit.
 	err = tcs[0].Srv.ChangeTrustlineLimitLocal(context.Background(), stellar1.ChangeTrustlineLimitLocalArg{
 		AccountID: senderAccountID,
@@ -3232,3 +3313,82 @@ func TestGetStaticConfigLocal(t *testing.T) {
 	require.Equal(t, staticConfig.RequestNoteMaxLength, 240)
 	require.Equal(t, staticConfig.PublicMemoMaxLength, 28)
 }
+
+func TestMinSendableAmountLocal(t *testing.T) {
+	tcs, cleanup := setupNTests(t, 1)
+	defer cleanup()
+
+	acceptDisclaimer(tcs[0])
+
+	rm := tcs[0].Backend
+	fundedRecip := rm.AddAccount(tcs[0].Fu.GetUID())
+	res, err := tcs[0].Srv.MinSendableAmountLocal(context.Background(), fundedRecip.String())
+	require.NoError(t, err)
+	require.False(t, res.AccountCreation)
+
+	unfundedRecip := rm.AddAccountEmpty(t, tcs[0].Fu.GetUID())
+	res, err = tcs[0].Srv.MinSendableAmountLocal(context.Background(), unfundedRecip.String())
+	require.NoError(t, err)
+	require.True(t, res.AccountCreation)
+}
+
+func TestPreviewPaymentEffectLocal(t *testing.T) {
+	tcs, cleanup := setupNTests(t, 1)
+	defer cleanup()
+
+	acceptDisclaimer(tcs[0])
+
+	senderAccountID, err := stellar.GetOwnPrimaryAccountID(tcs[0].MetaContext())
+	require.NoError(t, err)
+
+	rm := tcs[0].Backend
+	recip := rm.AddAccountEmpty(t, tcs[0].Fu.GetUID())
+
+	res, err := tcs[0].Srv.PreviewPaymentEffectLocal(context.Background(), stellar1.PreviewPaymentEffectLocalArg{
+		AccountID: senderAccountID,
+		Recipient: recip.String(),
+		Amount:    "5",
+	})
+	require.NoError(t, err)
+	require.True(t, res.RecipientAccountCreation)
+	require.Equal(t, "0", res.RecipientBalanceBefore)
+	require.Equal(t, "5", res.RecipientBalanceAfter)
+}
+
+func TestAbandonPendingPaymentLocal(t *testing.T) {
+	tcs, cleanup := setupNTests(t, 1)
+	defer cleanup()
+
+	acceptDisclaimer(tcs[0])
+
+	// A transaction ID that was never sent has no payment details, so
+	// abandoning it should surface that error rather than silently
+	// succeeding.
+	bogusTxID := stellar1.KeybaseTransactionID(strings.Repeat("a", 64))
+	err := tcs[0].Srv.AbandonPendingPaymentLocal(context.Background(), bogusTxID)
+	require.Error(t, err)
+}
+
+func TestRetryPaymentLocal(t *testing.T) {
+	tcs, cleanup := setupNTests(t, 1)
+	defer cleanup()
+
+	acceptDisclaimer(tcs[0])
+
+	// A transaction ID that was never sent has no payment details, so
+	// retrying it should surface that error rather than silently sending.
+	bogusTxID := stellar1.KeybaseTransactionID(strings.Repeat("a", 64))
+	_, err := tcs[0].Srv.RetryPaymentLocal(context.Background(), bogusTxID)
+	require.Error(t, err)
+}
+
+func TestEstimateConfirmationTimeLocal(t *testing.T) {
+	tcs, cleanup := setupNTests(t, 1)
+	defer cleanup()
+
+	acceptDisclaimer(tcs[0])
+
+	dur, err := tcs[0].Srv.EstimateConfirmationTimeLocal(context.Background(), 10000)
+	require.NoError(t, err)
+	require.True(t, dur >= 0)
+}
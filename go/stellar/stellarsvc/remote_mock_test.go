@@ -476,6 +476,10 @@ func (r *RemoteClientMock) PaymentDetailsGeneric(ctx context.Context, txID strin
 	return r.Backend.PaymentDetailsGeneric(ctx, r.Tc, txID)
 }
 
+func (r *RemoteClientMock) TransactionDetails(ctx context.Context, txHash string) (res stellar1.TransactionDetails, err error) {
+	return r.Backend.TransactionDetails(ctx, r.Tc, txHash)
+}
+
 func (r *RemoteClientMock) Details(ctx context.Context, accountID stellar1.AccountID) (stellar1.AccountDetails, error) {
 	return r.Backend.Details(ctx, r.Tc, accountID)
 }
@@ -504,6 +508,10 @@ func (r *RemoteClientMock) MarkAsRead(ctx context.Context, acctID stellar1.Accou
 	return r.Backend.MarkAsRead(ctx, r.Tc, acctID, mostRecentID)
 }
 
+func (r *RemoteClientMock) UpdateNote(ctx context.Context, acctID stellar1.AccountID, txID stellar1.TransactionID, noteB64 string) error {
+	return r.Backend.UpdateNote(ctx, r.Tc, acctID, txID, noteB64)
+}
+
 func (r *RemoteClientMock) SetAccountMobileOnly(ctx context.Context, acctID stellar1.AccountID) error {
 	return r.Backend.SetAccountMobileOnly(ctx, r.Tc, acctID)
 }
@@ -1013,6 +1021,22 @@ func (r *BackendMock) PaymentDetailsGeneric(ctx context.Context, tc *TestContext
 	return *p, nil
 }
 
+func (r *BackendMock) TransactionDetails(ctx context.Context, tc *TestContext, txHash string) (res stellar1.TransactionDetails, err error) {
+	defer tc.G.CTrace(ctx, "BackendMock.TransactionDetails", &err)()
+	r.Lock()
+	defer r.Unlock()
+	p := r.txLog.Find(txHash)
+	if p == nil {
+		return res, libkb.NotFoundError{Msg: fmt.Sprintf("no transaction found with hash %q", txHash)}
+	}
+	return stellar1.TransactionDetails{
+		Hash:       txHash,
+		FeeCharged: p.FeeCharged,
+		Memo:       p.Memo,
+		MemoType:   p.MemoType,
+	}, nil
+}
+
 type accountCurrencyResult struct {
 	libkb.AppStatusEmbed
 	CurrencyDisplayPreference string `json:"currency_display_preference"`
@@ -1272,6 +1296,26 @@ func (r *BackendMock) MarkAsRead(ctx context.Context, tc *TestContext, acctID st
 	return nil
 }
 
+func (r *BackendMock) UpdateNote(ctx context.Context, tc *TestContext, acctID stellar1.AccountID, txID stellar1.TransactionID, noteB64 string) error {
+	r.Lock()
+	defer r.Unlock()
+	p := r.txLog.Find(txID.String())
+	if p == nil {
+		return fmt.Errorf("BackendMock: tx not found: '%v'", txID)
+	}
+	typ, err := p.Summary.Typ()
+	if err != nil {
+		return err
+	}
+	if typ != stellar1.PaymentSummaryType_DIRECT {
+		return errors.New("BackendMock: UpdateNote only supports direct payments")
+	}
+	direct := p.Summary.Direct()
+	direct.NoteB64 = noteB64
+	p.Summary = stellar1.NewPaymentSummaryWithDirect(direct)
+	return nil
+}
+
 func (r *BackendMock) IsAccountMobileOnly(ctx context.Context, tc *TestContext, accountID stellar1.AccountID) (bool, error) {
 	return remote.IsAccountMobileOnly(ctx, tc.G, accountID)
 }
@@ -362,6 +362,19 @@ func (s *Server) PaymentDetailCLILocal(ctx context.Context, txID string) (res st
 	return stellar.PaymentDetailCLILocal(mctx.Ctx(), s.G(), s.remoter, txID)
 }
 
+func (s *Server) DecryptPaymentNoteLocal(ctx context.Context, kbTxID stellar1.KeybaseTransactionID) (res string, err error) {
+	mctx, fin, err := s.Preamble(ctx, preambleArg{
+		RPCName: "DecryptPaymentNoteLocal",
+		Err:     &err,
+	})
+	defer fin()
+	if err != nil {
+		return res, err
+	}
+
+	return stellar.DecryptPaymentNoteLocal(mctx.Ctx(), s.G(), s.remoter, kbTxID)
+}
+
 // WalletInitLocal creates and posts an initial stellar bundle for a user.
 // Only succeeds if they do not already have one.
 // Safe to call even if the user has a bundle already.
@@ -393,6 +406,27 @@ func (s *Server) SetDisplayCurrency(ctx context.Context, arg stellar1.SetDisplay
 	return remote.SetAccountDefaultCurrency(mctx.Ctx(), s.G(), arg.AccountID, arg.Currency)
 }
 
+// ResyncSequenceLocal forces a refetch of arg.AccountID's sequence
+// number from the network. Ordinary sends never need this -- WalletState
+// fetches the sequence once and increments it locally for each
+// subsequent send in order to avoid a network round trip per payment --
+// but if something outside of Keybase advances the on-chain sequence,
+// the cached value is stale until this is called or a send fails with a
+// sequence error and triggers the same refresh.
+func (s *Server) ResyncSequenceLocal(ctx context.Context, arg stellar1.ResyncSequenceLocalArg) (err error) {
+	mctx, fin, err := s.Preamble(ctx, preambleArg{
+		RPCName:       "ResyncSequenceLocal",
+		Err:           &err,
+		RequireWallet: true,
+	})
+	defer fin()
+	if err != nil {
+		return err
+	}
+
+	return s.walletState.ForceSeqnoRefresh(mctx, arg.AccountID)
+}
+
 type exchangeRateMap map[string]stellar1.OutsideExchangeRate
 
 // getLocalCurrencyAndExchangeRate gets display currency setting
@@ -245,7 +245,7 @@ func transformPaymentDirect(mctx libkb.MetaContext, acctID stellar1.AccountID, p
 	loc.StatusDescription = strings.ToLower(loc.StatusSimplified.String())
 	loc.StatusDetail = p.TxErrMsg
 
-	loc.Note, loc.NoteErr = decryptNote(mctx, p.TxID, p.NoteB64)
+	loc.Note, loc.Category, loc.NoteErr = decryptNote(mctx, p.TxID, p.NoteB64)
 
 	loc.SourceAmountMax = p.SourceAmountMax
 	loc.SourceAmountActual = p.SourceAmountActual
@@ -390,7 +390,7 @@ func formatWorth(mctx libkb.MetaContext, amount, currency *string) (worth, worth
 		return "", "", nil
 	}
 
-	worth, err = FormatCurrencyWithCodeSuffix(mctx, *amount, stellar1.OutsideCurrencyCode(*currency), stellarnet.Round)
+	worth, err = FormatDisplayBalance(mctx, DisplayBalance{Amount: *amount, Currency: *currency}, "")
 	if err != nil {
 		return "", "", err
 	}
@@ -428,21 +428,21 @@ func fillOwnAccounts(mctx libkb.MetaContext, loc *stellar1.PaymentLocal, oc OwnA
 	}
 }
 
-func decryptNote(mctx libkb.MetaContext, txid stellar1.TransactionID, note string) (plaintext, errOutput string) {
+func decryptNote(mctx libkb.MetaContext, txid stellar1.TransactionID, note string) (plaintext, category, errOutput string) {
 	if len(note) == 0 {
-		return "", ""
+		return "", "", ""
 	}
 
 	decrypted, err := NoteDecryptB64(mctx, note)
 	if err != nil {
-		return "", fmt.Sprintf("failed to decrypt payment note: %s", err)
+		return "", "", fmt.Sprintf("failed to decrypt payment note: %s", err)
 	}
 
 	if decrypted.StellarID != txid {
-		return "", "discarded note for wrong transaction ID"
+		return "", "", "discarded note for wrong transaction ID"
 	}
 
-	return utils.EscapeForDecorate(mctx.Ctx(), decrypted.Note), ""
+	return utils.EscapeForDecorate(mctx.Ctx(), decrypted.Note), decrypted.Category, ""
 }
 
 func newPaymentCommonLocal(mctx libkb.MetaContext, txID stellar1.TransactionID, ctime stellar1.TimeMs, amount string, asset stellar1.Asset) (*stellar1.PaymentLocal, error) {
@@ -5,6 +5,8 @@ import (
 
 	"github.com/keybase/client/go/libkb"
 	"github.com/keybase/client/go/protocol/stellar1"
+	"github.com/keybase/client/go/stellar/remote"
+	"github.com/keybase/client/go/stellar/stellarcommon"
 	"github.com/keybase/stellarnet"
 )
 
@@ -160,6 +162,47 @@ func DeleteTrustlineLocal(mctx libkb.MetaContext, arg stellar1.DeleteTrustlineLo
 	return nil
 }
 
+// RecipientAcceptedAssetsLocal resolves `to` and returns the non-XLM assets
+// they can already receive: one entry per trustline with a nonzero limit.
+// A recipient with no stellar account yet (unfunded, or a non-Keybase
+// recipient we can't resolve to an address) isn't an error -- they just
+// can't receive any non-XLM assets yet, so the result is an empty list.
+// SendPaymentLocal's asset-send support can reuse this to validate that the
+// asset the sender picked is one the recipient actually trusts.
+func RecipientAcceptedAssetsLocal(mctx libkb.MetaContext, to stellarcommon.RecipientInput) (assets []stellar1.Asset, err error) {
+	defer mctx.Trace(fmt.Sprintf("Stellar.RecipientAcceptedAssetsLocal(%s)", to), &err)()
+
+	recipient, err := LookupRecipient(mctx, to, false)
+	if err != nil {
+		return nil, err
+	}
+	if recipient.AccountID == nil {
+		// No stellar account to check trustlines against.
+		return nil, nil
+	}
+
+	balances, err := remote.Balances(mctx.Ctx(), mctx.G(), stellar1.AccountID(*recipient.AccountID))
+	if err != nil {
+		return nil, err
+	}
+
+	for _, balance := range balances {
+		if balance.Asset.IsNativeXLM() || !balance.IsAuthorized {
+			continue
+		}
+		limit, err := stellarnet.ParseStellarAmount(balance.Limit)
+		if err != nil {
+			mctx.Debug("RecipientAcceptedAssetsLocal: error parsing trustline limit %q: %s", balance.Limit, err)
+			continue
+		}
+		if limit <= 0 {
+			continue
+		}
+		assets = append(assets, balance.Asset)
+	}
+	return assets, nil
+}
+
 func ChangeTrustlineLimitLocal(mctx libkb.MetaContext, arg stellar1.ChangeTrustlineLimitLocalArg) (err error) {
 	defer mctx.Trace(
 		fmt.Sprintf("Stellar.ChangeTrustlineLimitLocal(%s,%s,%s)", arg.AccountID, arg.Trustline.AssetCode, arg.Limit),
@@ -89,6 +89,18 @@ func isAmountLessThanMin(amount, min string) bool {
 	return false
 }
 
+// minSendableAmountXLM returns the minimum amount that can be sent to an
+// account, given whether that account already exists on the network.
+// Unfunded accounts need enough to meet the network's account-creation
+// minimum balance; funded accounts just need to clear the network's
+// smallest unit, one stroop.
+func minSendableAmountXLM(funded bool) string {
+	if !funded {
+		return minAmountCreateAccountXLM
+	}
+	return stellarnet.StringFromStellarAmount(1)
+}
+
 func EmptyAmountStack(mctx libkb.MetaContext) {
 	mctx.Debug("unexpected empty amount\n%v", string(debug.Stack()))
 }
@@ -23,6 +23,10 @@ var ErrAccountNotFound = errors.New("account not found for user")
 // is clogged up.
 var ErrRefreshQueueFull = errors.New("refresh queue is full")
 
+// DefaultRateCacheTTL is how long a cached exchange rate is
+// considered fresh before ExchangeRate will hit the network again.
+const DefaultRateCacheTTL = 1 * time.Minute
+
 // WalletState holds all the current data for all the accounts
 // for the user.  It is also a remote.Remoter and should be used
 // in place of it so network calls can be avoided.
@@ -37,6 +41,7 @@ type WalletState struct {
 	backgroundStop chan struct{}
 	backgroundDone chan struct{}
 	rateGroup      *singleflight.Group
+	rateCacheTTL   time.Duration
 	shutdownOnce   sync.Once
 	sync.Mutex
 	seqnoMu       sync.Mutex
@@ -60,6 +65,7 @@ func NewWalletState(g *libkb.GlobalContext, r remote.Remoter) *WalletState {
 		backgroundDone: make(chan struct{}),
 		backgroundStop: make(chan struct{}),
 		rateGroup:      &singleflight.Group{},
+		rateCacheTTL:   DefaultRateCacheTTL,
 		options:        NewOptions(),
 	}
 
@@ -572,13 +578,33 @@ type rateEntry struct {
 	ctime    time.Time
 }
 
+// SetRateCacheTTL sets how long a cached exchange rate is considered
+// fresh. It is primarily useful for tests that want to exercise
+// expiry without waiting on DefaultRateCacheTTL.
+func (w *WalletState) SetRateCacheTTL(d time.Duration) {
+	w.Lock()
+	defer w.Unlock()
+	w.rateCacheTTL = d
+}
+
+// InformDisplayCurrencyChanged busts the exchange rate cache after
+// the user changes their display currency so that the next lookup
+// for any currency goes to the network instead of serving a rate
+// that was fetched under different assumptions.
+func (w *WalletState) InformDisplayCurrencyChanged() {
+	w.Lock()
+	defer w.Unlock()
+	w.rates = make(map[string]rateEntry)
+}
+
 // ExchangeRate is an overrider of remoter's ExchangeRate.
 func (w *WalletState) ExchangeRate(ctx context.Context, currency string) (stellar1.OutsideExchangeRate, error) {
 	w.Lock()
 	existing, ok := w.rates[currency]
+	ttl := w.rateCacheTTL
 	w.Unlock()
 	age := time.Since(existing.ctime)
-	if ok && age < 1*time.Minute {
+	if ok && age < ttl {
 		w.G().Log.CDebugf(ctx, "using cached value for ExchangeRate(%s) => %+v (%s old)", currency, existing.rate, age)
 		return existing.rate, nil
 	}
@@ -0,0 +1,95 @@
+package stellar
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/keybase/client/go/libkb"
+	"github.com/keybase/client/go/protocol/stellar1"
+	"github.com/keybase/client/go/stellar/remote"
+	"github.com/stretchr/testify/require"
+)
+
+// countingRateRemoter embeds a nil remote.Remoter and only implements
+// ExchangeRate, counting how many times it's actually called so tests
+// can assert on cache hits vs. network fetches.
+type countingRateRemoter struct {
+	remote.Remoter
+	calls int64
+}
+
+func (r *countingRateRemoter) ExchangeRate(ctx context.Context, currency string) (stellar1.OutsideExchangeRate, error) {
+	atomic.AddInt64(&r.calls, 1)
+	return stellar1.OutsideExchangeRate{
+		Currency: stellar1.OutsideCurrencyCode(currency),
+		Rate:     "1.23",
+	}, nil
+}
+
+func TestWalletStateExchangeRateCache(t *testing.T) {
+	tc := libkb.SetupTest(t, "wallet_state", 1)
+	defer tc.Cleanup()
+
+	remoter := &countingRateRemoter{}
+	ws := NewWalletState(tc.G, remoter)
+	defer func() { _ = ws.Shutdown(tc.MetaContext()) }()
+
+	_, err := ws.ExchangeRate(context.Background(), "USD")
+	require.NoError(t, err)
+	require.EqualValues(t, 1, atomic.LoadInt64(&remoter.calls))
+
+	_, err = ws.ExchangeRate(context.Background(), "USD")
+	require.NoError(t, err)
+	require.EqualValues(t, 1, atomic.LoadInt64(&remoter.calls), "cached value should be served without hitting the remoter")
+
+	ws.SetRateCacheTTL(1 * time.Millisecond)
+	time.Sleep(5 * time.Millisecond)
+
+	_, err = ws.ExchangeRate(context.Background(), "USD")
+	require.NoError(t, err)
+	require.EqualValues(t, 2, atomic.LoadInt64(&remoter.calls), "expired cache entry should trigger a refetch")
+}
+
+func TestWalletStateExchangeRateSingleFlight(t *testing.T) {
+	tc := libkb.SetupTest(t, "wallet_state", 1)
+	defer tc.Cleanup()
+
+	remoter := &countingRateRemoter{}
+	ws := NewWalletState(tc.G, remoter)
+	defer func() { _ = ws.Shutdown(tc.MetaContext()) }()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := ws.ExchangeRate(context.Background(), "EUR")
+			require.NoError(t, err)
+		}()
+	}
+	wg.Wait()
+
+	require.EqualValues(t, 1, atomic.LoadInt64(&remoter.calls), "concurrent lookups for the same currency should share one fetch")
+}
+
+func TestWalletStateExchangeRateInvalidateOnCurrencyChange(t *testing.T) {
+	tc := libkb.SetupTest(t, "wallet_state", 1)
+	defer tc.Cleanup()
+
+	remoter := &countingRateRemoter{}
+	ws := NewWalletState(tc.G, remoter)
+	defer func() { _ = ws.Shutdown(tc.MetaContext()) }()
+
+	_, err := ws.ExchangeRate(context.Background(), "USD")
+	require.NoError(t, err)
+	require.EqualValues(t, 1, atomic.LoadInt64(&remoter.calls))
+
+	ws.InformDisplayCurrencyChanged()
+
+	_, err = ws.ExchangeRate(context.Background(), "USD")
+	require.NoError(t, err)
+	require.EqualValues(t, 2, atomic.LoadInt64(&remoter.calls), "invalidation should force a refetch even within the TTL window")
+}
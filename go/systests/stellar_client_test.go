@@ -339,6 +339,56 @@ func (s *stellarRetryClient) CancelPaymentLocal(ctx context.Context, arg stellar
 	return res, err
 }
 
+func (s *stellarRetryClient) EstimateConfirmationTimeLocal(ctx context.Context, feeStroops int) (res stellar1.TimeMs, err error) {
+	for i := 0; i < retryCount; i++ {
+		res, err = s.cli.EstimateConfirmationTimeLocal(ctx, feeStroops)
+		if err == nil {
+			break
+		}
+	}
+	return res, err
+}
+
+func (s *stellarRetryClient) MinSendableAmountLocal(ctx context.Context, recipient string) (res stellar1.MinSendableAmountResultLocal, err error) {
+	for i := 0; i < retryCount; i++ {
+		res, err = s.cli.MinSendableAmountLocal(ctx, recipient)
+		if err == nil {
+			break
+		}
+	}
+	return res, err
+}
+
+func (s *stellarRetryClient) PreviewPaymentEffectLocal(ctx context.Context, arg stellar1.PreviewPaymentEffectLocalArg) (res stellar1.PreviewPaymentEffectResultLocal, err error) {
+	for i := 0; i < retryCount; i++ {
+		res, err = s.cli.PreviewPaymentEffectLocal(ctx, arg)
+		if err == nil {
+			break
+		}
+	}
+	return res, err
+}
+
+func (s *stellarRetryClient) AbandonPendingPaymentLocal(ctx context.Context, kbTxID stellar1.KeybaseTransactionID) (err error) {
+	for i := 0; i < retryCount; i++ {
+		err = s.cli.AbandonPendingPaymentLocal(ctx, kbTxID)
+		if err == nil {
+			break
+		}
+	}
+	return err
+}
+
+func (s *stellarRetryClient) RetryPaymentLocal(ctx context.Context, kbTxID stellar1.KeybaseTransactionID) (res stellar1.SendPaymentResLocal, err error) {
+	for i := 0; i < retryCount; i++ {
+		res, err = s.cli.RetryPaymentLocal(ctx, kbTxID)
+		if err == nil {
+			break
+		}
+	}
+	return res, err
+}
+
 func (s *stellarRetryClient) BalancesLocal(ctx context.Context, arg stellar1.AccountID) (res []stellar1.Balance, err error) {
 	for i := 0; i < retryCount; i++ {
 		res, err = s.cli.BalancesLocal(ctx, arg)
@@ -419,6 +469,16 @@ func (s *stellarRetryClient) WalletGetAccountsCLILocal(ctx context.Context) (res
 	return res, err
 }
 
+func (s *stellarRetryClient) DecryptPaymentNoteLocal(ctx context.Context, kbTxID stellar1.KeybaseTransactionID) (res string, err error) {
+	for i := 0; i < retryCount; i++ {
+		res, err = s.cli.DecryptPaymentNoteLocal(ctx, kbTxID)
+		if err == nil {
+			break
+		}
+	}
+	return res, err
+}
+
 func (s *stellarRetryClient) OwnAccountLocal(ctx context.Context, arg stellar1.AccountID) (res bool, err error) {
 	for i := 0; i < retryCount; i++ {
 		res, err = s.cli.OwnAccountLocal(ctx, arg)
@@ -37,6 +37,7 @@ type Entity struct {
 	UnverifiedRevocations []*packet.Signature
 	Subkeys               []Subkey
 	BadSubkeys            []BadSubkey
+	UserAttributes        []*UserAttribute
 }
 
 // An Identity represents an identity claimed by an Entity and zero or more
@@ -49,6 +50,15 @@ type Identity struct {
 	Revocation    *packet.Signature
 }
 
+// A UserAttribute is like an Identity, but for a user attribute packet
+// instead of a user ID packet. In practice these are almost always used to
+// embed a JPEG photo ID. See RFC 4880, section 5.12.
+type UserAttribute struct {
+	Contents      *packet.UserAttribute
+	SelfSignature *packet.Signature
+	Signatures    []*packet.Signature
+}
+
 // A Subkey is an additional public key in an Entity. Subkeys can be used for
 // encryption.
 type Subkey struct {
@@ -433,6 +443,7 @@ func ReadEntity(packets *packet.Reader) (*Entity, error) {
 	}
 
 	var current *Identity
+	var currentAttribute *UserAttribute
 	var revocations []*packet.Signature
 
 	designatedRevokers := make(map[uint64]bool)
@@ -453,6 +464,14 @@ EachPacket:
 			current = new(Identity)
 			current.Name = pkt.Id
 			current.UserId = pkt
+			currentAttribute = nil
+		case *packet.UserAttribute:
+			// Same idea as the UserId case above, except there's no name to
+			// key it by, so it's only added to e.UserAttributes (a slice)
+			// once it has a valid self-signature.
+			currentAttribute = new(UserAttribute)
+			currentAttribute.Contents = pkt
+			current = nil
 		case *packet.Signature:
 			if pkt.SigType == packet.SigTypeKeyRevocation {
 				// These revocations won't revoke UIDs (see
@@ -494,7 +513,19 @@ EachPacket:
 			// So further tighten our overwrite rules, and only allow the later
 			// signature to overwrite the earlier signature if so doing won't
 			// trash the key flags.
-			if current != nil &&
+			if currentAttribute != nil &&
+				(pkt.SigType == packet.SigTypePositiveCert || pkt.SigType == packet.SigTypeGenericCert) &&
+				pkt.IssuerKeyId != nil &&
+				*pkt.IssuerKeyId == e.PrimaryKey.KeyId {
+
+				hadSelfSignature := currentAttribute.SelfSignature != nil
+				if err = e.PrimaryKey.VerifyUserAttributeSignature(currentAttribute.Contents, e.PrimaryKey, pkt); err == nil {
+					currentAttribute.SelfSignature = pkt
+					if !hadSelfSignature {
+						e.UserAttributes = append(e.UserAttributes, currentAttribute)
+					}
+				}
+			} else if current != nil &&
 				(current.SelfSignature == nil ||
 					(!pkt.CreationTime.Before(current.SelfSignature.CreationTime) &&
 						(pkt.FlagsValid || !current.SelfSignature.FlagsValid))) &&
@@ -538,7 +569,7 @@ EachPacket:
 						designatedRevokers[keyID] = true
 					}
 				}
-			} else if current == nil {
+			} else if current == nil && currentAttribute == nil {
 				// NOTE(maxtaco)
 				//
 				// See https://github.com/keybase/client/issues/2666
@@ -550,6 +581,8 @@ EachPacket:
 				//
 				// Used to be:
 				//    return nil, errors.StructuralError("signature packet found before user id packet")
+			} else if currentAttribute != nil {
+				currentAttribute.Signatures = append(currentAttribute.Signatures, pkt)
 			} else {
 				current.Signatures = append(current.Signatures, pkt)
 			}
@@ -777,6 +810,22 @@ func (e *Entity) SerializePrivate(w io.Writer, config *packet.Config) (err error
 			return
 		}
 	}
+	for _, attr := range e.UserAttributes {
+		err = attr.Contents.Serialize(w)
+		if err != nil {
+			return
+		}
+		if e.PrivateKey.PrivateKey != nil {
+			err = attr.SelfSignature.SignUserAttribute(attr.Contents, e.PrimaryKey, e.PrivateKey, config)
+			if err != nil {
+				return
+			}
+		}
+		err = attr.SelfSignature.Serialize(w)
+		if err != nil {
+			return
+		}
+	}
 	for _, subkey := range e.Subkeys {
 		err = subkey.PrivateKey.Serialize(w)
 		if err != nil {
@@ -836,6 +885,22 @@ func (e *Entity) Serialize(w io.Writer) error {
 			}
 		}
 	}
+	for _, attr := range e.UserAttributes {
+		err = attr.Contents.Serialize(w)
+		if err != nil {
+			return err
+		}
+		err = attr.SelfSignature.Serialize(w)
+		if err != nil {
+			return err
+		}
+		for _, sig := range attr.Signatures {
+			err = sig.Serialize(w)
+			if err != nil {
+				return err
+			}
+		}
+	}
 	for _, subkey := range e.Subkeys {
 		err = subkey.PublicKey.Serialize(w)
 		if err != nil {
@@ -100,6 +100,22 @@ func NewECDHPrivateKey(currentTime time.Time, priv *ecdh.PrivateKey) *PrivateKey
 	return pk
 }
 
+// NewEdDSAPrivateKey returns a PrivateKey that wraps the given Ed25519
+// private key (see ed25519.GenerateKey). This library only supports
+// EdDSA over Curve25519, so unlike NewECDSAPrivateKey/NewECDHPrivateKey
+// there's no curve to select.
+func NewEdDSAPrivateKey(currentTime time.Time, priv ed25519.PrivateKey) *PrivateKey {
+	pk := new(PrivateKey)
+	pk.PublicKey = *NewEdDSAPublicKey(currentTime, priv.Public().(ed25519.PublicKey))
+
+	eddsaPriv := new(EdDSAPrivateKey)
+	eddsaPriv.PublicKey = pk.PublicKey
+	eddsaPriv.seed = FromBytes(priv.Seed())
+	pk.PrivateKey = eddsaPriv
+
+	return pk
+}
+
 func (pk *PrivateKey) parse(r io.Reader) (err error) {
 	err = (&pk.PublicKey).parse(r)
 	if err != nil {
@@ -393,6 +393,27 @@ func NewECDHPublicKey(creationTime time.Time, pub *ecdh.PublicKey) *PublicKey {
 	return pk
 }
 
+// NewEdDSAPublicKey returns a PublicKey that wraps the given Ed25519
+// public key. This library only supports EdDSA over Curve25519, so
+// unlike NewECDSAPublicKey/NewECDHPublicKey there's no curve to select.
+func NewEdDSAPublicKey(creationTime time.Time, pub ed25519.PublicKey) *PublicKey {
+	pk := &PublicKey{
+		CreationTime: creationTime,
+		PubKeyAlgo:   PubKeyAlgoEdDSA,
+		PublicKey:    pub,
+		edk:          new(edDSAkey),
+	}
+	pk.edk.oid = oidEdDSA
+	// MPI-encode the point the same way Curve25519 ECDH keys do (see
+	// ecdh.Marshal): a 0x40 tag byte followed by the raw 32-byte value.
+	bs, bitLen := ecdh.Marshal(curve25519.Cv25519(), new(big.Int).SetBytes(pub), new(big.Int))
+	pk.edk.p.bytes = bs
+	pk.edk.p.bitLength = uint16(bitLen)
+
+	pk.setFingerPrintAndKeyId()
+	return pk
+}
+
 func (pk *PublicKey) parse(r io.Reader) (err error) {
 	// RFC 4880, section 5.5.2
 	var buf [6]byte
@@ -913,6 +934,48 @@ func (pk *PublicKey) VerifyUserIdSignature(id string, pub *PublicKey, sig *Signa
 	return pk.VerifySignature(h, sig)
 }
 
+// userAttributeSignatureHash returns a Hash of the message that needs to be
+// signed to assert that pk is a valid key for the user attribute uat (e.g. a
+// photo ID). It's the same construction as userIdSignatureHash, but RFC
+// 4880, section 5.2.4, uses tag 0xd1 instead of 0xb4, and hashes the
+// serialized attribute packet body instead of an identity string.
+func userAttributeSignatureHash(uat *UserAttribute, pk *PublicKey, hashFunc crypto.Hash) (h hash.Hash, err error) {
+	if !hashFunc.Available() {
+		return nil, errors.UnsupportedError("hash function")
+	}
+	h = hashFunc.New()
+
+	pk.SerializeSignaturePrefix(h)
+	pk.serializeWithoutHeaders(h)
+
+	var body bytes.Buffer
+	if err = uat.serializeBody(&body); err != nil {
+		return nil, err
+	}
+
+	var buf [5]byte
+	buf[0] = 0xd1
+	buf[1] = byte(body.Len() >> 24)
+	buf[2] = byte(body.Len() >> 16)
+	buf[3] = byte(body.Len() >> 8)
+	buf[4] = byte(body.Len())
+	h.Write(buf[:])
+	h.Write(body.Bytes())
+
+	return h, nil
+}
+
+// VerifyUserAttributeSignature returns nil iff sig is a valid signature,
+// made by this public key, certifying uat (e.g. a photo ID) as belonging to
+// pub.
+func (pk *PublicKey) VerifyUserAttributeSignature(uat *UserAttribute, pub *PublicKey, sig *Signature) (err error) {
+	h, err := userAttributeSignatureHash(uat, pub, sig.Hash)
+	if err != nil {
+		return err
+	}
+	return pk.VerifySignature(h, sig)
+}
+
 // VerifyUserIdSignatureV3 returns nil iff sig is a valid signature, made by this
 // public key, that id is the identity of pub.
 func (pk *PublicKey) VerifyUserIdSignatureV3(id string, pub *PublicKey, sig *SignatureV3) (err error) {
@@ -52,6 +52,14 @@ type KeyFlagBits struct {
 	BitField byte
 }
 
+// NotationData represents a single notation data subpacket. See RFC 4880,
+// section 5.2.3.16.
+type NotationData struct {
+	Name            string
+	Value           string
+	IsHumanReadable bool
+}
+
 // Signature represents a signature. See RFC 4880, section 5.2.
 type Signature struct {
 	SigType    SignatureType
@@ -82,6 +90,7 @@ type Signature struct {
 	IssuerKeyId                                             *uint64
 	IsPrimaryId                                             *bool
 	IssuerFingerprint                                       []byte
+	NotationData                                            []NotationData
 
 	// FlagsValid is set if any flags were given. See RFC 4880, section
 	// 5.2.3.21 for details.
@@ -244,6 +253,7 @@ const (
 	signatureExpirationSubpacket signatureSubpacketType = 3
 	regularExpressionSubpacket   signatureSubpacketType = 6
 	keyExpirationSubpacket       signatureSubpacketType = 9
+	notationDataSubpacket        signatureSubpacketType = 20
 	prefSymmetricAlgosSubpacket  signatureSubpacketType = 11
 	revocationKey                signatureSubpacketType = 12
 	issuerSubpacket              signatureSubpacketType = 16
@@ -446,6 +456,27 @@ func parseSignatureSubpacket(sig *Signature, subpacket []byte, isHashed bool) (r
 		}
 	case prefKeyServerSubpacket:
 		sig.PreferredKeyServer = string(subpacket[:])
+	case notationDataSubpacket:
+		// Notation data, section 5.2.3.16: 4 bytes of flags, then
+		// 2-byte name length N, 2-byte value length M, N bytes of
+		// name, M bytes of value.
+		if len(subpacket) < 8 {
+			err = errors.StructuralError("notation data subpacket too short")
+			return
+		}
+		isHumanReadable := subpacket[0]&0x80 == 0x80
+		nameLen := int(binary.BigEndian.Uint16(subpacket[4:6]))
+		valueLen := int(binary.BigEndian.Uint16(subpacket[6:8]))
+		rest = subpacket[8:]
+		if len(rest) < nameLen+valueLen {
+			err = errors.StructuralError("notation data subpacket with bad length")
+			return
+		}
+		sig.NotationData = append(sig.NotationData, NotationData{
+			Name:            string(rest[:nameLen]),
+			Value:           string(rest[nameLen : nameLen+valueLen]),
+			IsHumanReadable: isHumanReadable,
+		})
 	case issuerFingerprint:
 		// The first byte is how many bytes the fingerprint is, but we'll just
 		// read until the end of the subpacket, so we'll ignore it.
@@ -697,6 +728,18 @@ func (sig *Signature) SignUserIdWithSigner(id string, pub *PublicKey, s Signer,
 	return sig.Sign(s, nil, config)
 }
 
+// SignUserAttribute computes a signature from priv, asserting that pub is a
+// valid key for the user attribute uat (e.g. a photo ID). On success, the
+// signature is stored in sig. Call Serialize to write it out.
+// If config is nil, sensible defaults will be used.
+func (sig *Signature) SignUserAttribute(uat *UserAttribute, pub *PublicKey, priv *PrivateKey, config *Config) error {
+	h, err := userAttributeSignatureHash(uat, pub, sig.Hash)
+	if err != nil {
+		return err
+	}
+	return sig.Sign(h, priv, config)
+}
+
 // SignKey computes a signature from priv, asserting that pub is a subkey. On
 // success, the signature is stored in sig. Call Serialize to write it out.
 // If config is nil, sensible defaults will be used.
@@ -64,12 +64,24 @@ func (uat *UserAttribute) parse(r io.Reader) (err error) {
 	return
 }
 
+// serializeBody writes the subpacket contents of uat to w, without a packet
+// header. This is also the body that's hashed when verifying or producing a
+// self-signature over a user attribute packet (RFC 4880, section 5.2.4).
+func (uat *UserAttribute) serializeBody(w io.Writer) (err error) {
+	for _, sp := range uat.Contents {
+		if err = sp.Serialize(w); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // Serialize marshals the user attribute to w in the form of an OpenPGP packet, including
 // header.
 func (uat *UserAttribute) Serialize(w io.Writer) (err error) {
 	var buf bytes.Buffer
-	for _, sp := range uat.Contents {
-		sp.Serialize(&buf)
+	if err = uat.serializeBody(&buf); err != nil {
+		return err
 	}
 	if err = serializeHeader(w, packetTypeUserAttribute, buf.Len()); err != nil {
 		return err